@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"mandor/internal/fs"
 )
 
 func FindProjectRoot() (string, error) {
@@ -12,18 +14,11 @@ func FindProjectRoot() (string, error) {
 		return "", fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	for {
-		mandorDir := filepath.Join(dir, ".mandor")
-		if _, err := os.Stat(mandorDir); err == nil {
-			return dir, nil
-		}
-
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			return "", fmt.Errorf("no .mandor directory found in current directory or any parent directory")
-		}
-		dir = parent
+	root, found := fs.FindWorkspaceRoot(dir)
+	if !found {
+		return "", fmt.Errorf("no .mandor directory found in current directory or any parent directory")
 	}
+	return root, nil
 }
 
 func FindProjectRootFrom(path string) (string, error) {
@@ -36,16 +31,9 @@ func FindProjectRootFrom(path string) (string, error) {
 		return "", fmt.Errorf("path does not exist: %s", path)
 	}
 
-	for {
-		mandorDir := filepath.Join(dir, ".mandor")
-		if _, err := os.Stat(mandorDir); err == nil {
-			return dir, nil
-		}
-
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			return "", fmt.Errorf("no .mandor directory found in path or any parent directory: %s", path)
-		}
-		dir = parent
+	root, found := fs.FindWorkspaceRoot(dir)
+	if !found {
+		return "", fmt.Errorf("no .mandor directory found in path or any parent directory: %s", path)
 	}
+	return root, nil
 }
@@ -0,0 +1,64 @@
+package feature
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+var (
+	commentProjectID string
+	commentAuthor    string
+	commentReplyTo   string
+)
+
+func NewCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment <feature_id> <text> [--project <id>] [--author <name>] [--reply-to <comment_id>]",
+		Short: "Leave a comment on a feature",
+		Long:  "Append a progress note or answer to a feature. Comments are never edited; read them with `mandor feature detail --comments`.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewFeatureService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			featureID, err := svc.ResolveFeatureID(commentProjectID, args[0])
+			if err != nil {
+				return err
+			}
+
+			input := &domain.CommentInput{
+				EntityID: featureID,
+				Author:   commentAuthor,
+				Body:     args[1],
+				ReplyTo:  commentReplyTo,
+			}
+
+			if err := svc.ValidateCommentInput(input); err != nil {
+				return err
+			}
+
+			comment, err := svc.CommentFeature(input)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Comment added: %s (on %s)\n", comment.ID, comment.EntityID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&commentProjectID, "project", "p", "", "Project ID (optional, extracted from feature ID)")
+	cmd.Flags().StringVar(&commentAuthor, "author", "", "Comment author (default: git user)")
+	cmd.Flags().StringVar(&commentReplyTo, "reply-to", "", "ID of the comment this one answers")
+
+	return cmd
+}
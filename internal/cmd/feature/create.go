@@ -2,6 +2,7 @@ package feature
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"mandor/internal/domain"
@@ -16,11 +17,13 @@ var (
 	scope     string
 	priority  string
 	dependsOn string
+	assignee  string
+	labels    string
 )
 
 func NewCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create <name> --project <id> --goal <text>",
+		Use:   "create <name> --project <id> --goal <text> [--assignee <name>] [--labels <labels>]",
 		Short: "Create a new feature",
 		Long:  "Create a new feature in the specified project with the given name and goal.",
 		Args:  cobra.ExactArgs(1),
@@ -45,7 +48,15 @@ func NewCreateCmd() *cobra.Command {
 
 			var dependsOnList []string
 			if dependsOn != "" {
-				dependsOnList = splitDependsOn(dependsOn)
+				dependsOnList, err = svc.ResolveFeatureIDs(splitDependsOn(dependsOn))
+				if err != nil {
+					return err
+				}
+			}
+
+			labelList, err := service.ParseLabels(labels)
+			if err != nil {
+				return err
 			}
 
 			input := &domain.FeatureCreateInput{
@@ -55,6 +66,8 @@ func NewCreateCmd() *cobra.Command {
 				Scope:     scope,
 				Priority:  priority,
 				DependsOn: dependsOnList,
+				Assignee:  assignee,
+				Labels:    labelList,
 			}
 
 			if err := svc.ValidateCreateInput(input); err != nil {
@@ -74,6 +87,12 @@ func NewCreateCmd() *cobra.Command {
 			fmt.Fprintf(out, "  Scope:    %s\n", feature.Scope)
 			fmt.Fprintf(out, "  Priority: %s\n", feature.Priority)
 			fmt.Fprintf(out, "  Status:   %s\n", feature.Status)
+			if feature.Assignee != "" {
+				fmt.Fprintf(out, "  Assignee: %s\n", feature.Assignee)
+			}
+			if len(feature.Labels) > 0 {
+				fmt.Fprintf(out, "  Labels:   %s\n", strings.Join(feature.Labels, ", "))
+			}
 
 			_, warning := util.GetGitUsernameWithWarning()
 			if warning != "" {
@@ -90,8 +109,10 @@ func NewCreateCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&goal, "goal", "g", "", "Feature goal (required, min 300 chars, include technical user flow and complete requirements)")
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Feature name (alternative to positional)")
 	cmd.Flags().StringVar(&scope, "scope", "", "Feature scope (frontend, backend, fullstack, cli, desktop, android, flutter, react-native, ios, swift)")
-	cmd.Flags().StringVar(&priority, "priority", "", "Priority (P0-P5, default from config)")
+	cmd.Flags().StringVar(&priority, "priority", "", "Priority (one of the project's levels, default from schema.json)")
 	cmd.Flags().StringVar(&dependsOn, "depends", "", "Pipe-separated feature IDs this feature depends on")
+	cmd.Flags().StringVar(&labels, "labels", "", "Labels (comma separated)")
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Person or agent the feature is assigned to")
 
 	return cmd
 }
@@ -3,6 +3,7 @@ package feature
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"mandor/internal/domain"
@@ -10,13 +11,15 @@ import (
 )
 
 var (
-	detailProjectID string
-	detailJSON      bool
+	detailProjectID    string
+	detailJSON         bool
+	detailComments     bool
+	detailLastComments int
 )
 
 func NewDetailCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "detail <feature_id> [--project <id>]",
+		Use:   "detail <feature_id> [--project <id>] [--json] [--comments] [--last <n>]",
 		Short: "Show feature details",
 		Long:  "Show detailed information about a specific feature.",
 		Args:  cobra.ExactArgs(1),
@@ -35,13 +38,17 @@ func NewDetailCmd() *cobra.Command {
 				return domain.NewValidationError("Project ID is required (--project).")
 			}
 
-			featureID := args[0]
+			featureID, err := svc.ResolveFeatureID(projectID, args[0])
+			if err != nil {
+				return err
+			}
 
 			input := &domain.FeatureDetailInput{
 				ProjectID:      projectID,
 				FeatureID:      featureID,
 				JSON:           detailJSON,
 				IncludeDeleted: false,
+				LastComments:   detailLastComments,
 			}
 
 			output, err := svc.GetFeatureDetail(input)
@@ -64,6 +71,13 @@ func NewDetailCmd() *cobra.Command {
 			fmt.Fprintf(out, "  Scope:     %s\n", output.Scope)
 			fmt.Fprintf(out, "  Priority:  %s\n", output.Priority)
 			fmt.Fprintf(out, "  Status:    %s\n", output.Status)
+			if output.Assignee != "" {
+				fmt.Fprintf(out, "  Assignee:  %s\n", output.Assignee)
+			}
+			if len(output.Labels) > 0 {
+				fmt.Fprintf(out, "  Labels:    %s\n", strings.Join(output.Labels, ", "))
+			}
+			fmt.Fprintf(out, "  Progress:  %d/%d tasks (%d%%)\n", output.Progress.Done, output.Progress.Total, output.Progress.Percent)
 			fmt.Fprintf(out, "  DependsOn: %v\n", output.DependsOn)
 			fmt.Fprintf(out, "  Reason:    %s\n", output.Reason)
 			fmt.Fprintf(out, "  Events:    %d\n", output.Events)
@@ -72,12 +86,21 @@ func NewDetailCmd() *cobra.Command {
 			fmt.Fprintf(out, "  CreatedBy: %s\n", output.CreatedBy)
 			fmt.Fprintf(out, "  UpdatedBy: %s\n", output.UpdatedBy)
 
+			if detailComments || detailLastComments > 0 {
+				fmt.Fprintf(out, "  Comments (%d of %d):\n", len(output.Comments), output.CommentCount)
+				for _, comment := range output.Comments {
+					fmt.Fprintf(out, "    %s\n", domain.FormatComment(comment))
+				}
+			}
+
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&detailProjectID, "project", "p", "", "Project ID (required)")
 	cmd.Flags().BoolVar(&detailJSON, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&detailComments, "comments", false, "Include comments")
+	cmd.Flags().IntVar(&detailLastComments, "last", 0, "Only the last N comments (implies --comments)")
 
 	return cmd
 }
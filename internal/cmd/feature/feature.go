@@ -15,6 +15,7 @@ func NewFeatureCmd() *cobra.Command {
 	cmd.AddCommand(NewListCmd())
 	cmd.AddCommand(NewDetailCmd())
 	cmd.AddCommand(NewUpdateCmd())
+	cmd.AddCommand(NewCommentCmd())
 
 	return cmd
 }
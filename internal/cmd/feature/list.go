@@ -11,13 +11,15 @@ import (
 )
 
 var (
-	listProjectID string
-	listJSON      bool
+	listProjectID  string
+	listJSON       bool
+	listLabels     []string
+	listLabelMatch string
 )
 
 func NewListCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list [--project <id>]",
+		Use:   "list [--project <id>] [--label <labels> [--label-match all|any]]",
 		Short: "List features",
 		Long:  "List all features in the specified project.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -35,10 +37,20 @@ func NewListCmd() *cobra.Command {
 				return domain.NewValidationError("Project ID is required (--project).")
 			}
 
+			labels, err := service.ParseLabels(listLabels...)
+			if err != nil {
+				return err
+			}
+			if err := service.ValidateLabelMatch(listLabelMatch); err != nil {
+				return err
+			}
+
 			input := &domain.FeatureListInput{
 				ProjectID:      projectID,
 				IncludeDeleted: false,
 				JSON:           listJSON,
+				Labels:         labels,
+				LabelMatch:     listLabelMatch,
 			}
 
 			output, err := svc.ListFeatures(input)
@@ -55,7 +67,7 @@ func NewListCmd() *cobra.Command {
 
 			out := cmd.OutOrStdout()
 			fmt.Fprintf(out, "Features in %s:\n", projectID)
-			fmt.Fprintf(out, "%-30s %-6s %-10s %s\n", "ID", "Priority", "Status", "Name")
+			fmt.Fprintf(out, "%-30s %-6s %-10s %-9s %s\n", "ID", "Priority", "Status", "Progress", "Name")
 			fmt.Fprintln(out, strings.Repeat("-", 80))
 
 			for _, f := range output.Features {
@@ -63,7 +75,8 @@ func NewListCmd() *cobra.Command {
 				if len(name) > 40 {
 					name = name[:37] + "..."
 				}
-				fmt.Fprintf(out, "%-30s %-6s %-10s %s\n", f.ID, f.Priority, f.Status, name)
+				progress := fmt.Sprintf("%d/%d", f.Progress.Done, f.Progress.Total)
+				fmt.Fprintf(out, "%-30s %-6s %-10s %-9s %s\n", f.ID, f.Priority, f.Status, progress, name)
 			}
 
 			fmt.Fprintf(out, "\nTotal: %d\n", output.Total)
@@ -73,6 +86,8 @@ func NewListCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&listProjectID, "project", "p", "", "Project ID (required)")
+	cmd.Flags().StringSliceVar(&listLabels, "label", nil, "Filter by label (comma separated or repeated)")
+	cmd.Flags().StringVar(&listLabelMatch, "label-match", "all", "With several --label values: all or any")
 	cmd.Flags().BoolVar(&listJSON, "json", false, "Output as JSON")
 
 	return cmd
@@ -1,6 +1,7 @@
 package feature
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -18,19 +19,46 @@ var (
 	updateStatus    string
 	updateReason    string
 	updateDependsOn string
+	updateAssignee  string
+	updateLabels    string
+	updateLabelsAdd string
+	updateLabelsRm  string
 	updateReopen    bool
 	updateCancel    bool
+	updateStart     bool
+	updateComplete  bool
+	updateCascade   bool
+	updateRewire    string
+	updateKeep      bool
 	updateForce     bool
 	updateDryRun    bool
+	updateJSON      bool
 	updateYes       bool
 )
 
 func NewUpdateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "update <feature_id> [--project <id>] [--name] [--goal] [--scope] [--priority] [--status] [--cancel --reason] [--reopen] [--depends]",
+		Use:   "update <feature_id> [--project <id>] [--name] [--goal] [--scope] [--priority] [--status] [--start] [--complete] [--cancel --reason [--cascade|--rewire <id>|--keep-blocked]] [--reopen] [--depends] [--assignee <name>] [--labels|--labels-add|--labels-remove <labels>]",
 		Short: "Update a feature",
-		Long:  "Update feature properties, change status, cancel, or reopen.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Update feature properties, change status, cancel, or reopen.
+
+Status flow: draft → active → done. Any feature that is not cancelled can be
+blocked or cancelled, including a done one; a blocked feature returns to draft
+or active once its dependencies are done. A feature can only be done when none
+of its tasks are open. A cancelled feature changes only through --reopen.
+
+A feature that other open features depend on can only be cancelled with a
+policy for those dependents:
+  --cascade        cancel every dependent, recursively, with the same reason;
+                   active dependents are skipped with a warning
+  --rewire <id>    make the dependents depend on <id> instead
+  --keep-blocked   leave the dependents blocked until someone intervenes
+Combine with --dry-run to list every affected feature first.
+
+--dry-run runs the whole update without writing anything and prints the
+field changes, the status transition and the dependents that would be
+unblocked or blocked again. Add --json for machine-readable output.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			svc, err := service.NewFeatureService()
 			if err != nil {
@@ -46,14 +74,29 @@ func NewUpdateCmd() *cobra.Command {
 				return domain.NewValidationError("Project ID is required (--project).")
 			}
 
-			featureID := args[0]
+			featureID, err := svc.ResolveFeatureID(projectID, args[0])
+			if err != nil {
+				return err
+			}
 
 			var dependsOnList *[]string
 			if updateDependsOn != "" {
-				list := splitDependsOn(updateDependsOn)
+				list, err := svc.ResolveFeatureIDs(splitDependsOn(updateDependsOn))
+				if err != nil {
+					return err
+				}
 				dependsOnList = &list
 			}
 
+			var rewirePtr *string
+			if cmd.Flags().Changed("rewire") {
+				rewire, err := svc.ResolveFeatureID("", updateRewire)
+				if err != nil {
+					return err
+				}
+				rewirePtr = &rewire
+			}
+
 			var namePtr, goalPtr, scopePtr, priorityPtr, statusPtr, reasonPtr *string
 			if updateName != "" {
 				namePtr = &updateName
@@ -74,26 +117,76 @@ func NewUpdateCmd() *cobra.Command {
 				reasonPtr = &updateReason
 			}
 
+			var labelsPtr, labelsAddPtr, labelsRemovePtr *[]string
+			if cmd.Flags().Changed("labels") {
+				labels, err := service.ParseLabels(updateLabels)
+				if err != nil {
+					return err
+				}
+				labelsPtr = &labels
+			}
+			if updateLabelsAdd != "" {
+				labels, err := service.ParseLabels(updateLabelsAdd)
+				if err != nil {
+					return err
+				}
+				labelsAddPtr = &labels
+			}
+			if updateLabelsRm != "" {
+				labels, err := service.ParseLabels(updateLabelsRm)
+				if err != nil {
+					return err
+				}
+				labelsRemovePtr = &labels
+			}
+
+			var assigneePtr *string
+			if cmd.Flags().Changed("assignee") {
+				assigneePtr = &updateAssignee
+			}
+
 			input := &domain.FeatureUpdateInput{
-				ProjectID: projectID,
-				FeatureID: featureID,
-				Name:      namePtr,
-				Goal:      goalPtr,
-				Scope:     scopePtr,
-				Priority:  priorityPtr,
-				Status:    statusPtr,
-				Reason:    reasonPtr,
-				DependsOn: dependsOnList,
-				Reopen:    updateReopen,
-				Cancel:    updateCancel,
-				Force:     updateForce,
-				DryRun:    updateDryRun,
+				ProjectID:    projectID,
+				FeatureID:    featureID,
+				Name:         namePtr,
+				Goal:         goalPtr,
+				Scope:        scopePtr,
+				Priority:     priorityPtr,
+				Status:       statusPtr,
+				Reason:       reasonPtr,
+				DependsOn:    dependsOnList,
+				Assignee:     assigneePtr,
+				Labels:       labelsPtr,
+				LabelsAdd:    labelsAddPtr,
+				LabelsRemove: labelsRemovePtr,
+				Reopen:       updateReopen,
+				Cancel:       updateCancel,
+				Start:        updateStart,
+				Complete:     updateComplete,
+				Cascade:      updateCascade,
+				Rewire:       rewirePtr,
+				KeepBlocked:  updateKeep,
+				Force:        updateForce,
+				DryRun:       updateDryRun,
 			}
 
 			if err := svc.ValidateUpdateInput(input); err != nil {
 				return err
 			}
 
+			if updateJSON {
+				if !updateDryRun {
+					return domain.NewValidationError("--json requires --dry-run.")
+				}
+				preview, err := svc.PreviewFeatureUpdate(input)
+				if err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(preview)
+			}
+
 			changes, err := svc.UpdateFeature(input)
 			if err != nil {
 				return err
@@ -101,16 +194,20 @@ func NewUpdateCmd() *cobra.Command {
 
 			out := cmd.OutOrStdout()
 			if updateDryRun {
-				fmt.Fprintln(out, "[DRY RUN] Changes:")
-			} else {
-				fmt.Fprintln(out, "Feature updated:", featureID)
+				fmt.Fprintln(out, changes[0])
+				for _, change := range changes[1:] {
+					fmt.Fprintf(out, "  - %s\n", change)
+				}
+				return nil
 			}
+
+			fmt.Fprintln(out, "Feature updated:", featureID)
 			for _, change := range changes {
 				fmt.Fprintf(out, "  - %s\n", change)
 			}
 
 			_, warning := util.GetGitUsernameWithWarning()
-			if warning != "" && !updateDryRun {
+			if warning != "" {
 				fmt.Fprintln(out)
 				fmt.Fprintln(out, warning)
 				fmt.Fprintln(out, "  Run: git config user.name \"Your Name\"")
@@ -124,14 +221,24 @@ func NewUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&updateName, "name", "", "New feature name")
 	cmd.Flags().StringVar(&updateGoal, "goal", "", "New feature goal")
 	cmd.Flags().StringVar(&updateScope, "scope", "", "New scope (frontend, backend, fullstack, cli, desktop, android, flutter, react-native, ios, swift)")
-	cmd.Flags().StringVar(&updatePriority, "priority", "", "New priority (P0-P5)")
+	cmd.Flags().StringVar(&updatePriority, "priority", "", "New priority (one of the project's levels)")
 	cmd.Flags().StringVar(&updateStatus, "status", "", "New status (draft, active, done, blocked, cancelled)")
 	cmd.Flags().StringVar(&updateReason, "reason", "", "Cancellation reason (required with --cancel)")
 	cmd.Flags().StringVar(&updateDependsOn, "depends", "", "Pipe-separated feature IDs this feature depends on")
-	cmd.Flags().BoolVar(&updateReopen, "reopen", false, "Reopen a cancelled feature")
+	cmd.Flags().StringVar(&updateAssignee, "assignee", "", "Assign the feature (empty string to unassign)")
+	cmd.Flags().StringVar(&updateLabels, "labels", "", "Set all labels (comma separated, empty string to clear)")
+	cmd.Flags().StringVar(&updateLabelsAdd, "labels-add", "", "Add labels (comma separated)")
+	cmd.Flags().StringVar(&updateLabelsRm, "labels-remove", "", "Remove labels (comma separated)")
+	cmd.Flags().BoolVar(&updateReopen, "reopen", false, "Reopen a done or cancelled feature")
 	cmd.Flags().BoolVar(&updateCancel, "cancel", false, "Cancel the feature")
-	cmd.Flags().BoolVar(&updateForce, "force", false, "Force operation (e.g., cancel with dependents)")
+	cmd.Flags().BoolVar(&updateStart, "start", false, "Start working (draft → active)")
+	cmd.Flags().BoolVar(&updateComplete, "complete", false, "Mark as done (active → done)")
+	cmd.Flags().BoolVar(&updateCascade, "cascade", false, "When cancelling, also cancel every dependent feature recursively")
+	cmd.Flags().StringVar(&updateRewire, "rewire", "", "When cancelling, move dependent features onto this feature")
+	cmd.Flags().BoolVar(&updateKeep, "keep-blocked", false, "When cancelling, keep dependent features blocked")
+	cmd.Flags().BoolVar(&updateForce, "force", false, "Same as --keep-blocked")
 	cmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show what would be changed without making changes")
+	cmd.Flags().BoolVar(&updateJSON, "json", false, "With --dry-run, print the preview as JSON")
 	cmd.Flags().BoolVarP(&updateYes, "yes", "y", false, "Skip confirmation")
 
 	return cmd
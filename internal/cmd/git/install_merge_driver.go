@@ -0,0 +1,45 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/service"
+)
+
+// NewInstallMergeDriverCmd creates the git install-merge-driver command
+func NewInstallMergeDriverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-merge-driver",
+		Short: "Register the Mandor merge driver for .mandor JSONL files",
+		Long: `Register ` + "`mandor merge-driver`" + ` with git so concurrent edits of
+.mandor/**/*.jsonl merge per entity instead of conflicting line by line.
+
+Adds a merge=mandor rule to .gitattributes at the workspace root (commit it so
+the team shares it) and sets merge.mandor.driver in the repository's
+.git/config (each clone runs this once).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewMergeService()
+			if err != nil {
+				return err
+			}
+
+			result, err := svc.InstallMergeDriver()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if result.AttributesUpdated {
+				fmt.Fprintf(out, "✓ Added merge rule to %s\n", result.Attributes)
+			} else {
+				fmt.Fprintf(out, "✓ Merge rule already present in %s\n", result.Attributes)
+			}
+			fmt.Fprintf(out, "✓ Configured git merge driver: %s\n", result.Driver)
+			return nil
+		},
+	}
+
+	return cmd
+}
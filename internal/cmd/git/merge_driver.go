@@ -0,0 +1,33 @@
+package git
+
+import (
+	"github.com/spf13/cobra"
+	"mandor/internal/service"
+)
+
+// NewMergeDriverCmd creates the merge-driver command invoked by git
+func NewMergeDriverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge-driver <base> <ours> <theirs>",
+		Short: "Three-way merge of a .mandor JSONL file (git merge driver)",
+		Long: `Three-way merge of a .mandor JSONL file, called by git as
+` + "`mandor merge-driver %O %A %B`" + `. The result is written to <ours>.
+
+Entity files (tasks.jsonl, features.jsonl, issues.jsonl, project.jsonl) are
+merged by id, field by field. When both sides changed the same field, the
+side with the newer updated_at wins. events.jsonl is union-merged and ordered
+by ts.
+
+Install with ` + "`mandor git install-merge-driver`" + `.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewMergeService()
+			if err != nil {
+				return err
+			}
+			return svc.MergeFiles(args[0], args[1], args[2])
+		},
+	}
+
+	return cmd
+}
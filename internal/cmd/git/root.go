@@ -0,0 +1,17 @@
+package git
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewGitCmd creates the git integration command group
+func NewGitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "git",
+		Short: "Git integration commands",
+	}
+
+	cmd.AddCommand(NewInstallMergeDriverCmd())
+
+	return cmd
+}
@@ -12,15 +12,17 @@ import (
 )
 
 var (
-	blockedProjectID string
-	blockedType      string
-	blockedPriority  string
-	blockedJSON      bool
+	blockedProjectID  string
+	blockedType       string
+	blockedPriority   string
+	blockedJSON       bool
+	blockedLabels     []string
+	blockedLabelMatch string
 )
 
 func NewBlockedCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "blocked [--project <id>] [--type <type>] [--priority <priority>] [--json]",
+		Use:   "blocked [--project <id>] [--type <type>] [--priority <priority>] [--label <labels> [--label-match all|any]] [--json]",
 		Short: "List blocked issues",
 		Long:  "List all issues with status='blocked' that are waiting for dependencies to complete.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,8 +55,22 @@ func NewBlockedCmd() *cobra.Command {
 				return domain.NewValidationError("Invalid issue type. Valid types: bug, improvement, debt, security, performance")
 			}
 
-			if blockedPriority != "" && !domain.ValidatePriority(blockedPriority) {
-				return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+			priorities, err := svc.PriorityScheme(projectID)
+			if err != nil {
+				return err
+			}
+			if blockedPriority != "" {
+				if err := priorities.Validate(blockedPriority); err != nil {
+					return err
+				}
+			}
+
+			labels, err := service.ParseLabels(blockedLabels...)
+			if err != nil {
+				return err
+			}
+			if err := service.ValidateLabelMatch(blockedLabelMatch); err != nil {
+				return err
 			}
 
 			input := &domain.IssueListInput{
@@ -66,6 +82,8 @@ func NewBlockedCmd() *cobra.Command {
 				JSON:           blockedJSON,
 				Sort:           "priority",
 				Order:          "asc",
+				Labels:         labels,
+				LabelMatch:     blockedLabelMatch,
 			}
 
 			output, err := svc.ListIssues(input)
@@ -77,7 +95,7 @@ func NewBlockedCmd() *cobra.Command {
 
 			// Sort by priority (P0 first)
 			sort.Slice(issues, func(i, j int) bool {
-				return comparePriority(issues[i].Priority, issues[j].Priority) < 0
+				return comparePriority(priorities, issues[i].Priority, issues[j].Priority) < 0
 			})
 
 			out := cmd.OutOrStdout()
@@ -126,7 +144,9 @@ func NewBlockedCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&blockedProjectID, "project", "p", "", "Project ID filter")
 	cmd.Flags().StringVar(&blockedType, "type", "", "Filter by issue type (bug, improvement, debt, security, performance)")
-	cmd.Flags().StringVar(&blockedPriority, "priority", "", "Filter by priority (P0-P5)")
+	cmd.Flags().StringVar(&blockedPriority, "priority", "", "Filter by priority (one of the project's levels)")
+	cmd.Flags().StringSliceVar(&blockedLabels, "label", nil, "Filter by label (comma separated or repeated)")
+	cmd.Flags().StringVar(&blockedLabelMatch, "label-match", "all", "With several --label values: all or any")
 	cmd.Flags().BoolVar(&blockedJSON, "json", false, "Output as JSON")
 
 	return cmd
@@ -0,0 +1,64 @@
+package issue
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+var (
+	commentProjectID string
+	commentAuthor    string
+	commentReplyTo   string
+)
+
+func NewCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment <issue_id> <text> [--project <id>] [--author <name>] [--reply-to <comment_id>]",
+		Short: "Leave a comment on an issue",
+		Long:  "Append a progress note or answer to an issue. Comments are never edited; read them with `mandor issue detail --comments`.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewIssueService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			issueID, err := svc.ResolveIssueID(commentProjectID, args[0])
+			if err != nil {
+				return err
+			}
+
+			input := &domain.CommentInput{
+				EntityID: issueID,
+				Author:   commentAuthor,
+				Body:     args[1],
+				ReplyTo:  commentReplyTo,
+			}
+
+			if err := svc.ValidateCommentInput(input); err != nil {
+				return err
+			}
+
+			comment, err := svc.CommentIssue(input)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Comment added: %s (on %s)\n", comment.ID, comment.EntityID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&commentProjectID, "project", "p", "", "Project ID (optional, extracted from issue ID)")
+	cmd.Flags().StringVar(&commentAuthor, "author", "", "Comment author (default: git user)")
+	cmd.Flags().StringVar(&commentReplyTo, "reply-to", "", "ID of the comment this one answers")
+
+	return cmd
+}
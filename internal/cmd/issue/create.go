@@ -21,12 +21,14 @@ var (
 	createAffectedTests string
 	createImplSteps     string
 	createLibraries     string
+	createAssignee      string
+	createLabels        string
 	createYes           bool
 )
 
 func NewCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create <name> --project <id> --type <type> --goal <text> --affected-files <files> --affected-tests <tests> --implementation-steps <steps> [--priority <P0-P5>] [--depends-on <ids>] [--library-needs <libs>] [-y]",
+		Use:   "create <name> --project <id> --type <type> --goal <text> --affected-files <files> --affected-tests <tests> --implementation-steps <steps> [--priority <P0-P5>] [--depends-on <ids>] [--library-needs <libs>] [--assignee <name>] [--labels <labels>] [-y]",
 		Short: "Create a new issue",
 		Long:  "Create a new issue in the specified project with the given details.",
 		Args:  cobra.ExactArgs(1),
@@ -74,7 +76,15 @@ func NewCreateCmd() *cobra.Command {
 
 			var dependsOnList []string
 			if createDependsOn != "" {
-				dependsOnList = splitByPipe(createDependsOn)
+				dependsOnList, err = svc.ResolveIssueIDs(splitByPipe(createDependsOn))
+				if err != nil {
+					return err
+				}
+			}
+
+			labels, err := service.ParseLabels(createLabels)
+			if err != nil {
+				return err
 			}
 
 			input := &domain.IssueCreateInput{
@@ -88,6 +98,8 @@ func NewCreateCmd() *cobra.Command {
 				AffectedTests:       affectedTests,
 				ImplementationSteps: implSteps,
 				LibraryNeeds:        libraries,
+				Assignee:            createAssignee,
+				Labels:              labels,
 			}
 
 			if err := svc.ValidateCreateInput(input); err != nil {
@@ -113,6 +125,12 @@ func NewCreateCmd() *cobra.Command {
 			if len(issue.DependsOn) > 0 {
 				fmt.Fprintf(out, "  Depends on:         %d issue(s)\n", len(issue.DependsOn))
 			}
+			if issue.Assignee != "" {
+				fmt.Fprintf(out, "  Assignee:           %s\n", issue.Assignee)
+			}
+			if len(issue.Labels) > 0 {
+				fmt.Fprintf(out, "  Labels:             %s\n", strings.Join(issue.Labels, ", "))
+			}
 
 			_, warning := util.GetGitUsernameWithWarning()
 			if warning != "" {
@@ -129,12 +147,14 @@ func NewCreateCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&createType, "type", "t", "", "Issue type: bug, improvement, debt, security, performance (required, use -t or --type)")
 	cmd.Flags().StringVar(&createName, "name", "", "Issue name (required for CLI, or use positional argument)")
 	cmd.Flags().StringVarP(&createGoal, "goal", "g", "", "Issue goal (required, min 200 chars, include problem description, impact analysis, and acceptance criteria)")
-	cmd.Flags().StringVar(&createPriority, "priority", "", "Priority (P0-P5, default from config)")
+	cmd.Flags().StringVar(&createPriority, "priority", "", "Priority (one of the project's levels, default from schema.json)")
 	cmd.Flags().StringVar(&createDependsOn, "depends-on", "", "Pipe-separated issue IDs this issue depends on")
 	cmd.Flags().StringVar(&createAffectedFiles, "affected-files", "", "Pipe-separated affected files (required)")
 	cmd.Flags().StringVar(&createAffectedTests, "affected-tests", "", "Pipe-separated affected tests (required)")
 	cmd.Flags().StringVar(&createImplSteps, "implementation-steps", "", "Pipe-separated implementation steps (required)")
 	cmd.Flags().StringVar(&createLibraries, "library-needs", "", "Pipe-separated required libraries (optional)")
+	cmd.Flags().StringVar(&createAssignee, "assignee", "", "Person or agent the issue is assigned to")
+	cmd.Flags().StringVar(&createLabels, "labels", "", "Labels (comma separated)")
 	cmd.Flags().BoolVarP(&createYes, "yes", "y", false, "Skip confirmation prompts")
 
 	return cmd
@@ -16,11 +16,13 @@ var (
 	detailIncludeDeleted bool
 	detailEvents         bool
 	detailTimestamps     bool
+	detailComments       bool
+	detailLastComments   int
 )
 
 func NewDetailCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "detail <issue_id> [--project <id>] [--json] [--include-deleted] [--events]",
+		Use:   "detail <issue_id> [--project <id>] [--json] [--include-deleted] [--events] [--comments] [--last <n>]",
 		Short: "Show issue details",
 		Long:  "Show detailed information about an issue.",
 		Args:  cobra.ExactArgs(1),
@@ -34,7 +36,10 @@ func NewDetailCmd() *cobra.Command {
 				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
 			}
 
-			issueID := args[0]
+			issueID, err := svc.ResolveIssueID(detailProjectID, args[0])
+			if err != nil {
+				return err
+			}
 
 			projectID := detailProjectID
 			if projectID == "" {
@@ -56,6 +61,7 @@ func NewDetailCmd() *cobra.Command {
 				IncludeDeleted: detailIncludeDeleted,
 				Events:         detailEvents,
 				Timestamps:     detailTimestamps,
+				LastComments:   detailLastComments,
 			}
 
 			output, err := svc.GetIssueDetail(input)
@@ -86,6 +92,12 @@ func NewDetailCmd() *cobra.Command {
 			fmt.Fprintf(out, "  Priority:    %s\n", output.Priority)
 			fmt.Fprintf(out, "  Status:      %s\n", output.Status)
 			fmt.Fprintf(out, "  Project:     %s\n", output.ProjectID)
+			if output.Assignee != "" {
+				fmt.Fprintf(out, "  Assignee:    %s\n", output.Assignee)
+			}
+			if len(output.Labels) > 0 {
+				fmt.Fprintf(out, "  Labels:      %s\n", strings.Join(output.Labels, ", "))
+			}
 
 			if output.Goal != "" {
 				fmt.Fprintf(out, "\n  Goal:        %s\n", output.Goal)
@@ -140,11 +152,21 @@ func NewDetailCmd() *cobra.Command {
 				events, _ := svc.GetIssueEvents(projectID, issueID)
 				for _, event := range events {
 					fmt.Fprintf(out, "    %s [%s] by %s\n", event.Ts.Format("2006-01-02 15:04:05"), event.Type, event.By)
+					for _, change := range event.Diff {
+						fmt.Fprintf(out, "      %s\n", domain.FormatFieldChange(change))
+					}
 				}
 			} else {
 				fmt.Fprintf(out, "\n  Events:      %d\n", output.Events)
 			}
 
+			if detailComments || detailLastComments > 0 {
+				fmt.Fprintf(out, "\n  Comments:    %d of %d\n", len(output.Comments), output.CommentCount)
+				for _, comment := range output.Comments {
+					fmt.Fprintf(out, "    %s\n", domain.FormatComment(comment))
+				}
+			}
+
 			return nil
 		},
 	}
@@ -154,6 +176,8 @@ func NewDetailCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&detailIncludeDeleted, "include-deleted", false, "Include cancelled issues")
 	cmd.Flags().BoolVar(&detailEvents, "events", false, "Show event history")
 	cmd.Flags().BoolVar(&detailTimestamps, "timestamps", false, "Show all timestamps")
+	cmd.Flags().BoolVar(&detailComments, "comments", false, "Show comments")
+	cmd.Flags().IntVar(&detailLastComments, "last", 0, "Only the last N comments (implies --comments)")
 
 	return cmd
 }
@@ -17,6 +17,7 @@ func NewIssueCmd() *cobra.Command {
 	cmd.AddCommand(NewUpdateCmd())
 	cmd.AddCommand(NewReadyCmd())
 	cmd.AddCommand(NewBlockedCmd())
+	cmd.AddCommand(NewCommentCmd())
 
 	return cmd
 }
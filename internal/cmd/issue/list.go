@@ -12,19 +12,23 @@ import (
 )
 
 var (
-	listProjectID string
-	listType      string
-	listStatus    string
-	listPriority  string
-	listJSON      bool
-	listSort      string
-	listOrder     string
-	listVerbose   bool
+	listProjectID  string
+	listType       string
+	listStatus     string
+	listPriority   string
+	listJSON       bool
+	listSort       string
+	listOrder      string
+	listVerbose    bool
+	listAssignee   string
+	listMine       bool
+	listLabels     []string
+	listLabelMatch string
 )
 
 func NewListCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list [--project <id>] [--type <type>] [--status <status>] [--priority <priority>] [--json] [--sort <field>] [--order <asc|desc>]",
+		Use:   "list [--project <id>] [--type <type>] [--status <status>] [--priority <priority>] [--assignee <name> | --mine] [--label <labels> [--label-match all|any]] [--json] [--sort <field>] [--order <asc|desc>]",
 		Short: "List issues",
 		Long:  "List issues in the specified project with optional filters.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,6 +57,19 @@ func NewListCmd() *cobra.Command {
 				return domain.NewValidationError("Project not found: " + projectID)
 			}
 
+			assignee, err := service.ResolveAssigneeFilter(listAssignee, listMine)
+			if err != nil {
+				return err
+			}
+
+			labels, err := service.ParseLabels(listLabels...)
+			if err != nil {
+				return err
+			}
+			if err := service.ValidateLabelMatch(listLabelMatch); err != nil {
+				return err
+			}
+
 			input := &domain.IssueListInput{
 				ProjectID:      projectID,
 				IssueType:      listType,
@@ -62,6 +79,9 @@ func NewListCmd() *cobra.Command {
 				JSON:           listJSON,
 				Sort:           listSort,
 				Order:          listOrder,
+				Assignee:       assignee,
+				Labels:         labels,
+				LabelMatch:     listLabelMatch,
 			}
 
 			if listType != "" && !domain.ValidateIssueType(listType) {
@@ -72,8 +92,14 @@ func NewListCmd() *cobra.Command {
 				return domain.NewValidationError("Invalid status. Valid options: open, ready, in_progress, blocked, resolved, wontfix, cancelled")
 			}
 
-			if listPriority != "" && !domain.ValidatePriority(listPriority) {
-				return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+			priorities, err := svc.PriorityScheme(projectID)
+			if err != nil {
+				return err
+			}
+			if listPriority != "" {
+				if err := priorities.Validate(listPriority); err != nil {
+					return err
+				}
 			}
 
 			output, err := svc.ListIssues(input)
@@ -91,7 +117,7 @@ func NewListCmd() *cobra.Command {
 				sort.Slice(issues, func(i, j int) bool {
 					switch sortField {
 					case "priority":
-						return comparePriority(issues[i].Priority, issues[j].Priority) < 0
+						return comparePriority(priorities, issues[i].Priority, issues[j].Priority) < 0
 					case "name":
 						return issues[i].Name < issues[j].Name
 					case "created_at", "last_updated_at":
@@ -103,7 +129,7 @@ func NewListCmd() *cobra.Command {
 				if listOrder == "asc" {
 					if listSort == "priority" {
 						sort.Slice(issues, func(i, j int) bool {
-							return comparePriority(issues[i].Priority, issues[j].Priority) > 0
+							return comparePriority(priorities, issues[i].Priority, issues[j].Priority) > 0
 						})
 					} else {
 						for i, j := 0, len(issues)-1; i < j; i, j = i+1, j-1 {
@@ -186,7 +212,11 @@ func NewListCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&listProjectID, "project", "p", "", "Project ID filter")
 	cmd.Flags().StringVar(&listType, "type", "", "Filter by issue type")
 	cmd.Flags().StringVar(&listStatus, "status", "", "Filter by status")
-	cmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority (P0-P5)")
+	cmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority (one of the project's levels)")
+	cmd.Flags().StringVar(&listAssignee, "assignee", "", "Filter by assignee")
+	cmd.Flags().BoolVar(&listMine, "mine", false, "Only issues assigned to the git user")
+	cmd.Flags().StringSliceVar(&listLabels, "label", nil, "Filter by label (comma separated or repeated)")
+	cmd.Flags().StringVar(&listLabelMatch, "label-match", "all", "With several --label values: all or any")
 	cmd.Flags().BoolVar(&listJSON, "json", false, "Output as JSON")
 	cmd.Flags().StringVar(&listSort, "sort", "last_updated_at", "Sort field (created_at, last_updated_at, priority, name)")
 	cmd.Flags().StringVar(&listOrder, "order", "desc", "Sort order (asc, desc)")
@@ -195,17 +225,8 @@ func NewListCmd() *cobra.Command {
 	return cmd
 }
 
-func comparePriority(a, b string) int {
-	order := []string{"P0", "P1", "P2", "P3", "P4", "P5"}
-	ai, bj := -1, -1
-	for i, p := range order {
-		if a == p {
-			ai = i
-		}
-		if b == p {
-			bj = i
-		}
-	}
+func comparePriority(priorities *service.PriorityScheme, a, b string) int {
+	ai, bj := priorities.Rank(a), priorities.Rank(b)
 	if ai < bj {
 		return -1
 	}
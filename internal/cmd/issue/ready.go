@@ -12,15 +12,19 @@ import (
 )
 
 var (
-	readyProjectID string
-	readyType      string
-	readyPriority  string
-	readyJSON      bool
+	readyProjectID  string
+	readyType       string
+	readyPriority   string
+	readyJSON       bool
+	readyAssignee   string
+	readyMine       bool
+	readyLabels     []string
+	readyLabelMatch string
 )
 
 func NewReadyCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "ready [--project <id>] [--type <type>] [--priority <priority>] [--json]",
+		Use:   "ready [--project <id>] [--type <type>] [--priority <priority>] [--assignee <name> | --mine] [--label <labels> [--label-match all|any]] [--json]",
 		Short: "List ready issues",
 		Long:  "List all issues with status='ready' that are available to work on (no blocking dependencies).",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -53,8 +57,27 @@ func NewReadyCmd() *cobra.Command {
 				return domain.NewValidationError("Invalid issue type. Valid types: bug, improvement, debt, security, performance")
 			}
 
-			if readyPriority != "" && !domain.ValidatePriority(readyPriority) {
-				return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+			priorities, err := svc.PriorityScheme(projectID)
+			if err != nil {
+				return err
+			}
+			if readyPriority != "" {
+				if err := priorities.Validate(readyPriority); err != nil {
+					return err
+				}
+			}
+
+			assignee, err := service.ResolveAssigneeFilter(readyAssignee, readyMine)
+			if err != nil {
+				return err
+			}
+
+			labels, err := service.ParseLabels(readyLabels...)
+			if err != nil {
+				return err
+			}
+			if err := service.ValidateLabelMatch(readyLabelMatch); err != nil {
+				return err
 			}
 
 			input := &domain.IssueListInput{
@@ -66,6 +89,9 @@ func NewReadyCmd() *cobra.Command {
 				JSON:           readyJSON,
 				Sort:           "priority",
 				Order:          "asc",
+				Assignee:       assignee,
+				Labels:         labels,
+				LabelMatch:     readyLabelMatch,
 			}
 
 			output, err := svc.ListIssues(input)
@@ -77,7 +103,7 @@ func NewReadyCmd() *cobra.Command {
 
 			// Sort by priority (P0 first)
 			sort.Slice(issues, func(i, j int) bool {
-				return comparePriority(issues[i].Priority, issues[j].Priority) < 0
+				return comparePriority(priorities, issues[i].Priority, issues[j].Priority) < 0
 			})
 
 			out := cmd.OutOrStdout()
@@ -126,7 +152,11 @@ func NewReadyCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&readyProjectID, "project", "p", "", "Project ID filter")
 	cmd.Flags().StringVar(&readyType, "type", "", "Filter by issue type (bug, improvement, debt, security, performance)")
-	cmd.Flags().StringVar(&readyPriority, "priority", "", "Filter by priority (P0-P5)")
+	cmd.Flags().StringVar(&readyPriority, "priority", "", "Filter by priority (one of the project's levels)")
+	cmd.Flags().StringVar(&readyAssignee, "assignee", "", "Filter by assignee")
+	cmd.Flags().BoolVar(&readyMine, "mine", false, "Only issues assigned to the git user")
+	cmd.Flags().StringSliceVar(&readyLabels, "label", nil, "Filter by label (comma separated or repeated)")
+	cmd.Flags().StringVar(&readyLabelMatch, "label-match", "all", "With several --label values: all or any")
 	cmd.Flags().BoolVar(&readyJSON, "json", false, "Output as JSON")
 
 	return cmd
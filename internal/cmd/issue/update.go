@@ -1,6 +1,7 @@
 package issue
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -24,6 +25,10 @@ var (
 	updateAffectedTests string
 	updateImplSteps     string
 	updateLibraries     string
+	updateAssignee      string
+	updateLabels        string
+	updateLabelsAdd     string
+	updateLabelsRemove  string
 	updateStart         bool
 	updateResolve       bool
 	updateWontFix       bool
@@ -31,14 +36,19 @@ var (
 	updateCancel        bool
 	updateForce         bool
 	updateDryRun        bool
+	updateJSON          bool
 )
 
 func NewUpdateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "update <issue_id> [--name <text>] [--goal <text>] [--type <type>] [--priority <P0-P5>] [--status <status>] [--reason <text>] [--depends-on <ids>] [--affected-files <files>] [--affected-tests <tests>] [--implementation-steps <steps>] [--library-needs <libs>] [--start] [--resolve] [--wontfix] [--reopen] [--cancel] [--force] [--dry-run]",
+		Use:   "update <issue_id> [--name <text>] [--goal <text>] [--type <type>] [--priority <P0-P5>] [--status <status>] [--reason <text>] [--depends-on <ids>] [--affected-files <files>] [--affected-tests <tests>] [--implementation-steps <steps>] [--library-needs <libs>] [--assignee <name>] [--labels|--labels-add|--labels-remove <labels>] [--start] [--resolve] [--wontfix] [--reopen] [--cancel] [--force] [--dry-run [--json]]",
 		Short: "Update an issue",
-		Long:  "Update an issue's metadata, status, or dependencies.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Update an issue's metadata, status, or dependencies.
+
+--dry-run runs the whole update without writing anything and prints the
+field changes, the status transition and the dependents that would be
+unblocked or blocked again. Add --json for machine-readable output.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			svc, err := service.NewIssueService()
 			if err != nil {
@@ -49,7 +59,10 @@ func NewUpdateCmd() *cobra.Command {
 				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
 			}
 
-			issueID := args[0]
+			issueID, err := svc.ResolveIssueID(updateProjectID, args[0])
+			if err != nil {
+				return err
+			}
 
 			projectID := updateProjectID
 			if projectID == "" {
@@ -94,17 +107,26 @@ func NewUpdateCmd() *cobra.Command {
 			}
 
 			if updateDependsOn != "" {
-				deps := splitByPipe(updateDependsOn)
+				deps, err := svc.ResolveIssueIDs(splitByPipe(updateDependsOn))
+				if err != nil {
+					return err
+				}
 				input.DependsOn = &deps
 			}
 
 			if updateDependsAdd != "" {
-				deps := splitByPipe(updateDependsAdd)
+				deps, err := svc.ResolveIssueIDs(splitByPipe(updateDependsAdd))
+				if err != nil {
+					return err
+				}
 				input.DependsAdd = &deps
 			}
 
 			if updateDependsRemove != "" {
-				deps := splitByPipe(updateDependsRemove)
+				deps, err := svc.ResolveIssueIDs(splitByPipe(updateDependsRemove))
+				if err != nil {
+					return err
+				}
 				input.DependsRemove = &deps
 			}
 
@@ -128,6 +150,32 @@ func NewUpdateCmd() *cobra.Command {
 				input.LibraryNeeds = &libs
 			}
 
+			if cmd.Flags().Changed("assignee") {
+				input.Assignee = &updateAssignee
+			}
+
+			if cmd.Flags().Changed("labels") {
+				labels, err := service.ParseLabels(updateLabels)
+				if err != nil {
+					return err
+				}
+				input.Labels = &labels
+			}
+			if updateLabelsAdd != "" {
+				labels, err := service.ParseLabels(updateLabelsAdd)
+				if err != nil {
+					return err
+				}
+				input.LabelsAdd = &labels
+			}
+			if updateLabelsRemove != "" {
+				labels, err := service.ParseLabels(updateLabelsRemove)
+				if err != nil {
+					return err
+				}
+				input.LabelsRemove = &labels
+			}
+
 			input.Start = updateStart
 			input.Resolve = updateResolve
 			input.WontFix = updateWontFix
@@ -140,6 +188,19 @@ func NewUpdateCmd() *cobra.Command {
 				return err
 			}
 
+			if updateJSON {
+				if !updateDryRun {
+					return domain.NewValidationError("--json requires --dry-run.")
+				}
+				preview, err := svc.PreviewIssueUpdate(input)
+				if err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(preview)
+			}
+
 			changes, err := svc.UpdateIssue(input)
 			if err != nil {
 				return err
@@ -148,11 +209,9 @@ func NewUpdateCmd() *cobra.Command {
 			out := cmd.OutOrStdout()
 
 			if updateDryRun {
-				if len(changes) == 0 {
-					fmt.Fprintf(out, "[DRY RUN] No changes to issue: %s\n", issueID)
-				} else {
-					fmt.Fprintf(out, "[DRY RUN] Would update issue: %s\n", issueID)
-					fmt.Fprintf(out, "  Changes: %s\n", strings.Join(changes, ", "))
+				fmt.Fprintln(out, changes[0])
+				for _, change := range changes[1:] {
+					fmt.Fprintf(out, "  - %s\n", change)
 				}
 				return nil
 			}
@@ -249,7 +308,7 @@ func NewUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&updateName, "name", "", "Update issue name")
 	cmd.Flags().StringVar(&updateGoal, "goal", "", "Update issue goal")
 	cmd.Flags().StringVar(&updateType, "type", "", "Update issue type (bug/improvement/debt/security/performance)")
-	cmd.Flags().StringVar(&updatePriority, "priority", "", "Update priority (P0-P5)")
+	cmd.Flags().StringVar(&updatePriority, "priority", "", "Update priority (one of the project's levels)")
 	cmd.Flags().StringVar(&updateStatus, "status", "", "Set status directly")
 	cmd.Flags().StringVar(&updateReason, "reason", "", "Reason for status change")
 	cmd.Flags().StringVar(&updateDependsOn, "depends-on", "", "Replace dependencies")
@@ -259,6 +318,10 @@ func NewUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&updateAffectedTests, "affected-tests", "", "Replace affected tests")
 	cmd.Flags().StringVar(&updateImplSteps, "implementation-steps", "", "Replace implementation steps")
 	cmd.Flags().StringVar(&updateLibraries, "library-needs", "", "Replace library needs")
+	cmd.Flags().StringVar(&updateAssignee, "assignee", "", "Assign the issue (empty string to unassign)")
+	cmd.Flags().StringVar(&updateLabels, "labels", "", "Set all labels (comma separated, empty string to clear)")
+	cmd.Flags().StringVar(&updateLabelsAdd, "labels-add", "", "Add labels (comma separated)")
+	cmd.Flags().StringVar(&updateLabelsRemove, "labels-remove", "", "Remove labels (comma separated)")
 	cmd.Flags().BoolVar(&updateStart, "start", false, "Start working (open/ready → in_progress)")
 	cmd.Flags().BoolVar(&updateResolve, "resolve", false, "Mark as resolved")
 	cmd.Flags().BoolVar(&updateWontFix, "wontfix", false, "Mark as wontfix")
@@ -266,6 +329,7 @@ func NewUpdateCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&updateCancel, "cancel", false, "Cancel issue")
 	cmd.Flags().BoolVar(&updateForce, "force", false, "Force operation (skip checks)")
 	cmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show what would change")
+	cmd.Flags().BoolVar(&updateJSON, "json", false, "With --dry-run, print the preview as JSON")
 
 	return cmd
 }
@@ -78,6 +78,7 @@ func outputPopulate(cmd *cobra.Command) error {
   Flags:
     --project, -p     Filter by specific project ID
     --summary, -s     Show compact summary view (counts only)
+                      Both views list open work per assignee
     --json, -j        Machine-readable JSON output
   
   Examples:
@@ -177,9 +178,11 @@ func outputPopulate(cmd *cobra.Command) error {
   Optional Flags:
     --name, -n <text>     Update project name
     --goal, -g <text>     Update project goal
+    --allowed-labels <l>  Comma separated labels entities may use ("" allows any)
   
   Example:
     mandor project update api --goal "Enhanced API with new features..."
+    mandor project update api --allowed-labels auth,tech-debt,needs-human
 
 ───────────────────────────────────────────────────────────────────────
 
@@ -203,6 +206,18 @@ func outputPopulate(cmd *cobra.Command) error {
   Example:
     mandor project reopen legacy
 
+───────────────────────────────────────────────────────────────────────
+
+▶ mandor project complete <project_id>
+  Mark a project as done
+  
+  Every feature must be done or cancelled and every issue resolved,
+  wontfix or cancelled. A project becomes active on its own when its
+  first feature becomes active or its first task starts.
+  
+  Example:
+    mandor project complete legacy
+
 ═════════════════════════════════════════════════════════════════════════
  3. FEATURE COMMANDS
 ═════════════════════════════════════════════════════════════════════════
@@ -222,6 +237,8 @@ func outputPopulate(cmd *cobra.Command) error {
                                                   android|flutter|react-native|ios|swift)
     --priority <P0-P5>             Priority level (default from config)
     --depends <ids>                Pipe-separated feature IDs for dependencies
+    --assignee <name>              Person or agent the feature is assigned to
+    --labels <labels>              Comma separated labels
     --yes, -y                      Skip confirmation
   
   Example:
@@ -241,10 +258,13 @@ func outputPopulate(cmd *cobra.Command) error {
   
   Flags:
     --project, -p <id>    Filter by project
+    --label <labels>      Filter by label (all must match)
+    --label-match any     Match any of the --label values instead
     --json, -j            JSON output
   
-  Example:
+  Examples:
     mandor feature list --project api
+    mandor feature list --project api --label auth
 
 ───────────────────────────────────────────────────────────────────────
 
@@ -253,12 +273,22 @@ func outputPopulate(cmd *cobra.Command) error {
   
   Flags:
     --project, -p <id>    Project ID (required)
+    --comments            Show comments
+    --last <n>            Only the last n comments (implies --comments)
   
   Example:
     mandor feature detail api-feature-abc123 --project api
 
 ───────────────────────────────────────────────────────────────────────
 
+▶ mandor feature comment <feature_id> "<text>" [--author <name>] [--reply-to <comment_id>]
+  Leave a progress note on a feature (author defaults to git user.name)
+  
+  Example:
+    mandor feature comment api-feature-abc123 "Scope agreed with the API team"
+
+───────────────────────────────────────────────────────────────────────
+
 ▶ mandor feature update <feature_id> --project <id> [OPTIONS]
   Update feature properties or status
   
@@ -272,10 +302,17 @@ func outputPopulate(cmd *cobra.Command) error {
     --priority <P0-P5>          Update priority
     --status <status>           Set status (draft|active|done|blocked|cancelled)
     --depends <ids>             Update dependencies (pipe-separated IDs)
+    --assignee <name>           Assign the feature ("" to unassign)
+    --labels <labels>           Set all labels ("" to clear)
+    --labels-add <labels>       Add labels
+    --labels-remove <labels>    Remove labels
     --cancel --reason <text>    Cancel with reason (audit trail)
     --reopen                    Reopen cancelled feature
-    --force                     Force cancel even with dependents
+    --cascade                   With --cancel: cancel dependents recursively
+    --rewire <id>               With --cancel: move dependents onto <id>
+    --keep-blocked              With --cancel: keep dependents blocked
     --dry-run                   Preview changes without saving
+    --json                      With --dry-run: print the preview as JSON
   
   Example:
     mandor feature update api-feature-abc123 \
@@ -306,6 +343,8 @@ func outputPopulate(cmd *cobra.Command) error {
   Optional Flags:
     --priority <P0-P5>             Priority level (default from config)
     --depends-on <ids>             Pipe-separated task IDs for dependencies
+    --assignee <name>              Person or agent the task is assigned to
+    --labels <labels>              Comma separated labels
     --yes, -y                      Skip confirmation
   
   Example:
@@ -329,10 +368,16 @@ func outputPopulate(cmd *cobra.Command) error {
     --project, -p <id>    Filter by project
     --status <status>     Filter by status (pending|ready|in_progress|done|blocked|cancelled)
     --priority <P0-P5>    Filter by priority
+    --assignee <name>     Filter by assignee
+    --mine                Only tasks assigned to your git user.name
+    --label <labels>      Filter by label (all must match)
+    --label-match any     Match any of the --label values instead
     --json, -j            JSON output
   
   Examples:
     mandor task list --project api
+    mandor task list --label auth,api
+    mandor task list --mine
     mandor task list --feature api-feature-auth --status ready
     mandor task list --project api --priority P0
 
@@ -348,8 +393,26 @@ func outputPopulate(cmd *cobra.Command) error {
     - Status and dependencies
     - Creation/update information
   
+  Optional Flags:
+    --events          Show event history
+    --comments        Show comments
+    --last <n>        Only the last n comments (implies --comments)
+  
   Example:
     mandor task detail api-feature-auth-task-abc123
+    mandor task detail api-feature-auth-task-abc123 --last 5
+
+───────────────────────────────────────────────────────────────────────
+
+▶ mandor task comment <task_id> "<text>" [--author <name>] [--reply-to <comment_id>]
+  Leave a progress note on a task
+  
+  Comments are appended to the project's comments.jsonl and never edited.
+  --author defaults to git user.name; --reply-to answers an earlier comment
+  on the same task. detail --json always includes the comments.
+  
+  Example:
+    mandor task comment abc123 "Handler done, tests next" --author agent-1
 
 ───────────────────────────────────────────────────────────────────────
 
@@ -365,12 +428,20 @@ func outputPopulate(cmd *cobra.Command) error {
     --test-cases <cases>            Update test cases (pipe-separated)
     --derivable-files <files>       Update output files (pipe-separated)
     --library-needs <libs>          Update library requirements
+    --assignee <name>               Assign the task ("" to unassign)
+    --labels <labels>               Set all labels ("" to clear)
+    --labels-add <labels>           Add labels
+    --labels-remove <labels>        Remove labels
     --depends-on <ids>              Set dependencies (replace all)
     --depends-add <ids>             Add dependencies (additive)
     --depends-remove <ids>          Remove dependencies
     --cancel --reason <text>        Cancel with reason
+    --cascade                       With --cancel: cancel dependents recursively
+    --rewire <id>                   With --cancel: move dependents onto <id>
+    --keep-blocked                  With --cancel: keep dependents blocked
     --reopen                        Reopen cancelled task
     --dry-run                       Preview without saving
+    --json                          With --dry-run: print the preview as JSON
   
   Example:
     mandor task update api-feature-auth-task-abc123 \
@@ -386,10 +457,15 @@ func outputPopulate(cmd *cobra.Command) error {
     --project, -p <id>    Filter by project
     --feature, -f <id>    Filter by feature
     --priority <P0-P5>    Filter by priority
+    --assignee <name>     Filter by assignee
+    --mine                Only tasks assigned to your git user.name
+    --label <labels>      Filter by label (all must match)
+    --label-match any     Match any of the --label values instead
     --json, -j            JSON output
   
-  Example:
+  Examples:
     mandor task ready --project api --priority P0
+    mandor task ready --mine
 
 ───────────────────────────────────────────────────────────────────────
 
@@ -400,11 +476,39 @@ func outputPopulate(cmd *cobra.Command) error {
     --project, -p <id>    Filter by project
     --feature, -f <id>    Filter by feature
     --priority <P0-P5>    Filter by priority
+    --label <labels>      Filter by label (--label-match any for OR)
     --json, -j            JSON output
   
   Example:
     mandor task blocked --project api
 
+───────────────────────────────────────────────────────────────────────
+
+▶ mandor task claim --agent <name> [--project <id>] [--feature <id>] [--lease <duration>]
+  Atomically pick the most urgent ready task, move it to in_progress and
+  hold it for the agent until the lease runs out
+  
+  Flags:
+    --agent <name>        Agent claiming the task (required)
+    --project, -p <id>    Only claim tasks in this project
+    --feature, -f <id>    Only claim tasks in this feature
+    --lease <duration>    Lease length (default: 30m)
+    --json                JSON output
+  
+  Example:
+    mandor task claim --project api --agent agent-1 --lease 30m
+
+───────────────────────────────────────────────────────────────────────
+
+▶ mandor task heartbeat <task_id> --agent <name> [--lease <duration>]
+  Extend the lease on a claimed task (counted from now)
+
+▶ mandor task release <task_id> --agent <name>
+  Clear the claim and return the task to ready
+  
+  Claims whose lease has run out return to ready the next time any
+  mandor command runs, with a system lease_expired event.
+
 ═════════════════════════════════════════════════════════════════════════
  5. ISSUE COMMANDS
 ═════════════════════════════════════════════════════════════════════════
@@ -427,6 +531,8 @@ func outputPopulate(cmd *cobra.Command) error {
     --priority <P0-P5>             Priority level (default: P2)
     --depends-on <ids>             Pipe-separated issue IDs for dependencies
     --library-needs <libs>         Pipe-separated required libraries
+    --assignee <name>              Person or agent the issue is assigned to
+    --labels <labels>              Comma separated labels
     --yes, -y                      Skip confirmation
   
   Example:
@@ -451,10 +557,15 @@ func outputPopulate(cmd *cobra.Command) error {
     --type, -t <type>     Filter by type (bug|improvement|debt|security|performance)
     --status <status>     Filter by status (open|ready|in_progress|resolved|wontfix|cancelled)
     --priority <P0-P5>    Filter by priority
+    --assignee <name>     Filter by assignee
+    --mine                Only issues assigned to your git user.name
+    --label <labels>      Filter by label (all must match)
+    --label-match any     Match any of the --label values instead
     --json, -j            JSON output
   
   Examples:
     mandor issue list --project api
+    mandor issue list --project api --assignee alice
     mandor issue list --project api --type bug --priority P0
     mandor issue list --status open
 
@@ -465,12 +576,23 @@ func outputPopulate(cmd *cobra.Command) error {
   
   Optional Flags:
     --project, -p <id>    Project ID (auto-extracted if omitted)
+    --events              Show event history
+    --comments            Show comments
+    --last <n>            Only the last n comments (implies --comments)
   
   Example:
     mandor issue detail api-issue-abc123 --project api
 
 ───────────────────────────────────────────────────────────────────────
 
+▶ mandor issue comment <issue_id> "<text>" [--author <name>] [--reply-to <comment_id>]
+  Leave a progress note on an issue
+  
+  Example:
+    mandor issue comment api-issue-abc123 "Reproduced on main"
+
+───────────────────────────────────────────────────────────────────────
+
 ▶ mandor issue update <issue_id> [--project <id>] [OPTIONS]
   Update issue properties or status
   
@@ -489,12 +611,17 @@ func outputPopulate(cmd *cobra.Command) error {
     --affected-tests <tests>        Update affected tests
     --implementation-steps <steps>  Update implementation steps
     --library-needs <libs>          Update library needs
+    --assignee <name>               Assign the issue ("" to unassign)
+    --labels <labels>               Set all labels ("" to clear)
+    --labels-add <labels>           Add labels
+    --labels-remove <labels>        Remove labels
     --start                         Transition to in_progress
     --resolve                       Mark as resolved
     --wontfix --reason <text>       Mark as wontfix with reason
     --reopen                        Reopen issue
     --cancel --reason <text>        Cancel issue
     --dry-run                       Preview without saving
+    --json                          With --dry-run: print the preview as JSON
   
   Examples:
     mandor issue update api-issue-abc123 --resolve
@@ -510,6 +637,10 @@ func outputPopulate(cmd *cobra.Command) error {
     --project, -p <id>    Filter by project
     --type, -t <type>     Filter by type
     --priority <P0-P5>    Filter by priority
+    --assignee <name>     Filter by assignee
+    --mine                Only issues assigned to your git user.name
+    --label <labels>      Filter by label (all must match)
+    --label-match any     Match any of the --label values instead
     --json, -j            JSON output
   
   Example:
@@ -524,6 +655,7 @@ func outputPopulate(cmd *cobra.Command) error {
     --project, -p <id>    Filter by project
     --type, -t <type>     Filter by type
     --priority <P0-P5>    Filter by priority
+    --label <labels>      Filter by label (--label-match any for OR)
     --json, -j            JSON output
   
   Example:
@@ -795,6 +927,11 @@ WORKFLOW 2: Find and Start Work
   4. (do work)
   5. mandor task update <task-id> --status done     # Mark complete
 
+  With several agents, claim instead of picking from the ready list:
+  1. mandor task claim --project api --agent <name> # Claim and start
+  2. mandor task heartbeat <task-id> --agent <name> # While working
+  3. mandor task update <task-id> --status done     # Mark complete
+
 WORKFLOW 3: Fix a Bug
   1. mandor issue create "Bug name" --project api --type bug --goal "..."
   2. mandor issue detail <issue-id>                 # Review
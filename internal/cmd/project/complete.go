@@ -0,0 +1,58 @@
+package project
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+func NewCompleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "complete <id>",
+		Short: "Mark a project as done",
+		Long: `Mark a project as done.
+
+Every feature must be done or cancelled and every issue resolved, wontfix or
+cancelled. Projects move from initial to active on their own once a feature
+becomes active or a task starts.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewProjectService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			input := &domain.ProjectCompleteInput{ID: args[0]}
+
+			if err := svc.ValidateCompleteInput(input); err != nil {
+				return err
+			}
+
+			result, err := svc.CompleteProject(input)
+			if err != nil {
+				return err
+			}
+
+			project, err := svc.GetProject(input.ID)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintln(out, result)
+			fmt.Fprintf(out, "  Name:   %s\n", project.Name)
+			fmt.Fprintf(out, "  Status: %s\n", project.Status)
+			fmt.Fprintf(out, "  By:     %s\n", project.UpdatedBy)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
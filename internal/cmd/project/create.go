@@ -112,7 +112,7 @@ func NewCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&taskDep, "task-dep", "same_project_only", "same_project_only,Task dependency rule ( cross_project_allowed, disabled)")
 	cmd.Flags().StringVar(&featureDep, "feature-dep", "cross_project_allowed", "Feature dependency rule (same_project_only, cross_project_allowed, disabled)")
 	cmd.Flags().StringVar(&issueDep, "issue-dep", "same_project_only", "Issue dependency rule (same_project_only, cross_project_allowed, disabled)")
-	cmd.Flags().BoolVar(&strict, "strict", false, "Enforce strict dependency rules")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Enforce strict mode rules for this project (see README: Strict Mode)")
 	cmd.Flags().BoolVarP(&yesFlag, "yes", "y", false, "Non-interactive mode")
 
 	return cmd
@@ -17,6 +17,7 @@ func NewProjectCmd() *cobra.Command {
 	cmd.AddCommand(NewUpdateCmd())
 	cmd.AddCommand(NewDeleteCmd())
 	cmd.AddCommand(NewReopenCmd())
+	cmd.AddCommand(NewCompleteCmd())
 
 	return cmd
 }
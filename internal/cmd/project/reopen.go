@@ -15,8 +15,8 @@ var yesReopen bool
 func NewReopenCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "reopen <id>",
-		Short: "Reopen a soft-deleted project",
-		Long:  "Reopen a soft-deleted project, restoring it to active state.",
+		Short: "Reopen a soft-deleted or done project",
+		Long:  "Reopen a soft-deleted project, restoring it to initial state, or a done project, moving it back to active.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			svc, err := service.NewProjectService()
@@ -46,7 +46,7 @@ func NewReopenCmd() *cobra.Command {
 			if !yesReopen {
 				fmt.Fprintf(out, "Reopen project: %s\n", input.ID)
 				fmt.Fprintf(out, "  Name: %s\n", project.Name)
-				fmt.Fprintf(out, "  Status: deleted\n\n")
+				fmt.Fprintf(out, "  Status: %s\n\n", project.Status)
 				fmt.Fprint(out, "Confirm reopen? [y/N]: ")
 				scanner := bufio.NewScanner(os.Stdin)
 				if scanner.Scan() {
@@ -63,9 +63,14 @@ func NewReopenCmd() *cobra.Command {
 				return err
 			}
 
+			project, err = svc.GetProject(input.ID)
+			if err != nil {
+				return err
+			}
+
 			fmt.Fprintln(out, result)
 			fmt.Fprintf(out, "  Name:   %s\n", project.Name)
-			fmt.Fprintf(out, "  Status: initial\n")
+			fmt.Fprintf(out, "  Status: %s\n", project.Status)
 			fmt.Fprintf(out, "  By:     %s\n", project.UpdatedBy)
 
 			return nil
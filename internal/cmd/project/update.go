@@ -2,6 +2,7 @@ package project
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"mandor/internal/domain"
@@ -15,6 +16,10 @@ var (
 	updateFeatureDep string
 	updateIssueDep   string
 	updateStrict     string
+	updateLevels     string
+	updateDefault    string
+	updateRollup     string
+	updateLabels     string
 )
 
 func NewUpdateCmd() *cobra.Command {
@@ -58,6 +63,15 @@ func NewUpdateCmd() *cobra.Command {
 			if updateIssueDep != "" {
 				input.IssueDep = &updateIssueDep
 			}
+			if updateLevels != "" {
+				input.PriorityLevels = &updateLevels
+			}
+			if updateDefault != "" {
+				input.PriorityDefault = &updateDefault
+			}
+			if cmd.Flags().Changed("allowed-labels") {
+				input.AllowedLabels = &updateLabels
+			}
 			if updateStrict != "" {
 				if !domain.ValidateBooleanValue(updateStrict) {
 					return domain.NewValidationError("Invalid value for --strict. Use: true, false, yes, no, 1, or 0.")
@@ -65,9 +79,17 @@ func NewUpdateCmd() *cobra.Command {
 				val := domain.ParseBooleanValue(updateStrict)
 				input.Strict = &val
 			}
+			if updateRollup != "" {
+				if !domain.ValidateBooleanValue(updateRollup) {
+					return domain.NewValidationError("Invalid value for --auto-complete-features. Use: true, false, yes, no, 1, or 0.")
+				}
+				val := domain.ParseBooleanValue(updateRollup)
+				input.AutoCompleteFeatures = &val
+			}
 
-			if input.Name == nil && input.Goal == nil && input.TaskDep == nil && input.FeatureDep == nil && input.IssueDep == nil && input.Strict == nil {
-				return domain.NewValidationError("No updates specified. Use --name, --goal, --task-dep, --feature-dep, --issue-dep, or --strict.")
+			if input.Name == nil && input.Goal == nil && input.TaskDep == nil && input.FeatureDep == nil && input.IssueDep == nil && input.Strict == nil &&
+				input.PriorityLevels == nil && input.PriorityDefault == nil && input.AutoCompleteFeatures == nil && input.AllowedLabels == nil {
+				return domain.NewValidationError("No updates specified. Use --name, --goal, --task-dep, --feature-dep, --issue-dep, --strict, --priority-levels, --priority-default, --auto-complete-features, or --allowed-labels.")
 			}
 
 			if err := svc.ValidateUpdateInput(input); err != nil {
@@ -110,6 +132,28 @@ func NewUpdateCmd() *cobra.Command {
 					fmt.Fprintf(out, "    - feature_dep: %s\n", updateFeatureDep)
 				case "issue_dep":
 					fmt.Fprintf(out, "    - issue_dep: %s\n", updateIssueDep)
+				case "priority_levels", "priority_default":
+					schema, err := svc.GetProjectSchema(args[0])
+					if err != nil {
+						return err
+					}
+					if change == "priority_levels" {
+						fmt.Fprintf(out, "    - priority_levels: %s\n", strings.Join(schema.Rules.Priority.Levels, ", "))
+					} else {
+						fmt.Fprintf(out, "    - priority_default: %s\n", schema.Rules.Priority.Default)
+					}
+				case "auto_complete_features":
+					fmt.Fprintf(out, "    - auto_complete_features: %t\n", *input.AutoCompleteFeatures)
+				case "allowed_labels":
+					schema, err := svc.GetProjectSchema(args[0])
+					if err != nil {
+						return err
+					}
+					if len(schema.Rules.Labels.Allowed) == 0 {
+						fmt.Fprintln(out, "    - allowed_labels: any")
+					} else {
+						fmt.Fprintf(out, "    - allowed_labels: %s\n", strings.Join(schema.Rules.Labels.Allowed, ", "))
+					}
 				}
 			}
 			fmt.Fprintf(out, "  Updated: %s\n", project.UpdatedAt.Format("2006-01-02T15:04:05Z"))
@@ -124,6 +168,10 @@ func NewUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&updateFeatureDep, "feature-dep", "", "Update feature dependency rule (same_project_only, cross_project_allowed, disabled)")
 	cmd.Flags().StringVar(&updateIssueDep, "issue-dep", "", "Update issue dependency rule (same_project_only, cross_project_allowed, disabled)")
 	cmd.Flags().StringVar(&updateStrict, "strict", "", "Toggle strict mode (true/false/yes/no/1/0)")
+	cmd.Flags().StringVar(&updateLevels, "priority-levels", "", "Comma separated priority levels, most urgent first (e.g. critical,high,normal,low)")
+	cmd.Flags().StringVar(&updateDefault, "priority-default", "", "Default priority for new entities (must be one of the levels)")
+	cmd.Flags().StringVar(&updateLabels, "allowed-labels", "", "Comma separated labels features, tasks and issues may use (empty allows any label)")
+	cmd.Flags().StringVar(&updateRollup, "auto-complete-features", "", "Mark active features done once their tasks are all done or cancelled and at least one is done (true/false/yes/no/1/0)")
 
 	return cmd
 }
@@ -7,12 +7,15 @@ import (
 	"github.com/spf13/cobra"
 	"mandor/internal/cmd/ai"
 	"mandor/internal/cmd/feature"
+	"mandor/internal/cmd/git"
 	"mandor/internal/cmd/issue"
 	"mandor/internal/cmd/populate"
 	"mandor/internal/cmd/project"
 	"mandor/internal/cmd/task"
 	"mandor/internal/cmd/workspace"
 	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/service"
 )
 
 // NewRootCmd creates the root command
@@ -23,13 +26,45 @@ func NewRootCmd() *cobra.Command {
 		Long: `Mandor is a CLI tool for deterministic, streaming-native task management.
 It provides schema-driven, event-based task management with dependency tracking.
 
+Commands run against the nearest parent directory containing .mandor, so they
+work from any subdirectory. Use --workspace or MANDOR_WORKSPACE to target a
+specific workspace from elsewhere.
+
 For more information, visit: https://github.com/budisantoso/mandor`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if skipsSchemaCheck(cmd) {
+				return nil
+			}
+			svc, err := service.NewMigrationService()
+			if err != nil {
+				return err
+			}
+			if err := svc.CheckSchemaVersion(); err != nil {
+				return err
+			}
+
+			// Claims whose lease ran out go back to ready before anything else
+			tasks, err := service.NewTaskService()
+			if err != nil {
+				return err
+			}
+			_, err = tasks.ReapExpiredLeases()
+			return err
+		},
 	}
 
+	rootCmd.PersistentFlags().StringVar(&fs.WorkspaceOverride, "workspace", "",
+		"Workspace root to operate on (default: nearest parent with .mandor, or $"+fs.WorkspaceEnv+")")
+
 	// Add workspace commands
 	rootCmd.AddCommand(workspace.NewInitCmd())
 	rootCmd.AddCommand(workspace.NewStatusCmd())
 	rootCmd.AddCommand(workspace.NewConfigCmd())
+	rootCmd.AddCommand(workspace.NewRebuildCmd())
+	rootCmd.AddCommand(workspace.NewDoctorCmd())
+	rootCmd.AddCommand(workspace.NewMigrateCmd())
+	rootCmd.AddCommand(workspace.NewExportCmd())
+	rootCmd.AddCommand(workspace.NewImportCmd())
 
 	// Add project commands
 	rootCmd.AddCommand(project.NewProjectCmd())
@@ -49,6 +84,10 @@ For more information, visit: https://github.com/budisantoso/mandor`,
 	// Add populate command
 	rootCmd.AddCommand(populate.NewPopulateCmd())
 
+	// Add git integration commands
+	rootCmd.AddCommand(git.NewGitCmd())
+	rootCmd.AddCommand(git.NewMergeDriverCmd())
+
 	// Add AI commands
 	rootCmd.AddCommand(ai.NewAICmd())
 
@@ -58,6 +97,19 @@ For more information, visit: https://github.com/budisantoso/mandor`,
 	return rootCmd
 }
 
+// skipsSchemaCheck reports whether cmd may run on a workspace with an older
+// on-disk schema: commands that create, upgrade, or never read the workspace.
+// merge-driver only sees the files git hands it.
+func skipsSchemaCheck(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "init", "migrate", "version", "completion", "help", "populate", "merge-driver", "git":
+			return true
+		}
+	}
+	return false
+}
+
 // ExecuteWithCode executes the command and returns the appropriate exit code
 func ExecuteWithCode() int {
 	rootCmd := NewRootCmd()
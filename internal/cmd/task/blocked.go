@@ -11,15 +11,17 @@ import (
 )
 
 var (
-	blockedProjectID string
-	blockedFeatureID string
-	blockedPriority  string
-	blockedJSON      bool
+	blockedProjectID  string
+	blockedFeatureID  string
+	blockedPriority   string
+	blockedJSON       bool
+	blockedLabels     []string
+	blockedLabelMatch string
 )
 
 func NewBlockedCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "blocked [--project <id>] [--feature <id>] [--priority <priority>] [--json]",
+		Use:   "blocked [--project <id>] [--feature <id>] [--priority <priority>] [--label <labels> [--label-match all|any]] [--json]",
 		Short: "List blocked tasks",
 		Long:  "List all tasks with status='blocked' that are waiting for dependencies to complete.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -32,10 +34,12 @@ func NewBlockedCmd() *cobra.Command {
 				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
 			}
 
-			if blockedPriority != "" {
-				if !domain.ValidatePriority(blockedPriority) {
-					return domain.NewValidationError(fmt.Sprintf("Invalid priority: '%s'. Valid values: P0, P1, P2, P3, P4, P5", blockedPriority))
-				}
+			labels, err := service.ParseLabels(blockedLabels...)
+			if err != nil {
+				return err
+			}
+			if err := service.ValidateLabelMatch(blockedLabelMatch); err != nil {
+				return err
 			}
 
 			input := &domain.TaskListInput{
@@ -47,6 +51,8 @@ func NewBlockedCmd() *cobra.Command {
 				JSON:           blockedJSON,
 				Sort:           "priority",
 				Order:          "asc",
+				Labels:         labels,
+				LabelMatch:     blockedLabelMatch,
 			}
 
 			output, err := svc.ListTasks(input)
@@ -110,7 +116,9 @@ func NewBlockedCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&blockedProjectID, "project", "p", "", "Filter by project ID")
 	cmd.Flags().StringVarP(&blockedFeatureID, "feature", "f", "", "Filter by feature ID")
-	cmd.Flags().StringVar(&blockedPriority, "priority", "", "Filter by priority (P0-P5)")
+	cmd.Flags().StringVar(&blockedPriority, "priority", "", "Filter by priority (one of the project's levels)")
+	cmd.Flags().StringSliceVar(&blockedLabels, "label", nil, "Filter by label (comma separated or repeated)")
+	cmd.Flags().StringVar(&blockedLabelMatch, "label-match", "all", "With several --label values: all or any")
 	cmd.Flags().BoolVar(&blockedJSON, "json", false, "Output as JSON")
 
 	return cmd
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+var (
+	claimProjectID string
+	claimFeatureID string
+	claimAgent     string
+	claimLease     time.Duration
+	claimJSON      bool
+)
+
+func NewClaimCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim --agent <name> [--project <id>] [--feature <id>] [--lease <duration>] [--json]",
+		Short: "Claim the next ready task",
+		Long: `Pick the most urgent ready task, move it to in_progress and hold it for
+--agent until the lease runs out. Several agents can claim at the same time;
+each gets a different task.
+
+Extend the lease with 'mandor task heartbeat' while working and give the task
+back with 'mandor task release'. A claim whose lease has run out returns to
+ready the next time any mandor command runs.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewTaskService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			featureID := claimFeatureID
+			if featureID != "" {
+				featureID, err = svc.ResolveFeatureID(featureID)
+				if err != nil {
+					return err
+				}
+			}
+
+			input := &domain.TaskClaimInput{
+				ProjectID: claimProjectID,
+				FeatureID: featureID,
+				Agent:     claimAgent,
+				Lease:     claimLease,
+			}
+
+			if err := svc.ValidateClaimInput(input); err != nil {
+				return err
+			}
+
+			task, err := svc.ClaimTask(input)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if claimJSON {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(task)
+			}
+
+			fmt.Fprintf(out, "Task claimed: %s\n", task.ID)
+			fmt.Fprintf(out, "  Name:     %s\n", task.Name)
+			fmt.Fprintf(out, "  Feature:  %s\n", task.FeatureID)
+			fmt.Fprintf(out, "  Priority: %s\n", task.Priority)
+			fmt.Fprintf(out, "  Agent:    %s\n", task.ClaimedBy)
+			fmt.Fprintf(out, "  Lease:    until %s\n", task.LeaseExpiresAt.Format(time.RFC3339))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&claimProjectID, "project", "p", "", "Only claim tasks in this project")
+	cmd.Flags().StringVarP(&claimFeatureID, "feature", "f", "", "Only claim tasks in this feature")
+	cmd.Flags().StringVar(&claimAgent, "agent", "", "Name of the agent claiming the task (required)")
+	cmd.Flags().DurationVar(&claimLease, "lease", 30*time.Minute, "How long the claim lasts without a heartbeat")
+	cmd.Flags().BoolVar(&claimJSON, "json", false, "Output the claimed task as JSON")
+
+	return cmd
+}
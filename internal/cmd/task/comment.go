@@ -0,0 +1,62 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+var (
+	commentAuthor  string
+	commentReplyTo string
+)
+
+func NewCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment <task_id> <text> [--author <name>] [--reply-to <comment_id>]",
+		Short: "Leave a comment on a task",
+		Long:  "Append a progress note or answer to a task. Comments are never edited; read them with `mandor task detail --comments`.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewTaskService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			taskID, err := svc.ResolveTaskID(args[0])
+			if err != nil {
+				return err
+			}
+
+			input := &domain.CommentInput{
+				EntityID: taskID,
+				Author:   commentAuthor,
+				Body:     args[1],
+				ReplyTo:  commentReplyTo,
+			}
+
+			if err := svc.ValidateCommentInput(input); err != nil {
+				return err
+			}
+
+			comment, err := svc.CommentTask(input)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Comment added: %s (on %s)\n", comment.ID, comment.EntityID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&commentAuthor, "author", "", "Comment author (default: git user)")
+	cmd.Flags().StringVar(&commentReplyTo, "reply-to", "", "ID of the comment this one answers")
+
+	return cmd
+}
@@ -19,12 +19,14 @@ var (
 	createLibraries string
 	createPriority  string
 	createDependsOn string
+	createAssignee  string
+	createLabels    string
 	createYes       bool
 )
 
 func NewCreateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create <name> --feature <id> --goal <text> --implementation-steps <steps> --test-cases <cases> --derivable-files <files> --library-needs <libs> [--priority <P0-P5>] [--depends-on <ids>] [-y]",
+		Use:   "create <name> --feature <id> --goal <text> --implementation-steps <steps> --test-cases <cases> --derivable-files <files> --library-needs <libs> [--priority <P0-P5>] [--depends-on <ids>] [--assignee <name>] [--labels <labels>] [-y]",
 		Short: "Create a new task",
 		Long:  "Create a new task in the specified feature with the given details.",
 		Args:  cobra.ExactArgs(1),
@@ -71,13 +73,26 @@ func NewCreateCmd() *cobra.Command {
 
 			libraries := splitByPipe(createLibraries)
 
+			featureID, err := svc.ResolveFeatureID(createFeatureID)
+			if err != nil {
+				return err
+			}
+
 			var dependsOnList []string
 			if createDependsOn != "" {
-				dependsOnList = splitByPipe(createDependsOn)
+				dependsOnList, err = svc.ResolveTaskIDs(splitByPipe(createDependsOn))
+				if err != nil {
+					return err
+				}
+			}
+
+			labels, err := service.ParseLabels(createLabels)
+			if err != nil {
+				return err
 			}
 
 			input := &domain.TaskCreateInput{
-				FeatureID:           createFeatureID,
+				FeatureID:           featureID,
 				Name:                args[0],
 				Goal:                createGoal,
 				ImplementationSteps: implSteps,
@@ -86,6 +101,8 @@ func NewCreateCmd() *cobra.Command {
 				LibraryNeeds:        libraries,
 				Priority:            createPriority,
 				DependsOn:           dependsOnList,
+				Assignee:            createAssignee,
+				Labels:              labels,
 			}
 
 			if err := svc.ValidateCreateInput(input); err != nil {
@@ -111,6 +128,12 @@ func NewCreateCmd() *cobra.Command {
 			if len(task.DependsOn) > 0 {
 				fmt.Fprintf(out, "  Depends on:         %d task(s)\n", len(task.DependsOn))
 			}
+			if task.Assignee != "" {
+				fmt.Fprintf(out, "  Assignee:           %s\n", task.Assignee)
+			}
+			if len(task.Labels) > 0 {
+				fmt.Fprintf(out, "  Labels:             %s\n", strings.Join(task.Labels, ", "))
+			}
 
 			_, warning := util.GetGitUsernameWithWarning()
 			if warning != "" {
@@ -129,8 +152,10 @@ func NewCreateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&createTestCases, "test-cases", "", "Test cases (pipe-separated, required)")
 	cmd.Flags().StringVar(&createDerivable, "derivable-files", "", "Derivable files (pipe-separated, required)")
 	cmd.Flags().StringVar(&createLibraries, "library-needs", "", "Required libraries (pipe-separated, required). Use \"none\" if no external libraries are needed.")
-	cmd.Flags().StringVar(&createPriority, "priority", "", "Priority (P0-P5, default from config)")
+	cmd.Flags().StringVar(&createPriority, "priority", "", "Priority (one of the project's levels, default from schema.json)")
 	cmd.Flags().StringVar(&createDependsOn, "depends-on", "", "Pipe-separated task IDs this task depends on")
+	cmd.Flags().StringVar(&createAssignee, "assignee", "", "Person or agent the task is assigned to")
+	cmd.Flags().StringVar(&createLabels, "labels", "", "Labels (comma separated)")
 	cmd.Flags().BoolVarP(&createYes, "yes", "y", false, "Skip confirmation prompts")
 
 	return cmd
@@ -3,6 +3,7 @@ package task
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"mandor/internal/domain"
@@ -14,11 +15,13 @@ var (
 	detailEvents       bool
 	detailDependencies bool
 	detailTimestamps   bool
+	detailComments     bool
+	detailLastComments int
 )
 
 func NewDetailCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "detail <task_id> [--json] [--events] [--dependencies] [--timestamps]",
+		Use:   "detail <task_id> [--json] [--events] [--dependencies] [--timestamps] [--comments] [--last <n>]",
 		Short: "Show task details",
 		Long:  "Show detailed information about a specific task.",
 		Args:  cobra.ExactArgs(1),
@@ -32,7 +35,10 @@ func NewDetailCmd() *cobra.Command {
 				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
 			}
 
-			taskID := args[0]
+			taskID, err := svc.ResolveTaskID(args[0])
+			if err != nil {
+				return err
+			}
 
 			input := &domain.TaskDetailInput{
 				TaskID:         taskID,
@@ -41,6 +47,7 @@ func NewDetailCmd() *cobra.Command {
 				Events:         detailEvents,
 				Dependencies:   detailDependencies,
 				Timestamps:     detailTimestamps,
+				LastComments:   detailLastComments,
 			}
 
 			output, err := svc.GetTaskDetail(input)
@@ -62,6 +69,12 @@ func NewDetailCmd() *cobra.Command {
 			fmt.Fprintf(out, "  Project:            %s\n", output.ProjectID)
 			fmt.Fprintf(out, "  Status:             %s\n", output.Status)
 			fmt.Fprintf(out, "  Priority:           %s\n", output.Priority)
+			if output.Assignee != "" {
+				fmt.Fprintf(out, "  Assignee:           %s\n", output.Assignee)
+			}
+			if len(output.Labels) > 0 {
+				fmt.Fprintf(out, "  Labels:             %s\n", strings.Join(output.Labels, ", "))
+			}
 			fmt.Fprintf(out, "  Goal:               %s\n", output.Goal)
 			fmt.Fprintf(out, "  Implementation Steps (%d):\n", len(output.ImplementationSteps))
 			for i, step := range output.ImplementationSteps {
@@ -85,12 +98,36 @@ func NewDetailCmd() *cobra.Command {
 					fmt.Fprintf(out, "    - %s\n", dep)
 				}
 			}
+			if output.ClaimedBy != "" {
+				fmt.Fprintf(out, "  Claimed By: %s (lease until %s)\n", output.ClaimedBy, output.LeaseExpiresAt)
+			}
 			fmt.Fprintf(out, "  Created:   %s\n", output.CreatedAt)
 			fmt.Fprintf(out, "  Updated:   %s\n", output.UpdatedAt)
 			fmt.Fprintf(out, "  CreatedBy: %s\n", output.CreatedBy)
 			fmt.Fprintf(out, "  UpdatedBy: %s\n", output.UpdatedBy)
 			fmt.Fprintf(out, "  Events:    %d\n", output.Events)
 
+			if detailEvents {
+				events, err := svc.GetTaskEvents(taskID)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "  History (%d):\n", len(events))
+				for _, event := range events {
+					fmt.Fprintf(out, "    %s [%s] by %s\n", event.Ts.Format("2006-01-02 15:04:05"), event.Type, event.By)
+					for _, change := range event.Diff {
+						fmt.Fprintf(out, "      %s\n", domain.FormatFieldChange(change))
+					}
+				}
+			}
+
+			if detailComments || detailLastComments > 0 {
+				fmt.Fprintf(out, "  Comments (%d of %d):\n", len(output.Comments), output.CommentCount)
+				for _, comment := range output.Comments {
+					fmt.Fprintf(out, "    %s\n", domain.FormatComment(comment))
+				}
+			}
+
 			return nil
 		},
 	}
@@ -99,6 +136,8 @@ func NewDetailCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&detailEvents, "events", false, "Include event history")
 	cmd.Flags().BoolVar(&detailDependencies, "dependencies", false, "Include dependency information")
 	cmd.Flags().BoolVar(&detailTimestamps, "timestamps", false, "Show formatted timestamps")
+	cmd.Flags().BoolVar(&detailComments, "comments", false, "Include comments")
+	cmd.Flags().IntVar(&detailLastComments, "last", 0, "Only the last N comments (implies --comments)")
 
 	return cmd
 }
@@ -0,0 +1,62 @@
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+var (
+	heartbeatAgent string
+	heartbeatLease time.Duration
+)
+
+func NewHeartbeatCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "heartbeat <task_id> --agent <name> [--lease <duration>]",
+		Short: "Extend the lease on a claimed task",
+		Long:  "Extend the lease on a task claimed with 'mandor task claim'. The new lease runs from now.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewTaskService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			taskID, err := svc.ResolveTaskID(args[0])
+			if err != nil {
+				return err
+			}
+
+			input := &domain.TaskHeartbeatInput{
+				TaskID: taskID,
+				Agent:  heartbeatAgent,
+				Lease:  heartbeatLease,
+			}
+
+			if err := svc.ValidateHeartbeatInput(input); err != nil {
+				return err
+			}
+
+			task, err := svc.HeartbeatTask(input)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Lease extended: %s until %s\n", task.ID, task.LeaseExpiresAt.Format(time.RFC3339))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&heartbeatAgent, "agent", "", "Name of the agent holding the claim (required)")
+	cmd.Flags().DurationVar(&heartbeatLease, "lease", 30*time.Minute, "New lease, counted from now")
+
+	return cmd
+}
@@ -19,11 +19,15 @@ var (
 	listIncludeDeleted bool
 	listSort           string
 	listOrder          string
+	listAssignee       string
+	listMine           bool
+	listLabels         []string
+	listLabelMatch     string
 )
 
 func NewListCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list [--feature <id>] [--project <id>] [--status <status>] [--priority <priority>] [--json] [--include-deleted]",
+		Use:   "list [--feature <id>] [--project <id>] [--status <status>] [--priority <priority>] [--assignee <name> | --mine] [--label <labels> [--label-match all|any]] [--json] [--include-deleted]",
 		Short: "List tasks",
 		Long:  "List all tasks in the workspace or filter by feature/project.",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -42,10 +46,17 @@ func NewListCmd() *cobra.Command {
 				}
 			}
 
-			if listPriority != "" {
-				if !domain.ValidatePriority(listPriority) {
-					return domain.NewValidationError(fmt.Sprintf("Invalid priority: '%s'. Valid values: P0, P1, P2, P3, P4, P5", listPriority))
-				}
+			assignee, err := service.ResolveAssigneeFilter(listAssignee, listMine)
+			if err != nil {
+				return err
+			}
+
+			labels, err := service.ParseLabels(listLabels...)
+			if err != nil {
+				return err
+			}
+			if err := service.ValidateLabelMatch(listLabelMatch); err != nil {
+				return err
 			}
 
 			input := &domain.TaskListInput{
@@ -57,6 +68,9 @@ func NewListCmd() *cobra.Command {
 				JSON:           listJSON,
 				Sort:           listSort,
 				Order:          listOrder,
+				Assignee:       assignee,
+				Labels:         labels,
+				LabelMatch:     listLabelMatch,
 			}
 
 			output, err := svc.ListTasks(input)
@@ -109,7 +123,11 @@ func NewListCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&listFeatureID, "feature", "f", "", "Filter by feature ID")
 	cmd.Flags().StringVarP(&listProjectID, "project", "p", "", "Filter by project ID")
 	cmd.Flags().StringVar(&listStatus, "status", "", "Filter by status (pending, ready, in_progress, blocked, done, cancelled)")
-	cmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority (P0-P5)")
+	cmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority (one of the project's levels)")
+	cmd.Flags().StringVar(&listAssignee, "assignee", "", "Filter by assignee")
+	cmd.Flags().BoolVar(&listMine, "mine", false, "Only tasks assigned to the git user")
+	cmd.Flags().StringSliceVar(&listLabels, "label", nil, "Filter by label (comma separated or repeated)")
+	cmd.Flags().StringVar(&listLabelMatch, "label-match", "all", "With several --label values: all or any")
 	cmd.Flags().BoolVar(&listJSON, "json", false, "Output as JSON")
 	cmd.Flags().BoolVar(&listIncludeDeleted, "include-deleted", false, "Include deleted tasks")
 	cmd.Flags().StringVar(&listSort, "sort", "priority", "Sort field: priority, created_at, name")
@@ -11,15 +11,19 @@ import (
 )
 
 var (
-	readyProjectID string
-	readyFeatureID string
-	readyPriority  string
-	readyJSON      bool
+	readyProjectID  string
+	readyFeatureID  string
+	readyPriority   string
+	readyJSON       bool
+	readyAssignee   string
+	readyMine       bool
+	readyLabels     []string
+	readyLabelMatch string
 )
 
 func NewReadyCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "ready [--project <id>] [--feature <id>] [--priority <priority>] [--json]",
+		Use:   "ready [--project <id>] [--feature <id>] [--priority <priority>] [--assignee <name> | --mine] [--label <labels> [--label-match all|any]] [--json]",
 		Short: "List ready tasks",
 		Long:  "List all tasks with status='ready' that are available to work on (no blocking dependencies).",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -32,10 +36,17 @@ func NewReadyCmd() *cobra.Command {
 				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
 			}
 
-			if readyPriority != "" {
-				if !domain.ValidatePriority(readyPriority) {
-					return domain.NewValidationError(fmt.Sprintf("Invalid priority: '%s'. Valid values: P0, P1, P2, P3, P4, P5", readyPriority))
-				}
+			assignee, err := service.ResolveAssigneeFilter(readyAssignee, readyMine)
+			if err != nil {
+				return err
+			}
+
+			labels, err := service.ParseLabels(readyLabels...)
+			if err != nil {
+				return err
+			}
+			if err := service.ValidateLabelMatch(readyLabelMatch); err != nil {
+				return err
 			}
 
 			input := &domain.TaskListInput{
@@ -47,6 +58,9 @@ func NewReadyCmd() *cobra.Command {
 				JSON:           readyJSON,
 				Sort:           "priority",
 				Order:          "asc",
+				Assignee:       assignee,
+				Labels:         labels,
+				LabelMatch:     readyLabelMatch,
 			}
 
 			output, err := svc.ListTasks(input)
@@ -110,7 +124,11 @@ func NewReadyCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&readyProjectID, "project", "p", "", "Filter by project ID")
 	cmd.Flags().StringVarP(&readyFeatureID, "feature", "f", "", "Filter by feature ID")
-	cmd.Flags().StringVar(&readyPriority, "priority", "", "Filter by priority (P0-P5)")
+	cmd.Flags().StringVar(&readyPriority, "priority", "", "Filter by priority (one of the project's levels)")
+	cmd.Flags().StringVar(&readyAssignee, "assignee", "", "Filter by assignee")
+	cmd.Flags().BoolVar(&readyMine, "mine", false, "Only tasks assigned to the git user")
+	cmd.Flags().StringSliceVar(&readyLabels, "label", nil, "Filter by label (comma separated or repeated)")
+	cmd.Flags().StringVar(&readyLabelMatch, "label-match", "all", "With several --label values: all or any")
 	cmd.Flags().BoolVar(&readyJSON, "json", false, "Output as JSON")
 
 	return cmd
@@ -0,0 +1,56 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+var releaseAgent string
+
+func NewReleaseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release <task_id> --agent <name>",
+		Short: "Give a claimed task back",
+		Long:  "Clear the claim on a task and return it to ready so another agent can pick it up.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewTaskService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			taskID, err := svc.ResolveTaskID(args[0])
+			if err != nil {
+				return err
+			}
+
+			input := &domain.TaskReleaseInput{
+				TaskID: taskID,
+				Agent:  releaseAgent,
+			}
+
+			if err := svc.ValidateReleaseInput(input); err != nil {
+				return err
+			}
+
+			task, err := svc.ReleaseTask(input)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Task released: %s (%s)\n", task.ID, task.Status)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&releaseAgent, "agent", "", "Name of the agent holding the claim (required)")
+
+	return cmd
+}
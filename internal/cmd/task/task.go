@@ -17,6 +17,10 @@ func NewTaskCmd() *cobra.Command {
 	cmd.AddCommand(NewUpdateCmd())
 	cmd.AddCommand(NewReadyCmd())
 	cmd.AddCommand(NewBlockedCmd())
+	cmd.AddCommand(NewClaimCmd())
+	cmd.AddCommand(NewHeartbeatCmd())
+	cmd.AddCommand(NewReleaseCmd())
+	cmd.AddCommand(NewCommentCmd())
 
 	return cmd
 }
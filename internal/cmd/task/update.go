@@ -1,6 +1,7 @@
 package task
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -23,19 +24,39 @@ var (
 	updateDependsOn     string
 	updateDependsAdd    string
 	updateDependsRemove string
+	updateAssignee      string
+	updateLabels        string
+	updateLabelsAdd     string
+	updateLabelsRemove  string
 	updateReopen        bool
 	updateCancel        bool
+	updateCascade       bool
+	updateRewire        string
+	updateKeepBlocked   bool
 	updateForce         bool
 	updateDryRun        bool
+	updateJSON          bool
 	updateYes           bool
 )
 
 func NewUpdateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "update <task_id> [--name] [--priority] [--goal] [--implementation-steps] [--test-cases] [--derivable-files] [--library-needs] [--status <ready|in_progress|done>] [--cancel --reason] [--reopen] [--depends <ids>]",
+		Use:   "update <task_id> [--name] [--priority] [--goal] [--implementation-steps] [--test-cases] [--derivable-files] [--library-needs] [--status <ready|in_progress|done>] [--cancel --reason [--cascade|--rewire <id>|--keep-blocked]] [--reopen] [--depends <ids>] [--assignee <name>] [--labels|--labels-add|--labels-remove <labels>]",
 		Short: "Update a task",
-		Long:  "Update task properties, change status, cancel, or reopen.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Update task properties, change status, cancel, or reopen.
+
+A task that other open tasks depend on can only be cancelled with a policy
+for those dependents:
+  --cascade        cancel every dependent, recursively, with the same reason;
+                   in-progress dependents are skipped with a warning
+  --rewire <id>    make the dependents depend on <id> instead
+  --keep-blocked   leave the dependents blocked until someone intervenes
+Combine with --dry-run to list every affected task first.
+
+--dry-run runs the whole update without writing anything and prints the
+field changes, the status transition and the dependents that would be
+unblocked or blocked again. Add --json for machine-readable output.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			svc, err := service.NewTaskService()
 			if err != nil {
@@ -46,7 +67,10 @@ func NewUpdateCmd() *cobra.Command {
 				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
 			}
 
-			taskID := args[0]
+			taskID, err := svc.ResolveTaskID(args[0])
+			if err != nil {
+				return err
+			}
 
 			var namePtr, goalPtr, priorityPtr, statusPtr, reasonPtr *string
 			var implStepsPtr, testCasesPtr, derivablePtr, librariesPtr *[]string
@@ -59,9 +83,6 @@ func NewUpdateCmd() *cobra.Command {
 				goalPtr = &updateGoal
 			}
 			if updatePriority != "" {
-				if !domain.ValidatePriority(updatePriority) {
-					return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
-				}
 				priorityPtr = &updatePriority
 			}
 			if updateStatus != "" {
@@ -92,18 +113,64 @@ func NewUpdateCmd() *cobra.Command {
 			}
 
 			if updateDependsOn != "" {
-				deps := splitByPipe(updateDependsOn)
+				deps, err := svc.ResolveTaskIDs(splitByPipe(updateDependsOn))
+				if err != nil {
+					return err
+				}
 				dependsOnPtr = &deps
 			}
 			if updateDependsAdd != "" {
-				deps := splitByPipe(updateDependsAdd)
+				deps, err := svc.ResolveTaskIDs(splitByPipe(updateDependsAdd))
+				if err != nil {
+					return err
+				}
 				dependsAddPtr = &deps
 			}
 			if updateDependsRemove != "" {
-				deps := splitByPipe(updateDependsRemove)
+				deps, err := svc.ResolveTaskIDs(splitByPipe(updateDependsRemove))
+				if err != nil {
+					return err
+				}
 				dependsRemovePtr = &deps
 			}
 
+			var rewirePtr *string
+			if cmd.Flags().Changed("rewire") {
+				rewire, err := svc.ResolveTaskID(updateRewire)
+				if err != nil {
+					return err
+				}
+				rewirePtr = &rewire
+			}
+
+			var labelsPtr, labelsAddPtr, labelsRemovePtr *[]string
+			if cmd.Flags().Changed("labels") {
+				labels, err := service.ParseLabels(updateLabels)
+				if err != nil {
+					return err
+				}
+				labelsPtr = &labels
+			}
+			if updateLabelsAdd != "" {
+				labels, err := service.ParseLabels(updateLabelsAdd)
+				if err != nil {
+					return err
+				}
+				labelsAddPtr = &labels
+			}
+			if updateLabelsRemove != "" {
+				labels, err := service.ParseLabels(updateLabelsRemove)
+				if err != nil {
+					return err
+				}
+				labelsRemovePtr = &labels
+			}
+
+			var assigneePtr *string
+			if cmd.Flags().Changed("assignee") {
+				assigneePtr = &updateAssignee
+			}
+
 			input := &domain.TaskUpdateInput{
 				TaskID:              taskID,
 				Name:                namePtr,
@@ -118,8 +185,15 @@ func NewUpdateCmd() *cobra.Command {
 				DependsOn:           dependsOnPtr,
 				DependsAdd:          dependsAddPtr,
 				DependsRemove:       dependsRemovePtr,
+				Assignee:            assigneePtr,
+				Labels:              labelsPtr,
+				LabelsAdd:           labelsAddPtr,
+				LabelsRemove:        labelsRemovePtr,
 				Reopen:              updateReopen,
 				Cancel:              updateCancel,
+				Cascade:             updateCascade,
+				Rewire:              rewirePtr,
+				KeepBlocked:         updateKeepBlocked,
 				Force:               updateForce,
 				DryRun:              updateDryRun,
 			}
@@ -128,6 +202,19 @@ func NewUpdateCmd() *cobra.Command {
 				return err
 			}
 
+			if updateJSON {
+				if !updateDryRun {
+					return domain.NewValidationError("--json requires --dry-run.")
+				}
+				preview, err := svc.PreviewTaskUpdate(input)
+				if err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(preview)
+			}
+
 			changes, err := svc.UpdateTask(input)
 			if err != nil {
 				return err
@@ -135,8 +222,8 @@ func NewUpdateCmd() *cobra.Command {
 
 			out := cmd.OutOrStdout()
 			if updateDryRun {
-				fmt.Fprintln(out, "[DRY RUN] Changes:")
-				for _, change := range changes {
+				fmt.Fprintln(out, changes[0])
+				for _, change := range changes[1:] {
 					fmt.Fprintf(out, "  - %s\n", change)
 				}
 				return nil
@@ -211,7 +298,7 @@ func NewUpdateCmd() *cobra.Command {
 
 	cmd.Flags().StringVar(&updateName, "name", "", "New task name")
 	cmd.Flags().StringVar(&updateGoal, "goal", "", "New task goal")
-	cmd.Flags().StringVar(&updatePriority, "priority", "", "New priority (P0-P5)")
+	cmd.Flags().StringVar(&updatePriority, "priority", "", "New priority (one of the project's levels)")
 	cmd.Flags().StringVar(&updateImplSteps, "implementation-steps", "", "Update implementation steps (pipe-separated)")
 	cmd.Flags().StringVar(&updateTestCases, "test-cases", "", "Update test cases (pipe-separated)")
 	cmd.Flags().StringVar(&updateDerivable, "derivable-files", "", "Update derivable files (pipe-separated)")
@@ -221,10 +308,18 @@ func NewUpdateCmd() *cobra.Command {
 	cmd.Flags().StringVar(&updateDependsOn, "depends", "", "Set all dependencies (pipe-separated)")
 	cmd.Flags().StringVar(&updateDependsAdd, "depends-add", "", "Add dependencies (pipe-separated)")
 	cmd.Flags().StringVar(&updateDependsRemove, "depends-remove", "", "Remove dependencies (pipe-separated)")
-	cmd.Flags().BoolVar(&updateReopen, "reopen", false, "Reopen a cancelled task")
+	cmd.Flags().StringVar(&updateAssignee, "assignee", "", "Assign the task (empty string to unassign)")
+	cmd.Flags().StringVar(&updateLabels, "labels", "", "Set all labels (comma separated, empty string to clear)")
+	cmd.Flags().StringVar(&updateLabelsAdd, "labels-add", "", "Add labels (comma separated)")
+	cmd.Flags().StringVar(&updateLabelsRemove, "labels-remove", "", "Remove labels (comma separated)")
+	cmd.Flags().BoolVar(&updateReopen, "reopen", false, "Reopen a done or cancelled task")
 	cmd.Flags().BoolVar(&updateCancel, "cancel", false, "Cancel the task")
-	cmd.Flags().BoolVar(&updateForce, "force", false, "Force operation (e.g., cancel with dependents)")
+	cmd.Flags().BoolVar(&updateCascade, "cascade", false, "When cancelling, also cancel every dependent task recursively")
+	cmd.Flags().StringVar(&updateRewire, "rewire", "", "When cancelling, move dependent tasks onto this task")
+	cmd.Flags().BoolVar(&updateKeepBlocked, "keep-blocked", false, "When cancelling, keep dependent tasks blocked")
+	cmd.Flags().BoolVar(&updateForce, "force", false, "Same as --keep-blocked")
 	cmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show what would be changed without making changes")
+	cmd.Flags().BoolVar(&updateJSON, "json", false, "With --dry-run, print the preview as JSON")
 	cmd.Flags().BoolVarP(&updateYes, "yes", "y", false, "Skip confirmation")
 
 	return cmd
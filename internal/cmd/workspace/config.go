@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"mandor/internal/domain"
+	"mandor/internal/fs"
 	"mandor/internal/service"
 )
 
@@ -18,7 +19,8 @@ func NewConfigCmd() *cobra.Command {
 
 Available keys:
   - default_priority: Default priority for new entities (P0-P5, default: P3)
-  - strict_mode: Enforce strict validation rules (true/false, default: false)`,
+  - strict_mode: Enforce strict mode rules in every project (true/false, default: false)
+  - lock_timeout: How long to wait for the workspace lock (duration, default: 10s)`,
 	}
 
 	cmd.AddCommand(newConfigGetCmd())
@@ -60,6 +62,7 @@ func newConfigGetCmd() *cobra.Command {
 				fmt.Println()
 				fmt.Printf("default_priority  %s\n", ws.Config.DefaultPriority)
 				fmt.Printf("strict_mode       %v\n", ws.Config.StrictMode)
+				fmt.Printf("lock_timeout      %s\n", lockTimeoutValue(ws))
 				fmt.Println()
 				fmt.Println("Project Dependency Rules")
 				fmt.Println("════════════════════════")
@@ -111,9 +114,11 @@ func newConfigSetCmd() *cobra.Command {
 					)
 				}
 				value = boolValue
+			case "lock_timeout":
+				value = strings.ToLower(valueStr)
 			default:
 				return domain.NewValidationError(
-					fmt.Sprintf("Unknown configuration key: %s\n\nAvailable keys:\n  - default_priority\n  - strict_mode", key),
+					fmt.Sprintf("Unknown configuration key: %s\n\nAvailable keys:\n  - default_priority\n  - strict_mode\n  - lock_timeout", key),
 				)
 			}
 
@@ -165,7 +170,17 @@ func newConfigListCmd() *cobra.Command {
 			fmt.Printf("  Current:  %v\n", ws.Config.StrictMode)
 			fmt.Println("  Default:  false")
 			fmt.Println("  Options:  true, false")
-			fmt.Println("  Desc:     Enforce strict validation rules")
+			fmt.Println("  Desc:     Enforce strict mode rules in every project")
+			fmt.Println()
+
+			// lock_timeout
+			fmt.Println("lock_timeout")
+			fmt.Println("  Type:     duration")
+			fmt.Printf("  Current:  %s\n", lockTimeoutValue(ws))
+			fmt.Printf("  Default:  %s\n", fs.DefaultLockTimeout)
+			fmt.Println("  Options:  any positive duration (e.g., 5s, 30s, 1m)")
+			fmt.Println("  Desc:     How long to wait for the workspace lock held by another process")
+			fmt.Println("            (overridden by MANDOR_LOCK_TIMEOUT)")
 			fmt.Println()
 
 			fmt.Println("Use 'mandor config get <key>' for value.")
@@ -205,10 +220,14 @@ func newConfigResetCmd() *cobra.Command {
 				if err := svc.UpdateWorkspaceConfig("strict_mode", false); err != nil {
 					return err
 				}
+				if err := svc.UpdateWorkspaceConfig("lock_timeout", fs.DefaultLockTimeout.String()); err != nil {
+					return err
+				}
 
 				fmt.Println("✓ Reset all configuration to defaults")
 				fmt.Println("  - default_priority = P3")
 				fmt.Println("  - strict_mode = false")
+				fmt.Printf("  - lock_timeout = %s\n", fs.DefaultLockTimeout)
 				return nil
 			}
 
@@ -221,6 +240,8 @@ func newConfigResetCmd() *cobra.Command {
 				defaultValue = "P3"
 			case "strict_mode":
 				defaultValue = false
+			case "lock_timeout":
+				defaultValue = fs.DefaultLockTimeout.String()
 			default:
 				return domain.NewValidationError(
 					fmt.Sprintf("Unknown configuration key: %s", key),
@@ -249,6 +270,14 @@ func newConfigResetCmd() *cobra.Command {
 	return cmd
 }
 
+// lockTimeoutValue returns the configured lock timeout or the default
+func lockTimeoutValue(ws *domain.Workspace) string {
+	if ws.Config.LockTimeout == "" {
+		return fs.DefaultLockTimeout.String()
+	}
+	return ws.Config.LockTimeout
+}
+
 // parseBool parses boolean values with multiple formats
 func parseBool(value string) (bool, error) {
 	lowerValue := strings.ToLower(value)
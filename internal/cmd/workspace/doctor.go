@@ -0,0 +1,94 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+// NewDoctorCmd creates the doctor command
+func NewDoctorCmd() *cobra.Command {
+	var (
+		fix        bool
+		jsonFormat bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor [--fix] [--json]",
+		Short: "Check workspace integrity",
+		Long: `Check the workspace for inconsistent state left by hand edits or
+interrupted runs. Every finding carries a stable code:
+
+  dangling_dependency  depends_on references an ID that does not exist (fixable)
+  missing_feature      task's feature_id does not exist
+  duplicate_id         an ID appears on more than one line of a JSONL file (fixable)
+  stale_blocked        entity is blocked but all dependencies are satisfied (fixable)
+  stale_ready          entity is ready (draft for features) but a dependency is not finished (fixable)
+  leftover_tmp         temporary file left behind by an interrupted write (fixable)
+
+With --fix the fixable findings are repaired and a system "repaired" event is
+recorded for each repair. The command exits with a validation error while
+unresolved findings remain.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewDoctorService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			report, err := svc.Check(fix)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonFormat {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(report); err != nil {
+					return err
+				}
+			} else {
+				if len(report.Findings) == 0 {
+					fmt.Fprintln(out, "✓ No problems found")
+					return nil
+				}
+
+				for _, f := range report.Findings {
+					marker := "✗"
+					if f.Fixed {
+						marker = "✓"
+					}
+					subject := f.ID
+					if subject == "" {
+						subject = f.Path
+					}
+					fmt.Fprintf(out, "%s [%s] %s: %s\n", marker, f.Code, subject, f.Message)
+				}
+
+				fmt.Fprintln(out)
+				fmt.Fprintf(out, "%d problem(s) found, %d fixed\n", len(report.Findings), report.Fixed)
+				if !fix && report.Unresolved() > 0 {
+					fmt.Fprintln(out, "Run 'mandor doctor --fix' to repair fixable problems.")
+				}
+			}
+
+			if unresolved := report.Unresolved(); unresolved > 0 {
+				return domain.NewValidationError(fmt.Sprintf("%d unresolved problem(s) in workspace.", unresolved))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Repair fixable problems")
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "Output as JSON")
+
+	return cmd
+}
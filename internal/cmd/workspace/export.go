@@ -0,0 +1,67 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+// NewExportCmd creates the export command
+func NewExportCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export [--output <file>]",
+		Short: "Export the workspace to a bundle file",
+		Long: `Serialize the workspace and every project (metadata, schema, features,
+tasks, issues, events and comments) into a single versioned JSON bundle.
+
+The bundle is written to --output, or to stdout when omitted. Load it into
+another workspace with ` + "`mandor import`" + `.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewBundleService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			bundle, err := svc.Export()
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(bundle, "", "  ")
+			if err != nil {
+				return domain.NewSystemError("Cannot encode bundle", err)
+			}
+			data = append(data, '\n')
+
+			if output == "" || output == "-" {
+				_, err := cmd.OutOrStdout().Write(data)
+				return err
+			}
+
+			if err := os.WriteFile(output, data, 0644); err != nil {
+				if os.IsPermission(err) {
+					return domain.NewPermissionError("Permission denied. Cannot write " + output + ".")
+				}
+				return domain.NewSystemError("Cannot write bundle", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Exported %d project(s) to %s\n", len(bundle.Projects), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Bundle file to write (default: stdout)")
+
+	return cmd
+}
@@ -0,0 +1,105 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+// NewImportCmd creates the import command
+func NewImportCmd() *cobra.Command {
+	var (
+		projectPrefix string
+		merge         bool
+		replace       bool
+		jsonFormat    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <bundle.json> [--project-prefix <prefix>] [--merge|--replace]",
+		Short: "Import projects from a bundle file",
+		Long: `Import every project from a bundle written by ` + "`mandor export`" + `.
+
+By default the import fails if a project in the bundle already exists.
+  --merge     add the bundle's features, tasks, issues, events and comments to existing projects
+  --replace   overwrite existing projects with the bundle's content
+
+--project-prefix prepends a prefix to every imported project ID and to the
+feature, task and issue IDs inside them, so a plan can be seeded next to an
+existing copy (e.g. --project-prefix seed- turns "api" into "seed-api").
+Only ID fields are rewritten; names, goals and comment text are kept as is.
+
+Project IDs, ID uniqueness, task features and dependencies are validated
+before anything is written. If writing fails part way through, the workspace
+is restored to its state before the import.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if merge && replace {
+				return domain.NewValidationError("--merge and --replace cannot be used together.")
+			}
+
+			svc, err := service.NewBundleService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				if os.IsNotExist(err) {
+					return domain.NewValidationError("Bundle file not found: " + args[0])
+				}
+				return domain.NewSystemError("Cannot read bundle", err)
+			}
+
+			var bundle domain.Bundle
+			if err := json.Unmarshal(data, &bundle); err != nil {
+				return domain.NewValidationError("Invalid bundle file: " + err.Error())
+			}
+
+			opts := service.ImportOptions{ProjectPrefix: projectPrefix, Mode: service.ImportModeCreate}
+			if merge {
+				opts.Mode = service.ImportModeMerge
+			} else if replace {
+				opts.Mode = service.ImportModeReplace
+			}
+
+			result, err := svc.Import(&bundle, opts)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonFormat {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+
+			fmt.Fprintf(out, "✓ Imported %d project(s) from %s\n", len(result.Projects), args[0])
+			for _, p := range result.Projects {
+				name := p.ID
+				if p.SourceID != p.ID {
+					name = fmt.Sprintf("%s (from %s)", p.ID, p.SourceID)
+				}
+				fmt.Fprintf(out, "  %s: %s — %d feature(s), %d task(s), %d issue(s), %d event(s), %d comment(s)\n",
+					name, p.Action, p.Features, p.Tasks, p.Issues, p.Events, p.Comments)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&projectPrefix, "project-prefix", "", "Prefix for imported project and entity IDs")
+	cmd.Flags().BoolVar(&merge, "merge", false, "Merge into existing projects")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Replace existing projects")
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "Output as JSON")
+
+	return cmd
+}
@@ -19,11 +19,12 @@ func NewInitCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize a new Mandor workspace",
-		Long: `Initialize a new Mandor workspace in the current directory.
+		Long: `Initialize a new Mandor workspace in the current directory, or in the
+directory given by --workspace / MANDOR_WORKSPACE.
 
 Creates a .mandor/ directory with workspace metadata and project storage.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			svc, err := service.NewWorkspaceService()
+			svc, err := service.NewWorkspaceServiceForInit()
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,67 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+// NewMigrateCmd creates the migrate command
+func NewMigrateCmd() *cobra.Command {
+	var jsonFormat bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the workspace to the current on-disk schema",
+		Long: fmt.Sprintf(`Upgrade an older workspace to schema %s.
+
+The .mandor directory is copied to a mandor-backup-<timestamp> directory under
+the system temp directory first, then every
+pending migration is applied in order to all project JSONL files and the
+workspace schema_version is bumped. Other commands refuse to run on an older
+workspace until it has been migrated.`, domain.CurrentSchemaVersion),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewMigrationService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			result, err := svc.Migrate()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonFormat {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(result)
+			}
+
+			if len(result.Applied) == 0 {
+				fmt.Fprintf(out, "✓ Workspace is already at schema %s\n", result.To)
+				return nil
+			}
+
+			fmt.Fprintf(out, "✓ Migrated workspace from %s to %s\n", result.From, result.To)
+			fmt.Fprintf(out, "  Backup: %s\n", result.Backup)
+			fmt.Fprintf(out, "  Files:  %d\n", result.Files)
+			for _, applied := range result.Applied {
+				fmt.Fprintf(out, "  - %s\n", applied)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "Output as JSON")
+
+	return cmd
+}
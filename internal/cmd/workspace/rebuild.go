@@ -0,0 +1,98 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"mandor/internal/domain"
+	"mandor/internal/service"
+)
+
+// NewRebuildCmd creates the rebuild command
+func NewRebuildCmd() *cobra.Command {
+	var (
+		projectID  string
+		verify     bool
+		jsonFormat bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rebuild [--project <id>] [--verify]",
+		Short: "Regenerate entity files by replaying events.jsonl",
+		Long: `Replay each project's events.jsonl and regenerate features.jsonl,
+tasks.jsonl, issues.jsonl and project.jsonl from it.
+
+With --verify nothing is written: the replayed state is compared against the
+current entity files and every difference is reported. The command exits with
+a validation error when differences are found.
+
+Events recorded before snapshots were added to the log carry no payload; a
+project containing such entities cannot be rebuilt.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := service.NewRebuildService()
+			if err != nil {
+				return err
+			}
+
+			if !svc.WorkspaceInitialized() {
+				return domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+			}
+
+			result, err := svc.Rebuild(projectID, verify)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonFormat {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(result); err != nil {
+					return err
+				}
+			} else {
+				for _, p := range result.Projects {
+					if verify {
+						fmt.Fprintf(out, "Verified project %s against %d event(s)\n", p.ProjectID, p.Events)
+					} else {
+						fmt.Fprintf(out, "✓ Rebuilt project %s from %d event(s)\n", p.ProjectID, p.Events)
+					}
+					fmt.Fprintf(out, "  Features: %d\n", p.Features)
+					fmt.Fprintf(out, "  Tasks:    %d\n", p.Tasks)
+					fmt.Fprintf(out, "  Issues:   %d\n", p.Issues)
+
+					if verify {
+						if len(p.Mismatches) == 0 {
+							fmt.Fprintln(out, "  ✓ Entity files match replayed events")
+						}
+						for _, m := range p.Mismatches {
+							line := fmt.Sprintf("  ✗ %s %s: %s", m.Layer, m.ID, m.Reason)
+							if len(m.Fields) > 0 {
+								line += " (" + strings.Join(m.Fields, ", ") + ")"
+							}
+							fmt.Fprintln(out, line)
+						}
+					}
+					fmt.Fprintln(out)
+				}
+			}
+
+			if verify {
+				if count := result.MismatchCount(); count > 0 {
+					return domain.NewValidationError(fmt.Sprintf("Replayed state differs from entity files in %d place(s).", count))
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectID, "project", "p", "", "Rebuild a single project only")
+	cmd.Flags().BoolVar(&verify, "verify", false, "Compare replayed state with entity files without writing")
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "Output as JSON")
+
+	return cmd
+}
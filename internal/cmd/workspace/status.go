@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"mandor/internal/service"
@@ -93,7 +94,14 @@ func outputStatusDefault(status *service.WorkspaceStatus) error {
 	fmt.Println()
 
 	fmt.Printf("Cross-project dependencies: %d\n", status.Dependencies.CrossProjectCount)
-	fmt.Printf("Circular dependencies: %d ✓\n", status.Dependencies.CircularDeps)
+	if status.Dependencies.CircularDeps == 0 {
+		fmt.Printf("Circular dependencies: %d ✓\n", status.Dependencies.CircularDeps)
+	} else {
+		fmt.Printf("Circular dependencies: %d ✗\n", status.Dependencies.CircularDeps)
+		for _, cycle := range status.Dependencies.Cycles {
+			fmt.Printf("  - %s\n", strings.Join(cycle, ", "))
+		}
+	}
 	fmt.Println()
 
 	// Total stats
@@ -109,6 +117,34 @@ func outputStatusDefault(status *service.WorkspaceStatus) error {
 	fmt.Printf("  - Issues: %d\n", status.Totals.Issues)
 	fmt.Println()
 
+	// Open work per assignee
+	if len(status.Assignees) > 0 {
+		fmt.Println("╔════════════════════════════════════════════════════════════╗")
+		fmt.Println("║ ASSIGNEES (open work)                                      ║")
+		fmt.Println("╚════════════════════════════════════════════════════════════╝")
+		fmt.Println()
+
+		fmt.Printf("%-24s %8s %8s %8s\n", "Assignee", "Features", "Tasks", "Issues")
+		for _, a := range status.Assignees {
+			fmt.Printf("%-24s %8d %8d %8d\n", a.Assignee, a.Features, a.Tasks, a.Issues)
+		}
+		fmt.Println()
+	}
+
+	// Open work per label
+	if len(status.Labels) > 0 {
+		fmt.Println("╔════════════════════════════════════════════════════════════╗")
+		fmt.Println("║ LABELS (open work)                                         ║")
+		fmt.Println("╚════════════════════════════════════════════════════════════╝")
+		fmt.Println()
+
+		fmt.Printf("%-24s %8s %8s %8s\n", "Label", "Features", "Tasks", "Issues")
+		for _, l := range status.Labels {
+			fmt.Printf("%-24s %8d %8d %8d\n", l.Label, l.Features, l.Tasks, l.Issues)
+		}
+		fmt.Println()
+	}
+
 	return nil
 }
 
@@ -142,6 +178,13 @@ func outputStatusSummary(status *service.WorkspaceStatus) error {
 		status.Dependencies.CircularDeps,
 	)
 
+	for _, a := range status.Assignees {
+		fmt.Printf("  @%s: %dF | %dT | %dI open\n", a.Assignee, a.Features, a.Tasks, a.Issues)
+	}
+	for _, l := range status.Labels {
+		fmt.Printf("  #%s: %dF | %dT | %dI open\n", l.Label, l.Features, l.Tasks, l.Issues)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BundleVersion identifies the format written by `mandor export`
+const BundleVersion = "mandor.bundle.v1"
+
+// Bundle is a portable, versioned export of a whole workspace
+type Bundle struct {
+	BundleVersion string          `json:"bundle_version"`
+	SchemaVersion string          `json:"schema_version"`
+	ExportedAt    time.Time       `json:"exported_at"`
+	ExportedBy    string          `json:"exported_by"`
+	Workspace     *Workspace      `json:"workspace"`
+	Projects      []BundleProject `json:"projects"`
+}
+
+// BundleProject holds one project with its schema, entities and event log
+type BundleProject struct {
+	Project  *Project       `json:"project"`
+	Schema   *ProjectSchema `json:"schema,omitempty"`
+	Features []*Feature     `json:"features"`
+	Tasks    []*Task        `json:"tasks"`
+	Issues   []*Issue       `json:"issues"`
+	// Events are kept verbatim so every layer's event shape round-trips
+	Events []json.RawMessage `json:"events"`
+	// Comments is omitted by bundles written before comments existed
+	Comments []*Comment `json:"comments,omitempty"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Comment is a note left on a feature, task or issue. Comments are kept in
+// the project's append-only comments.jsonl and are never edited.
+type Comment struct {
+	ID       string `json:"id"`
+	Layer    string `json:"layer"`
+	EntityID string `json:"entity_id"`
+	Author   string `json:"author"`
+	Body     string `json:"body"`
+	// ReplyTo is the ID of the comment this one answers
+	ReplyTo string    `json:"reply_to,omitempty"`
+	Ts      time.Time `json:"ts"`
+}
+
+type CommentInput struct {
+	EntityID string
+	Author   string
+	Body     string
+	ReplyTo  string
+}
+
+// FormatComment renders a comment as one line of terminal output
+func FormatComment(c Comment) string {
+	reply := ""
+	if c.ReplyTo != "" {
+		reply = " ↳ " + c.ReplyTo
+	}
+	return fmt.Sprintf("%s %s [%s]%s: %s", c.Ts.Format("2006-01-02 15:04:05"), c.Author, c.ID, reply, c.Body)
+}
@@ -12,6 +12,7 @@ const (
 	ExitSystemError     ExitCode = 1 // System error (permission denied, disk full, etc.)
 	ExitValidationError ExitCode = 2 // Validation error (invalid input, already exists, etc.)
 	ExitPermissionError ExitCode = 3 // Permission error (cannot write to directory)
+	ExitLockError       ExitCode = 4 // Lock error (workspace locked by another process)
 )
 
 // MandorError represents an error in the Mandor system
@@ -53,3 +54,11 @@ func NewPermissionError(message string) *MandorError {
 		Message: message,
 	}
 }
+
+// NewLockError creates a lock error
+func NewLockError(message string) *MandorError {
+	return &MandorError{
+		Code:    ExitLockError,
+		Message: message,
+	}
+}
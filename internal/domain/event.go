@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldChange records the value of a single field before and after an update
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// DiffFields compares two snapshots of the same entity and returns a
+// FieldChange for every named JSON field whose value differs. Names that
+// are not fields of the entity (e.g. "dependent_unblocked") are skipped.
+func DiffFields(before, after interface{}, fields []string) []FieldChange {
+	beforeMap := toFieldMap(before)
+	afterMap := toFieldMap(after)
+
+	var diff []FieldChange
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+
+		from, inBefore := beforeMap[field]
+		to, inAfter := afterMap[field]
+		if !inBefore && !inAfter {
+			continue
+		}
+		if reflect.DeepEqual(from, to) {
+			continue
+		}
+		diff = append(diff, FieldChange{Field: field, From: from, To: to})
+	}
+	return diff
+}
+
+// ChangedFields returns the sorted JSON field names whose values differ
+// between two snapshots of the same entity.
+func ChangedFields(before, after interface{}) []string {
+	beforeMap := toFieldMap(before)
+	afterMap := toFieldMap(after)
+
+	var fields []string
+	for field, from := range beforeMap {
+		if !reflect.DeepEqual(from, afterMap[field]) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range afterMap {
+		if _, ok := beforeMap[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func toFieldMap(v interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	json.Unmarshal(data, &fields)
+	return fields
+}
+
+// FormatFieldChange renders a change as "field: from → to" for terminal output
+func FormatFieldChange(c FieldChange) string {
+	return fmt.Sprintf("%s: %s → %s", c.Field, formatFieldValue(c.From), formatFieldValue(c.To))
+}
+
+func formatFieldValue(v interface{}) string {
+	const maxLen = 60
+
+	var s string
+	switch val := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		if val == "" {
+			return "(none)"
+		}
+		s = val
+	case []interface{}:
+		if len(val) == 0 {
+			return "[]"
+		}
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = fmt.Sprintf("%v", item)
+		}
+		s = "[" + strings.Join(items, ", ") + "]"
+	default:
+		s = fmt.Sprintf("%v", val)
+	}
+
+	if len(s) > maxLen {
+		s = s[:maxLen-3] + "..."
+	}
+	return s
+}
+
+// UpdatePreview is what an update would do, worked out by running it in
+// memory for --dry-run. Nothing in it has been written.
+type UpdatePreview struct {
+	Layer      string         `json:"layer"`
+	ID         string         `json:"id"`
+	Changes    []string       `json:"changes"`
+	Diff       []FieldChange  `json:"diff"`
+	StatusFrom string         `json:"status_from"`
+	StatusTo   string         `json:"status_to"`
+	Unblocked  []string       `json:"unblocked,omitempty"`
+	Reblocked  []string       `json:"reblocked,omitempty"`
+	Rollups    []StatusChange `json:"rollups,omitempty"`
+	Dependents []string       `json:"dependents,omitempty"`
+	Warnings   []string       `json:"warnings,omitempty"`
+}
+
+// StatusChange is a status move an update causes on the entity above it,
+// such as the owning feature or project
+type StatusChange struct {
+	Layer string `json:"layer"`
+	ID    string `json:"id"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Lines renders the preview for terminal output, one change per line
+func (p *UpdatePreview) Lines() []string {
+	lines := []string{fmt.Sprintf("[DRY RUN] Would update %s: %s", p.Layer, p.ID)}
+	for _, c := range p.Diff {
+		lines = append(lines, FormatFieldChange(c))
+	}
+	for _, id := range p.Unblocked {
+		lines = append(lines, "would unblock: "+id)
+	}
+	for _, id := range p.Reblocked {
+		lines = append(lines, "would block: "+id)
+	}
+	for _, c := range p.Rollups {
+		lines = append(lines, fmt.Sprintf("would move %s %s: %s -> %s", c.Layer, c.ID, c.From, c.To))
+	}
+	lines = append(lines, p.Dependents...)
+	lines = append(lines, p.Warnings...)
+	if len(lines) == 1 {
+		lines = append(lines, "no changes")
+	}
+	return lines
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestFormatFieldChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   FieldChange
+		expected string
+	}{
+		{"string", FieldChange{Field: "priority", From: "P3", To: "P1"}, "priority: P3 → P1"},
+		{"empty to value", FieldChange{Field: "reason", From: "", To: "obsolete"}, "reason: (none) → obsolete"},
+		{"list", FieldChange{Field: "depends_on", From: nil, To: []interface{}{"a", "b"}}, "depends_on: (none) → [a, b]"},
+		{"bool", FieldChange{Field: "strict", From: false, To: true}, "strict: false → true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatFieldChange(tt.change)
+			if result != tt.expected {
+				t.Errorf("FormatFieldChange() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	before := Task{Name: "Old", Priority: "P3", Status: TaskStatusReady}
+	after := Task{Name: "Old", Priority: "P1", Status: TaskStatusInProgress}
+
+	diff := DiffFields(before, after, []string{"name", "priority", "status", "status", "dependent_unblocked"})
+	if len(diff) != 2 {
+		t.Fatalf("DiffFields() returned %d changes, want 2: %+v", len(diff), diff)
+	}
+	if diff[0].Field != "priority" || diff[0].From != "P3" || diff[0].To != "P1" {
+		t.Errorf("unexpected priority change: %+v", diff[0])
+	}
+	if diff[1].Field != "status" || diff[1].From != TaskStatusReady || diff[1].To != TaskStatusInProgress {
+		t.Errorf("unexpected status change: %+v", diff[1])
+	}
+}
@@ -29,6 +29,8 @@ type Feature struct {
 	Status    string    `json:"status"`
 	DependsOn []string  `json:"depends_on,omitempty"`
 	Reason    string    `json:"reason,omitempty"`
+	Assignee  string    `json:"assignee,omitempty"`
+	Labels    []string  `json:"labels,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	CreatedBy string    `json:"created_by"`
@@ -36,12 +38,15 @@ type Feature struct {
 }
 
 type FeatureEvent struct {
-	Layer   string    `json:"layer"`
-	Type    string    `json:"type"`
-	ID      string    `json:"id"`
-	By      string    `json:"by"`
-	Ts      time.Time `json:"ts"`
-	Changes []string  `json:"changes,omitempty"`
+	Layer   string        `json:"layer"`
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	By      string        `json:"by"`
+	Ts      time.Time     `json:"ts"`
+	Changes []string      `json:"changes,omitempty"`
+	Diff    []FieldChange `json:"diff,omitempty"`
+	// Snapshot is the full feature after the change, so state can be rebuilt from events.jsonl
+	Snapshot *Feature `json:"snapshot,omitempty"`
 }
 
 type FeatureCreateInput struct {
@@ -51,12 +56,16 @@ type FeatureCreateInput struct {
 	Scope     string
 	Priority  string
 	DependsOn []string
+	Assignee  string
+	Labels    []string
 }
 
 type FeatureListInput struct {
 	ProjectID      string
 	IncludeDeleted bool
 	JSON           bool
+	Labels         []string
+	LabelMatch     string
 }
 
 type FeatureDetailInput struct {
@@ -64,34 +73,55 @@ type FeatureDetailInput struct {
 	FeatureID      string
 	JSON           bool
 	IncludeDeleted bool
+	LastComments   int
 }
 
 type FeatureUpdateInput struct {
-	ProjectID string
-	FeatureID string
-	Name      *string
-	Goal      *string
-	Scope     *string
-	Priority  *string
-	Status    *string
-	Reason    *string
-	DependsOn *[]string
-	Reopen    bool
-	Cancel    bool
-	Force     bool
-	DryRun    bool
+	ProjectID    string
+	FeatureID    string
+	Name         *string
+	Goal         *string
+	Scope        *string
+	Priority     *string
+	Status       *string
+	Reason       *string
+	DependsOn    *[]string
+	Assignee     *string
+	Labels       *[]string
+	LabelsAdd    *[]string
+	LabelsRemove *[]string
+	Reopen       bool
+	Cancel       bool
+	Start        bool
+	Complete     bool
+	Cascade      bool
+	Rewire       *string
+	KeepBlocked  bool
+	Force        bool
+	DryRun       bool
 }
 
 type FeatureListItem struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Goal      string `json:"goal,omitempty"`
-	Scope     string `json:"scope,omitempty"`
-	Priority  string `json:"priority"`
-	Status    string `json:"status"`
-	DependsOn int    `json:"depends_on_count"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Goal      string          `json:"goal,omitempty"`
+	Scope     string          `json:"scope,omitempty"`
+	Priority  string          `json:"priority"`
+	Status    string          `json:"status"`
+	DependsOn int             `json:"depends_on_count"`
+	Assignee  string          `json:"assignee,omitempty"`
+	Labels    []string        `json:"labels,omitempty"`
+	CreatedAt string          `json:"created_at"`
+	UpdatedAt string          `json:"updated_at"`
+	Progress  FeatureProgress `json:"progress"`
+}
+
+// FeatureProgress summarizes a feature's tasks. Cancelled tasks are not
+// counted.
+type FeatureProgress struct {
+	Done    int `json:"done"`
+	Total   int `json:"total"`
+	Percent int `json:"percent"`
 }
 
 type FeatureListOutput struct {
@@ -101,20 +131,25 @@ type FeatureListOutput struct {
 }
 
 type FeatureDetailOutput struct {
-	ID        string   `json:"id"`
-	ProjectID string   `json:"project_id"`
-	Name      string   `json:"name"`
-	Goal      string   `json:"goal"`
-	Scope     string   `json:"scope,omitempty"`
-	Priority  string   `json:"priority"`
-	Status    string   `json:"status"`
-	DependsOn []string `json:"depends_on"`
-	Reason    string   `json:"reason,omitempty"`
-	Events    int      `json:"events"`
-	CreatedAt string   `json:"created_at"`
-	UpdatedAt string   `json:"updated_at"`
-	CreatedBy string   `json:"created_by"`
-	UpdatedBy string   `json:"updated_by"`
+	ID           string          `json:"id"`
+	ProjectID    string          `json:"project_id"`
+	Name         string          `json:"name"`
+	Goal         string          `json:"goal"`
+	Scope        string          `json:"scope,omitempty"`
+	Priority     string          `json:"priority"`
+	Status       string          `json:"status"`
+	DependsOn    []string        `json:"depends_on"`
+	Reason       string          `json:"reason,omitempty"`
+	Assignee     string          `json:"assignee,omitempty"`
+	Labels       []string        `json:"labels,omitempty"`
+	Events       int             `json:"events"`
+	CommentCount int             `json:"comment_count"`
+	Comments     []Comment       `json:"comments,omitempty"`
+	CreatedAt    string          `json:"created_at"`
+	UpdatedAt    string          `json:"updated_at"`
+	CreatedBy    string          `json:"created_by"`
+	UpdatedBy    string          `json:"updated_by"`
+	Progress     FeatureProgress `json:"progress"`
 }
 
 func ValidateFeatureID(id string) bool {
@@ -43,19 +43,24 @@ type Issue struct {
 	AffectedTests       []string  `json:"affected_tests,omitempty"`
 	ImplementationSteps []string  `json:"implementation_steps,omitempty"`
 	LibraryNeeds        []string  `json:"library_needs,omitempty"`
+	Assignee            string    `json:"assignee,omitempty"`
+	Labels              []string  `json:"labels,omitempty"`
 	CreatedAt           time.Time `json:"created_at"`
-	LastUpdatedAt       time.Time `json:"last_updated_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
 	CreatedBy           string    `json:"created_by"`
-	LastUpdatedBy       string    `json:"last_updated_by"`
+	UpdatedBy           string    `json:"updated_by"`
 }
 
 type IssueEvent struct {
-	Layer   string    `json:"layer"`
-	Type    string    `json:"type"`
-	ID      string    `json:"id"`
-	By      string    `json:"by"`
-	Ts      time.Time `json:"ts"`
-	Changes []string  `json:"changes,omitempty"`
+	Layer   string        `json:"layer"`
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	By      string        `json:"by"`
+	Ts      time.Time     `json:"ts"`
+	Changes []string      `json:"changes,omitempty"`
+	Diff    []FieldChange `json:"diff,omitempty"`
+	// Snapshot is the full issue after the change, so state can be rebuilt from events.jsonl
+	Snapshot *Issue `json:"snapshot,omitempty"`
 }
 
 type IssueCreateInput struct {
@@ -69,6 +74,8 @@ type IssueCreateInput struct {
 	AffectedTests       []string
 	ImplementationSteps []string
 	LibraryNeeds        []string
+	Assignee            string
+	Labels              []string
 }
 
 type IssueListInput struct {
@@ -76,6 +83,9 @@ type IssueListInput struct {
 	IssueType      string
 	Status         string
 	Priority       string
+	Assignee       string
+	Labels         []string
+	LabelMatch     string
 	IncludeDeleted bool
 	JSON           bool
 	Sort           string
@@ -90,6 +100,7 @@ type IssueDetailInput struct {
 	Events         bool
 	Dependencies   bool
 	Timestamps     bool
+	LastComments   int
 }
 
 type IssueUpdateInput struct {
@@ -108,6 +119,10 @@ type IssueUpdateInput struct {
 	AffectedTests       *[]string
 	ImplementationSteps *[]string
 	LibraryNeeds        *[]string
+	Assignee            *string
+	Labels              *[]string
+	LabelsAdd           *[]string
+	LabelsRemove        *[]string
 	Start               bool
 	Resolve             bool
 	WontFix             bool
@@ -118,19 +133,21 @@ type IssueUpdateInput struct {
 }
 
 type IssueListItem struct {
-	ID                       string `json:"id"`
-	Name                     string `json:"name"`
-	IssueType                string `json:"issue_type"`
-	Status                   string `json:"status"`
-	Priority                 string `json:"priority"`
-	ProjectID                string `json:"project_id"`
-	DependsOnCount           int    `json:"depends_on_count"`
-	AffectedFilesCount       int    `json:"affected_files_count"`
-	AffectedTestsCount       int    `json:"affected_tests_count"`
-	ImplementationStepsCount int    `json:"implementation_steps_count"`
-	LibraryNeedsCount        int    `json:"library_needs_count"`
-	CreatedAt                string `json:"created_at"`
-	LastUpdatedAt            string `json:"last_updated_at"`
+	ID                       string   `json:"id"`
+	Name                     string   `json:"name"`
+	IssueType                string   `json:"issue_type"`
+	Status                   string   `json:"status"`
+	Priority                 string   `json:"priority"`
+	ProjectID                string   `json:"project_id"`
+	DependsOnCount           int      `json:"depends_on_count"`
+	AffectedFilesCount       int      `json:"affected_files_count"`
+	AffectedTestsCount       int      `json:"affected_tests_count"`
+	ImplementationStepsCount int      `json:"implementation_steps_count"`
+	LibraryNeedsCount        int      `json:"library_needs_count"`
+	Assignee                 string   `json:"assignee,omitempty"`
+	Labels                   []string `json:"labels,omitempty"`
+	CreatedAt                string   `json:"created_at"`
+	LastUpdatedAt            string   `json:"last_updated_at"`
 }
 
 type IssueListOutput struct {
@@ -140,24 +157,28 @@ type IssueListOutput struct {
 }
 
 type IssueDetailOutput struct {
-	ID                  string   `json:"id"`
-	ProjectID           string   `json:"project_id"`
-	Name                string   `json:"name"`
-	Goal                string   `json:"goal,omitempty"`
-	IssueType           string   `json:"issue_type"`
-	Priority            string   `json:"priority"`
-	Status              string   `json:"status"`
-	DependsOn           []string `json:"depends_on"`
-	Reason              string   `json:"reason,omitempty"`
-	AffectedFiles       []string `json:"affected_files"`
-	AffectedTests       []string `json:"affected_tests"`
-	ImplementationSteps []string `json:"implementation_steps"`
-	LibraryNeeds        []string `json:"library_needs"`
-	Events              int      `json:"events"`
-	CreatedAt           string   `json:"created_at"`
-	LastUpdatedAt       string   `json:"last_updated_at"`
-	CreatedBy           string   `json:"created_by"`
-	LastUpdatedBy       string   `json:"last_updated_by"`
+	ID                  string    `json:"id"`
+	ProjectID           string    `json:"project_id"`
+	Name                string    `json:"name"`
+	Goal                string    `json:"goal,omitempty"`
+	IssueType           string    `json:"issue_type"`
+	Priority            string    `json:"priority"`
+	Status              string    `json:"status"`
+	DependsOn           []string  `json:"depends_on"`
+	Reason              string    `json:"reason,omitempty"`
+	AffectedFiles       []string  `json:"affected_files"`
+	AffectedTests       []string  `json:"affected_tests"`
+	ImplementationSteps []string  `json:"implementation_steps"`
+	LibraryNeeds        []string  `json:"library_needs"`
+	Assignee            string    `json:"assignee,omitempty"`
+	Labels              []string  `json:"labels,omitempty"`
+	Events              int       `json:"events"`
+	CommentCount        int       `json:"comment_count"`
+	Comments            []Comment `json:"comments,omitempty"`
+	CreatedAt           string    `json:"created_at"`
+	LastUpdatedAt       string    `json:"last_updated_at"`
+	CreatedBy           string    `json:"created_by"`
+	LastUpdatedBy       string    `json:"last_updated_by"`
 }
 
 func ValidateIssueID(id string) bool {
@@ -51,12 +51,38 @@ func ValidateGoalLength(goal string) bool {
 }
 
 type ProjectEvent struct {
-	Layer   string    `json:"layer"`
-	Type    string    `json:"type"`
-	ID      string    `json:"id"`
-	By      string    `json:"by"`
-	Ts      time.Time `json:"ts"`
-	Changes []string  `json:"changes,omitempty"`
+	Layer   string        `json:"layer"`
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	By      string        `json:"by"`
+	Ts      time.Time     `json:"ts"`
+	Changes []string      `json:"changes,omitempty"`
+	Diff    []FieldChange `json:"diff,omitempty"`
+	// Snapshot is the full project after the change, so state can be rebuilt from events.jsonl
+	Snapshot *Project `json:"snapshot,omitempty"`
+	// Repair describes what `doctor --fix` repaired, on "repaired" events
+	Repair *RepairRecord `json:"repair,omitempty"`
+	// Import describes the bundle written by `mandor import`, on "imported" events
+	Import *ImportRecord `json:"import,omitempty"`
+}
+
+// ImportRecord is the import mode and entity counts of one imported project
+type ImportRecord struct {
+	Mode     string `json:"mode"`
+	SourceID string `json:"source_id"`
+	Features int    `json:"features"`
+	Tasks    int    `json:"tasks"`
+	Issues   int    `json:"issues"`
+}
+
+// RepairRecord is one repair made by `doctor --fix` outside any single
+// entity: a duplicate ID line dropped from an entity file, or a leftover
+// temporary file removed
+type RepairRecord struct {
+	Code  string `json:"code"`
+	Layer string `json:"layer,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Path  string `json:"path,omitempty"`
 }
 
 type ProjectSchema struct {
@@ -70,6 +96,21 @@ type ProjectRules struct {
 	Feature  DependencyRule `json:"feature"`
 	Issue    DependencyRule `json:"issue"`
 	Priority PriorityConfig `json:"priority"`
+	Rollup   RollupConfig   `json:"rollup"`
+	Labels   LabelConfig    `json:"labels"`
+}
+
+// LabelConfig restricts the labels features, tasks and issues may carry
+type LabelConfig struct {
+	// Allowed lists the permitted labels; empty allows any label
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// RollupConfig controls how feature status follows its tasks
+type RollupConfig struct {
+	// AutoCompleteFeatures marks a feature done once all its tasks are done
+	// or cancelled
+	AutoCompleteFeatures bool `json:"auto_complete_features"`
 }
 
 type DependencyRule struct {
@@ -160,6 +201,11 @@ type EntityStats struct {
 	ByType       map[string]int `json:"by_type,omitempty"`
 	AvgPriority  string         `json:"avg_priority"`
 	BlockedCount int            `json:"blocked_count,omitempty"`
+	// ByAssignee counts open items per assignee; unassigned items are
+	// counted under "unassigned"
+	ByAssignee map[string]int `json:"by_assignee,omitempty"`
+	// ByLabel counts open items per label
+	ByLabel map[string]int `json:"by_label,omitempty"`
 }
 
 type TimelineStats struct {
@@ -224,6 +270,13 @@ type ProjectUpdateInput struct {
 	FeatureDep *string
 	IssueDep   *string
 	Strict     *bool
+	// PriorityLevels is a comma separated list, most urgent first
+	PriorityLevels  *string
+	PriorityDefault *string
+	// AutoCompleteFeatures toggles rules.rollup.auto_complete_features
+	AutoCompleteFeatures *bool
+	// AllowedLabels is a comma separated list; empty allows any label
+	AllowedLabels *string
 }
 
 type ProjectDeleteInput struct {
@@ -237,3 +290,7 @@ type ProjectReopenInput struct {
 	ID  string
 	Yes bool
 }
+
+type ProjectCompleteInput struct {
+	ID string
+}
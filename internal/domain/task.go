@@ -21,32 +21,39 @@ const (
 )
 
 type Task struct {
-	ID                  string    `json:"id"`
-	FeatureID           string    `json:"feature_id"`
-	ProjectID           string    `json:"project_id"`
-	Name                string    `json:"name"`
-	Goal                string    `json:"goal"`
-	Priority            string    `json:"priority"`
-	Status              string    `json:"status"`
-	DependsOn           []string  `json:"depends_on,omitempty"`
-	Reason              string    `json:"reason,omitempty"`
-	ImplementationSteps []string  `json:"implementation_steps,omitempty"`
-	TestCases           []string  `json:"test_cases,omitempty"`
-	DerivableFiles      []string  `json:"derivable_files,omitempty"`
-	LibraryNeeds        []string  `json:"library_needs,omitempty"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
-	CreatedBy           string    `json:"created_by"`
-	UpdatedBy           string    `json:"updated_by"`
+	ID                  string     `json:"id"`
+	FeatureID           string     `json:"feature_id"`
+	ProjectID           string     `json:"project_id"`
+	Name                string     `json:"name"`
+	Goal                string     `json:"goal"`
+	Priority            string     `json:"priority"`
+	Status              string     `json:"status"`
+	DependsOn           []string   `json:"depends_on,omitempty"`
+	Reason              string     `json:"reason,omitempty"`
+	ImplementationSteps []string   `json:"implementation_steps,omitempty"`
+	TestCases           []string   `json:"test_cases,omitempty"`
+	DerivableFiles      []string   `json:"derivable_files,omitempty"`
+	LibraryNeeds        []string   `json:"library_needs,omitempty"`
+	Assignee            string     `json:"assignee,omitempty"`
+	Labels              []string   `json:"labels,omitempty"`
+	ClaimedBy           string     `json:"claimed_by,omitempty"`
+	LeaseExpiresAt      *time.Time `json:"lease_expires_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	CreatedBy           string     `json:"created_by"`
+	UpdatedBy           string     `json:"updated_by"`
 }
 
 type TaskEvent struct {
-	Layer   string    `json:"layer"`
-	Type    string    `json:"type"`
-	ID      string    `json:"id"`
-	By      string    `json:"by"`
-	Ts      time.Time `json:"ts"`
-	Changes []string  `json:"changes,omitempty"`
+	Layer   string        `json:"layer"`
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	By      string        `json:"by"`
+	Ts      time.Time     `json:"ts"`
+	Changes []string      `json:"changes,omitempty"`
+	Diff    []FieldChange `json:"diff,omitempty"`
+	// Snapshot is the full task after the change, so state can be rebuilt from events.jsonl
+	Snapshot *Task `json:"snapshot,omitempty"`
 }
 
 type TaskCreateInput struct {
@@ -59,6 +66,8 @@ type TaskCreateInput struct {
 	LibraryNeeds        []string
 	Priority            string
 	DependsOn           []string
+	Assignee            string
+	Labels              []string
 }
 
 type TaskListInput struct {
@@ -66,6 +75,9 @@ type TaskListInput struct {
 	ProjectID      string
 	Status         string
 	Priority       string
+	Assignee       string
+	Labels         []string
+	LabelMatch     string
 	IncludeDeleted bool
 	JSON           bool
 	Sort           string
@@ -80,6 +92,7 @@ type TaskDetailInput struct {
 	Events         bool
 	Dependencies   bool
 	Timestamps     bool
+	LastComments   int
 }
 
 type TaskUpdateInput struct {
@@ -99,20 +112,50 @@ type TaskUpdateInput struct {
 	DependsRemove       *[]string
 	Reopen              bool
 	Cancel              bool
+	Cascade             bool
+	Rewire              *string
+	KeepBlocked         bool
+	Assignee            *string
+	Labels              *[]string
+	LabelsAdd           *[]string
+	LabelsRemove        *[]string
 	Force               bool
 	DryRun              bool
 }
 
+// TaskClaimInput picks the best ready task for Agent, optionally limited to
+// a project or feature, and holds it for Lease
+type TaskClaimInput struct {
+	ProjectID string
+	FeatureID string
+	Agent     string
+	Lease     time.Duration
+}
+
+type TaskHeartbeatInput struct {
+	TaskID string
+	Agent  string
+	Lease  time.Duration
+}
+
+type TaskReleaseInput struct {
+	TaskID string
+	Agent  string
+}
+
 type TaskListItem struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	Status         string `json:"status"`
-	Priority       string `json:"priority"`
-	FeatureID      string `json:"feature_id"`
-	ProjectID      string `json:"project_id"`
-	DependsOnCount int    `json:"depends_on_count"`
-	CreatedAt      string `json:"created_at"`
-	UpdatedAt      string `json:"updated_at"`
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Status         string   `json:"status"`
+	Priority       string   `json:"priority"`
+	FeatureID      string   `json:"feature_id"`
+	ProjectID      string   `json:"project_id"`
+	DependsOnCount int      `json:"depends_on_count"`
+	Assignee       string   `json:"assignee,omitempty"`
+	Labels         []string `json:"labels,omitempty"`
+	ClaimedBy      string   `json:"claimed_by,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
 }
 
 type TaskListOutput struct {
@@ -122,24 +165,30 @@ type TaskListOutput struct {
 }
 
 type TaskDetailOutput struct {
-	ID                  string   `json:"id"`
-	FeatureID           string   `json:"feature_id"`
-	ProjectID           string   `json:"project_id"`
-	Name                string   `json:"name"`
-	Goal                string   `json:"goal"`
-	Priority            string   `json:"priority"`
-	Status              string   `json:"status"`
-	DependsOn           []string `json:"depends_on"`
-	Reason              string   `json:"reason,omitempty"`
-	ImplementationSteps []string `json:"implementation_steps"`
-	TestCases           []string `json:"test_cases"`
-	DerivableFiles      []string `json:"derivable_files"`
-	LibraryNeeds        []string `json:"library_needs"`
-	Events              int      `json:"events"`
-	CreatedAt           string   `json:"created_at"`
-	UpdatedAt           string   `json:"updated_at"`
-	CreatedBy           string   `json:"created_by"`
-	UpdatedBy           string   `json:"updated_by"`
+	ID                  string    `json:"id"`
+	FeatureID           string    `json:"feature_id"`
+	ProjectID           string    `json:"project_id"`
+	Name                string    `json:"name"`
+	Goal                string    `json:"goal"`
+	Priority            string    `json:"priority"`
+	Status              string    `json:"status"`
+	DependsOn           []string  `json:"depends_on"`
+	Reason              string    `json:"reason,omitempty"`
+	ImplementationSteps []string  `json:"implementation_steps"`
+	TestCases           []string  `json:"test_cases"`
+	DerivableFiles      []string  `json:"derivable_files"`
+	LibraryNeeds        []string  `json:"library_needs"`
+	Assignee            string    `json:"assignee,omitempty"`
+	Labels              []string  `json:"labels,omitempty"`
+	ClaimedBy           string    `json:"claimed_by,omitempty"`
+	LeaseExpiresAt      string    `json:"lease_expires_at,omitempty"`
+	Events              int       `json:"events"`
+	CommentCount        int       `json:"comment_count"`
+	Comments            []Comment `json:"comments,omitempty"`
+	CreatedAt           string    `json:"created_at"`
+	UpdatedAt           string    `json:"updated_at"`
+	CreatedBy           string    `json:"created_by"`
+	UpdatedBy           string    `json:"updated_by"`
 }
 
 func ValidateTaskID(id string) bool {
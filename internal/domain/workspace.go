@@ -1,6 +1,27 @@
 package domain
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CurrentSchemaVersion is the on-disk layout this build reads and writes.
+// Older workspaces are upgraded with `mandor migrate`.
+const CurrentSchemaVersion = "mandor.v2"
+
+// SchemaVersionNumber parses a "mandor.vN" schema version. Workspaces created
+// before schema_version was populated are treated as v1.
+func SchemaVersionNumber(version string) (int, bool) {
+	if version == "" {
+		return 1, true
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(version, "mandor.v"))
+	if err != nil || !strings.HasPrefix(version, "mandor.v") {
+		return 0, false
+	}
+	return n, true
+}
 
 // Workspace represents the root workspace configuration
 type Workspace struct {
@@ -19,6 +40,7 @@ type WorkspaceConfig struct {
 	DefaultPriority string `json:"default_priority"`
 	StrictMode      bool   `json:"strict_mode"`
 	DefaultProject  string `json:"default_project,omitempty"`
+	LockTimeout     string `json:"lock_timeout,omitempty"`
 }
 
 // DefaultWorkspaceConfig returns the default configuration
@@ -0,0 +1,70 @@
+package fs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// TmpSuffix is appended to a file while it is being rewritten
+const TmpSuffix = ".tmp"
+
+// writeFileAtomic writes data to path.tmp, fsyncs it, renames it over path
+// and fsyncs the parent directory so the rename itself survives a crash.
+// Readers only ever see the old content or the complete new content.
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + TmpSuffix
+
+	file, err := os.OpenFile(tmpPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	syncDir(filepath.Dir(path))
+	return nil
+}
+
+// writeNDJSONAtomic encodes every item as one JSON line and writes the
+// result with writeFileAtomic.
+func writeNDJSONAtomic[T any](path string, items []T) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// syncDir flushes directory metadata. Some platforms (Windows) cannot open
+// directories for syncing; that is best-effort and ignored.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	defer d.Close()
+	d.Sync()
+}
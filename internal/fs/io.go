@@ -245,17 +245,10 @@ func (w *Writer) WriteWorkspace(ws *domain.Workspace) error {
 	path := w.paths.WorkspacePath()
 
 	// Write to temporary file first for atomic operation
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+	if err := writeFileAtomic(path, data); err != nil {
 		if os.IsPermission(err) {
 			return domain.NewPermissionError("Permission denied. Cannot write to .mandor/workspace.json.")
 		}
-		return domain.NewSystemError("Cannot write workspace file", err)
-	}
-
-	// Atomic rename
-	if err := os.Rename(tmpPath, path); err != nil {
-		os.Remove(tmpPath)
 		return domain.NewSystemError("Cannot save workspace file", err)
 	}
 
@@ -264,6 +257,12 @@ func (w *Writer) WriteWorkspace(ws *domain.Workspace) error {
 
 // AppendNDJSON appends a JSON object as a new line to NDJSON file
 func (w *Writer) AppendNDJSON(filepath string, obj interface{}) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	data, err := json.Marshal(obj)
 	if err != nil {
 		return domain.NewSystemError("Cannot marshal to JSON", err)
@@ -278,11 +277,12 @@ func (w *Writer) AppendNDJSON(filepath string, obj interface{}) error {
 	}
 	defer file.Close()
 
-	if _, err := file.Write(data); err != nil {
+	// Single write so a crash can't separate the record from its newline
+	if _, err := file.Write(append(data, '\n')); err != nil {
 		return domain.NewSystemError("Cannot write to file", err)
 	}
-	if _, err := file.WriteString("\n"); err != nil {
-		return domain.NewSystemError("Cannot write newline", err)
+	if err := file.Sync(); err != nil {
+		return domain.NewSystemError("Cannot flush file to disk", err)
 	}
 
 	return nil
@@ -304,22 +304,95 @@ func (w *Writer) WriteJSON(filePath string, obj interface{}) error {
 		return domain.NewSystemError("Cannot marshal to JSON", err)
 	}
 
-	tmpPath := filePath + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+	if err := writeFileAtomic(filePath, data); err != nil {
 		if os.IsPermission(err) {
 			return domain.NewPermissionError("Permission denied. Cannot write file.")
 		}
-		return domain.NewSystemError("Cannot write file", err)
+		return domain.NewSystemError("Cannot save file", err)
 	}
 
-	if err := os.Rename(tmpPath, filePath); err != nil {
-		os.Remove(tmpPath)
-		return domain.NewSystemError("Cannot save file", err)
+	return nil
+}
+
+// RewriteNDJSON atomically replaces an NDJSON file with the given lines
+func (w *Writer) RewriteNDJSON(filePath string, lines []json.RawMessage) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := writeNDJSONAtomic(filePath, lines); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot write " + filepath.Base(filePath) + ".")
+		}
+		return domain.NewSystemError("Cannot write "+filepath.Base(filePath), err)
+	}
+	return nil
+}
+
+// BackupMandorDir copies the .mandor directory to dest, skipping the lock file
+func (w *Writer) BackupMandorDir(dest string) error {
+	if err := copyMandorTree(w.paths.MandorDirPath(), dest); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot back up .mandor to " + dest + ".")
+		}
+		return domain.NewSystemError("Cannot back up .mandor directory", err)
 	}
+	return nil
+}
 
+// RestoreMandorDir puts back a copy made by BackupMandorDir, replacing
+// everything in .mandor except the lock file, which the caller still holds
+func (w *Writer) RestoreMandorDir(src string) error {
+	dir := w.paths.MandorDirPath()
+	entries, err := os.ReadDir(dir)
+	if err == nil {
+		for _, entry := range entries {
+			if entry.Name() == LockFile {
+				continue
+			}
+			if err = os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil {
+		err = copyMandorTree(src, dir)
+	}
+	if err != nil {
+		return domain.NewSystemError("Cannot restore .mandor from "+src, err)
+	}
 	return nil
 }
 
+// copyMandorTree copies src to dest, skipping the lock file
+func copyMandorTree(src, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if rel == LockFile {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
 // MandorDirExists checks if .mandor directory exists
 func (w *Writer) MandorDirExists() bool {
 	_, err := os.Stat(w.paths.MandorDirPath())
@@ -367,16 +440,10 @@ func (w *Writer) WriteProjectMetadata(projectID string, project *domain.Project)
 		return domain.NewSystemError("Cannot create project directory", err)
 	}
 
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+	if err := writeFileAtomic(path, data); err != nil {
 		if os.IsPermission(err) {
 			return domain.NewPermissionError("Permission denied. Cannot write to project.jsonl.")
 		}
-		return domain.NewSystemError("Cannot write project file", err)
-	}
-
-	if err := os.Rename(tmpPath, path); err != nil {
-		os.Remove(tmpPath)
 		return domain.NewSystemError("Cannot save project file", err)
 	}
 
@@ -393,6 +460,24 @@ func (w *Writer) AppendProjectEvent(projectID string, event *domain.ProjectEvent
 	return w.AppendNDJSON(w.paths.ProjectEventsPath(projectID), event)
 }
 
+// AppendComment appends a comment to comments.jsonl
+func (w *Writer) AppendComment(projectID string, comment *domain.Comment) error {
+	return w.AppendNDJSON(w.paths.ProjectCommentsPath(projectID), comment)
+}
+
+// ReplaceComments atomically rewrites comments.jsonl with comments
+func (w *Writer) ReplaceComments(projectID string, comments []*domain.Comment) error {
+	lines := make([]json.RawMessage, 0, len(comments))
+	for _, comment := range comments {
+		data, err := json.Marshal(comment)
+		if err != nil {
+			return domain.NewSystemError("Cannot marshal comment", err)
+		}
+		lines = append(lines, data)
+	}
+	return w.RewriteNDJSON(w.paths.ProjectCommentsPath(projectID), lines)
+}
+
 // DeleteProjectDir removes the project directory and all contents
 func (w *Writer) DeleteProjectDir(projectID string) error {
 	projectDir := w.paths.ProjectDirPath(projectID)
@@ -458,11 +543,17 @@ func (w *Writer) WriteFeature(projectID string, feature *domain.Feature) error {
 }
 
 func (w *Writer) ReplaceFeature(projectID string, feature *domain.Feature) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	featuresPath := w.paths.ProjectFeaturesPath(projectID)
 
 	var features []*domain.Feature
 	reader := NewReader(w.paths)
-	err := reader.ReadNDJSON(featuresPath, func(raw []byte) error {
+	err = reader.ReadNDJSON(featuresPath, func(raw []byte) error {
 		var f domain.Feature
 		if err := json.Unmarshal(raw, &f); err != nil {
 			return err
@@ -478,17 +569,11 @@ func (w *Writer) ReplaceFeature(projectID string, feature *domain.Feature) error
 
 	features = append(features, feature)
 
-	file, err := os.OpenFile(featuresPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return domain.NewSystemError("Cannot open features file for writing", err)
-	}
-	defer file.Close()
-
-	for _, f := range features {
-		encoder := json.NewEncoder(file)
-		if err := encoder.Encode(f); err != nil {
-			return domain.NewSystemError("Cannot write feature", err)
+	if err := writeNDJSONAtomic(featuresPath, features); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot write to features.jsonl.")
 		}
+		return domain.NewSystemError("Cannot write features file", err)
 	}
 
 	return nil
@@ -524,11 +609,17 @@ func (w *Writer) WriteTask(projectID string, task *domain.Task) error {
 }
 
 func (w *Writer) ReplaceTask(projectID string, task *domain.Task) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	tasksPath := w.paths.ProjectTasksPath(projectID)
 
 	var tasks []*domain.Task
 	reader := NewReader(w.paths)
-	err := reader.ReadNDJSON(tasksPath, func(raw []byte) error {
+	err = reader.ReadNDJSON(tasksPath, func(raw []byte) error {
 		var t domain.Task
 		if err := json.Unmarshal(raw, &t); err != nil {
 			return err
@@ -544,17 +635,11 @@ func (w *Writer) ReplaceTask(projectID string, task *domain.Task) error {
 
 	tasks = append(tasks, task)
 
-	file, err := os.OpenFile(tasksPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return domain.NewSystemError("Cannot open tasks file for writing", err)
-	}
-	defer file.Close()
-
-	for _, t := range tasks {
-		encoder := json.NewEncoder(file)
-		if err := encoder.Encode(t); err != nil {
-			return domain.NewSystemError("Cannot write task", err)
+	if err := writeNDJSONAtomic(tasksPath, tasks); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot write to tasks.jsonl.")
 		}
+		return domain.NewSystemError("Cannot write tasks file", err)
 	}
 
 	return nil
@@ -563,6 +648,12 @@ func (w *Writer) ReplaceTask(projectID string, task *domain.Task) error {
 // ReplaceTasks updates multiple tasks atomically. allTasks is all tasks from file,
 // tasksToUpdate is a map of task IDs to updated task objects.
 func (w *Writer) ReplaceTasks(projectID string, allTasks []*domain.Task, tasksToUpdate map[string]*domain.Task) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	tasksPath := w.paths.ProjectTasksPath(projectID)
 
 	// Build final task list with updates applied
@@ -575,18 +666,11 @@ func (w *Writer) ReplaceTasks(projectID string, allTasks []*domain.Task, tasksTo
 		}
 	}
 
-	// Write all tasks atomically
-	file, err := os.OpenFile(tasksPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return domain.NewSystemError("Cannot open tasks file for writing", err)
-	}
-	defer file.Close()
-
-	for _, t := range finalTasks {
-		encoder := json.NewEncoder(file)
-		if err := encoder.Encode(t); err != nil {
-			return domain.NewSystemError("Cannot write task", err)
+	if err := writeNDJSONAtomic(tasksPath, finalTasks); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot write to tasks.jsonl.")
 		}
+		return domain.NewSystemError("Cannot write tasks file", err)
 	}
 
 	return nil
@@ -595,6 +679,12 @@ func (w *Writer) ReplaceTasks(projectID string, allTasks []*domain.Task, tasksTo
 // ReplaceFeatures updates multiple features atomically. allFeatures is all features from file,
 // featuresToUpdate is a map of feature IDs to updated feature objects.
 func (w *Writer) ReplaceFeatures(projectID string, allFeatures []*domain.Feature, featuresToUpdate map[string]*domain.Feature) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	featuresPath := w.paths.ProjectFeaturesPath(projectID)
 
 	// Build final feature list with updates applied
@@ -607,24 +697,23 @@ func (w *Writer) ReplaceFeatures(projectID string, allFeatures []*domain.Feature
 		}
 	}
 
-	// Write all features atomically
-	file, err := os.OpenFile(featuresPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return domain.NewSystemError("Cannot open features file for writing", err)
-	}
-	defer file.Close()
-
-	for _, f := range finalFeatures {
-		encoder := json.NewEncoder(file)
-		if err := encoder.Encode(f); err != nil {
-			return domain.NewSystemError("Cannot write feature", err)
+	if err := writeNDJSONAtomic(featuresPath, finalFeatures); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot write to features.jsonl.")
 		}
+		return domain.NewSystemError("Cannot write features file", err)
 	}
 
 	return nil
 }
 
 func (w *Writer) ReplaceIssues(projectID string, allIssues []*domain.Issue, issuesToUpdate map[string]*domain.Issue) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	issuesPath := w.paths.ProjectIssuesPath(projectID)
 
 	// Build final issues list with updates applied
@@ -637,18 +726,11 @@ func (w *Writer) ReplaceIssues(projectID string, allIssues []*domain.Issue, issu
 		}
 	}
 
-	// Write all issues atomically
-	file, err := os.OpenFile(issuesPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return domain.NewSystemError("Cannot open issues file for writing", err)
-	}
-	defer file.Close()
-
-	for _, i := range finalIssues {
-		encoder := json.NewEncoder(file)
-		if err := encoder.Encode(i); err != nil {
-			return domain.NewSystemError("Cannot write issue", err)
+	if err := writeNDJSONAtomic(issuesPath, finalIssues); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot write to issues.jsonl.")
 		}
+		return domain.NewSystemError("Cannot write issues file", err)
 	}
 
 	return nil
@@ -684,11 +766,17 @@ func (w *Writer) WriteIssue(projectID string, issue *domain.Issue) error {
 }
 
 func (w *Writer) ReplaceIssue(projectID string, issue *domain.Issue) error {
+	unlock, err := w.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	issuesPath := w.paths.ProjectIssuesPath(projectID)
 
 	var issues []*domain.Issue
 	reader := NewReader(w.paths)
-	err := reader.ReadNDJSON(issuesPath, func(raw []byte) error {
+	err = reader.ReadNDJSON(issuesPath, func(raw []byte) error {
 		var i domain.Issue
 		if err := json.Unmarshal(raw, &i); err != nil {
 			return err
@@ -704,17 +792,11 @@ func (w *Writer) ReplaceIssue(projectID string, issue *domain.Issue) error {
 
 	issues = append(issues, issue)
 
-	file, err := os.OpenFile(issuesPath, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return domain.NewSystemError("Cannot open issues file for writing", err)
-	}
-	defer file.Close()
-
-	for _, i := range issues {
-		encoder := json.NewEncoder(file)
-		if err := encoder.Encode(i); err != nil {
-			return domain.NewSystemError("Cannot write issue", err)
+	if err := writeNDJSONAtomic(issuesPath, issues); err != nil {
+		if os.IsPermission(err) {
+			return domain.NewPermissionError("Permission denied. Cannot write to issues.jsonl.")
 		}
+		return domain.NewSystemError("Cannot write issues file", err)
 	}
 
 	return nil
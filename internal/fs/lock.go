@@ -0,0 +1,128 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"mandor/internal/domain"
+	"mandor/internal/util"
+)
+
+const (
+	// LockFile is the advisory lock guarding every mutation of .mandor
+	LockFile = ".lock"
+
+	// DefaultLockTimeout is how long a command waits for another mandor process
+	DefaultLockTimeout = 10 * time.Second
+
+	lockRetryInterval = 50 * time.Millisecond
+)
+
+// heldLock tracks a lock owned by this process so nested callers
+// (service -> writer) can re-enter it without deadlocking on themselves.
+type heldLock struct {
+	file  *os.File
+	depth int
+}
+
+var processLocks = struct {
+	sync.Mutex
+	held map[string]*heldLock
+}{held: make(map[string]*heldLock)}
+
+// AcquireFileLock takes an exclusive advisory lock on path, retrying until
+// timeout elapses. It is not reentrant; most callers want Writer.Lock.
+func AcquireFileLock(path string, timeout time.Duration) (*os.File, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		file, acquired, err := tryLockFile(path)
+		if err != nil {
+			if os.IsPermission(err) {
+				return nil, domain.NewPermissionError("Permission denied. Cannot create lock file " + path)
+			}
+			return nil, domain.NewSystemError("Cannot acquire workspace lock", err)
+		}
+		if acquired {
+			return file, nil
+		}
+		if !time.Now().Before(deadline) {
+			return nil, domain.NewLockError(fmt.Sprintf(
+				"Workspace is locked by another mandor process (waited %s for %s).\nRetry, or raise the wait with MANDOR_LOCK_TIMEOUT or `mandor config set lock_timeout <duration>`.%s",
+				timeout, path, staleLockHint(path),
+			))
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// ReleaseFileLock releases a lock obtained with AcquireFileLock
+func ReleaseFileLock(file *os.File) {
+	unlockFile(file)
+}
+
+// Lock acquires the workspace-wide lock (.mandor/.lock), waiting up to the
+// configured timeout. The returned function releases it. The lock is
+// reentrant within a process, so services may hold it across several
+// Writer calls that also lock.
+func (w *Writer) Lock() (func(), error) {
+	path := w.paths.LockPath()
+
+	processLocks.Lock()
+	defer processLocks.Unlock()
+
+	if held, ok := processLocks.held[path]; ok {
+		held.depth++
+		return w.unlockFunc(path), nil
+	}
+
+	if _, err := os.Stat(w.paths.MandorDirPath()); err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.NewValidationError("Workspace not initialized. Run `mandor init` first.")
+		}
+		return nil, domain.NewSystemError("Cannot access .mandor directory", err)
+	}
+
+	file, err := AcquireFileLock(path, w.lockTimeout())
+	if err != nil {
+		return nil, err
+	}
+	processLocks.held[path] = &heldLock{file: file, depth: 1}
+	return w.unlockFunc(path), nil
+}
+
+func (w *Writer) unlockFunc(path string) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			processLocks.Lock()
+			defer processLocks.Unlock()
+
+			held, ok := processLocks.held[path]
+			if !ok {
+				return
+			}
+			held.depth--
+			if held.depth == 0 {
+				unlockFile(held.file)
+				delete(processLocks.held, path)
+			}
+		})
+	}
+}
+
+// lockTimeout resolves the wait timeout: MANDOR_LOCK_TIMEOUT, then the
+// workspace lock_timeout setting, then DefaultLockTimeout.
+func (w *Writer) lockTimeout() time.Duration {
+	if timeout, ok := util.GetLockTimeout(); ok {
+		return timeout
+	}
+	ws, err := NewReader(w.paths).ReadWorkspace()
+	if err == nil && ws.Config.LockTimeout != "" {
+		if timeout, err := time.ParseDuration(ws.Config.LockTimeout); err == nil && timeout > 0 {
+			return timeout
+		}
+	}
+	return DefaultLockTimeout
+}
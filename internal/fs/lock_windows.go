@@ -0,0 +1,78 @@
+//go:build windows
+
+package fs
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// stillActive is the exit code GetExitCodeProcess reports for a running process
+const stillActive = 259
+
+// tryLockFile emulates an exclusive lock by creating path with O_EXCL and
+// writing the owner's PID into it. The file is removed on unlock; one left
+// behind by a crashed process is detected through its PID and taken over.
+func tryLockFile(path string) (*os.File, bool, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0644)
+	if err != nil {
+		if !os.IsExist(err) {
+			return nil, false, err
+		}
+		if !staleLock(path) {
+			return nil, false, nil
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return tryLockFile(path)
+	}
+	if _, err := file.WriteString(strconv.Itoa(os.Getpid())); err != nil {
+		unlockFile(file)
+		return nil, false, err
+	}
+	return file, true, nil
+}
+
+// staleLock reports whether the lock at path names a process that is no
+// longer running. A lock without a readable PID is still being written
+// (or predates PIDs) and is left alone.
+func staleLock(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return false
+	}
+	return !processRunning(pid)
+}
+
+func processRunning(pid int) bool {
+	handle, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		// Access denied means the process exists but belongs to someone else
+		return err == syscall.ERROR_ACCESS_DENIED
+	}
+	defer syscall.CloseHandle(handle)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(handle, &code); err != nil {
+		return true
+	}
+	return code == stillActive
+}
+
+// staleLockHint names the file to delete when the owner cannot be detected
+func staleLockHint(path string) string {
+	return "\nIf no mandor process is running, delete " + path + "."
+}
+
+func unlockFile(file *os.File) {
+	name := file.Name()
+	file.Close()
+	os.Remove(name)
+}
@@ -16,15 +16,88 @@ type Paths struct {
 	WorkspaceRoot string
 }
 
-// NewPaths creates a new Paths instance for the current working directory
+// WorkspaceEnv names the environment variable that pins the workspace root
+const WorkspaceEnv = "MANDOR_WORKSPACE"
+
+// WorkspaceOverride is set by the global --workspace flag. It takes
+// precedence over MANDOR_WORKSPACE and over discovery from the cwd.
+var WorkspaceOverride string
+
+// NewPaths creates a new Paths instance for the workspace containing the
+// current working directory. The root is resolved from --workspace, then
+// MANDOR_WORKSPACE, then the nearest parent directory holding .mandor; when
+// none is found the cwd is used so callers can report "not initialized".
 func NewPaths() (*Paths, error) {
+	if root, ok, err := explicitWorkspaceRoot(); ok || err != nil {
+		return &Paths{WorkspaceRoot: root}, err
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
+	if root, found := FindWorkspaceRoot(cwd); found {
+		return &Paths{WorkspaceRoot: root}, nil
+	}
 	return &Paths{WorkspaceRoot: cwd}, nil
 }
 
+// NewInitPaths creates a Paths instance for `mandor init`, which creates the
+// workspace in the cwd (or the explicit root) instead of walking up.
+func NewInitPaths() (*Paths, error) {
+	if root, ok, err := explicitWorkspaceRoot(); ok || err != nil {
+		return &Paths{WorkspaceRoot: root}, err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	return &Paths{WorkspaceRoot: cwd}, nil
+}
+
+// FindWorkspaceRoot walks from start up to the filesystem root and returns
+// the first directory containing .mandor.
+func FindWorkspaceRoot(start string) (string, bool) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		if info, err := os.Stat(filepath.Join(dir, MandorDir)); err == nil && info.IsDir() {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// explicitWorkspaceRoot returns the root named by --workspace or
+// MANDOR_WORKSPACE. A path to the .mandor directory itself is accepted too.
+func explicitWorkspaceRoot() (string, bool, error) {
+	root := WorkspaceOverride
+	if root == "" {
+		root = os.Getenv(WorkspaceEnv)
+	}
+	if root == "" {
+		return "", false, nil
+	}
+
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", true, err
+	}
+	if filepath.Base(abs) == MandorDir {
+		abs = filepath.Dir(abs)
+	}
+	return abs, true, nil
+}
+
 // NewPathsFromRoot creates a new Paths instance for the specified root directory
 func NewPathsFromRoot(root string) (*Paths, error) {
 	return &Paths{WorkspaceRoot: root}, nil
@@ -40,6 +113,11 @@ func (p *Paths) WorkspacePath() string {
 	return filepath.Join(p.MandorDirPath(), WorkspaceFile)
 }
 
+// LockPath returns the path to the workspace lock file
+func (p *Paths) LockPath() string {
+	return filepath.Join(p.MandorDirPath(), LockFile)
+}
+
 // ProjectsDirPath returns the path to projects directory
 func (p *Paths) ProjectsDirPath() string {
 	return filepath.Join(p.MandorDirPath(), ProjectsDir)
@@ -65,6 +143,11 @@ func (p *Paths) ProjectEventsPath(projectID string) string {
 	return filepath.Join(p.ProjectDirPath(projectID), "events.jsonl")
 }
 
+// ProjectCommentsPath returns the path to comments.jsonl (append-only notes)
+func (p *Paths) ProjectCommentsPath(projectID string) string {
+	return filepath.Join(p.ProjectDirPath(projectID), "comments.jsonl")
+}
+
 // ProjectFeaturesPath returns the path to features.jsonl
 func (p *Paths) ProjectFeaturesPath(projectID string) string {
 	return filepath.Join(p.ProjectDirPath(projectID), "features.jsonl")
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"strings"
+
+	"mandor/internal/domain"
+	"mandor/internal/util"
+)
+
+// ResolveAssigneeFilter turns the --assignee and --mine flags of the list
+// commands into one assignee to filter on. --mine stands for the configured
+// git user.name; an empty result means no filter.
+func ResolveAssigneeFilter(assignee string, mine bool) (string, error) {
+	assignee = strings.TrimSpace(assignee)
+	if !mine {
+		return assignee, nil
+	}
+	if assignee != "" {
+		return "", domain.NewValidationError("Use either --assignee or --mine, not both.")
+	}
+	if !util.IsGitUserConfigured() {
+		return "", domain.NewValidationError("--mine needs a git user. Run 'git config user.name \"Your Name\"' or use --assignee.")
+	}
+	return util.GetGitUsername(), nil
+}
@@ -0,0 +1,564 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/util"
+)
+
+// Import modes
+const (
+	ImportModeCreate  = "create"
+	ImportModeMerge   = "merge"
+	ImportModeReplace = "replace"
+)
+
+// maxImportProblems caps the validation problems listed in one error
+const maxImportProblems = 20
+
+// BundleService exports a workspace to a bundle and imports bundles back
+type BundleService struct {
+	reader *fs.Reader
+	writer *fs.Writer
+	paths  *fs.Paths
+}
+
+// NewBundleService creates a new bundle service
+func NewBundleService() (*BundleService, error) {
+	paths, err := fs.NewPaths()
+	if err != nil {
+		return nil, err
+	}
+	return NewBundleServiceWithPaths(paths), nil
+}
+
+// NewBundleServiceWithPaths creates a bundle service rooted at paths
+func NewBundleServiceWithPaths(paths *fs.Paths) *BundleService {
+	return &BundleService{
+		reader: fs.NewReader(paths),
+		writer: fs.NewWriter(paths),
+		paths:  paths,
+	}
+}
+
+// ImportOptions controls how a bundle is written into the workspace
+type ImportOptions struct {
+	// ProjectPrefix is prepended to every project ID and every entity ID in it
+	ProjectPrefix string
+	// Mode is ImportModeCreate (default), ImportModeMerge or ImportModeReplace
+	Mode string
+}
+
+// ImportResult describes an import run
+type ImportResult struct {
+	Mode     string                `json:"mode"`
+	Projects []ImportProjectResult `json:"projects"`
+}
+
+// ImportProjectResult describes what was written for one project
+type ImportProjectResult struct {
+	ID       string `json:"id"`
+	SourceID string `json:"source_id"`
+	Action   string `json:"action"`
+	Features int    `json:"features"`
+	Tasks    int    `json:"tasks"`
+	Issues   int    `json:"issues"`
+	Events   int    `json:"events"`
+	Comments int    `json:"comments"`
+}
+
+func (s *BundleService) WorkspaceInitialized() bool {
+	return s.reader.WorkspaceExists()
+}
+
+// Export serializes the workspace and every project into a bundle
+func (s *BundleService) Export() (*domain.Bundle, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ws, err := s.reader.ReadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &domain.Bundle{
+		BundleVersion: domain.BundleVersion,
+		SchemaVersion: domain.CurrentSchemaVersion,
+		ExportedAt:    time.Now().UTC(),
+		ExportedBy:    util.GetGitUsername(),
+		Workspace:     ws,
+		Projects:      []domain.BundleProject{},
+	}
+
+	projectIDs, err := s.reader.ListProjects(true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, projectID := range projectIDs {
+		project, err := s.exportProject(projectID)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Projects = append(bundle.Projects, *project)
+	}
+
+	return bundle, nil
+}
+
+func (s *BundleService) exportProject(projectID string) (*domain.BundleProject, error) {
+	project, err := s.reader.ReadProjectMetadata(projectID)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := s.reader.ReadProjectSchema(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := readEntityFile[domain.Feature](s.reader, s.paths.ProjectFeaturesPath(projectID), func(f *domain.Feature) string { return f.ID })
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := readEntityFile[domain.Task](s.reader, s.paths.ProjectTasksPath(projectID), func(t *domain.Task) string { return t.ID })
+	if err != nil {
+		return nil, err
+	}
+	issues, err := readEntityFile[domain.Issue](s.reader, s.paths.ProjectIssuesPath(projectID), func(i *domain.Issue) string { return i.ID })
+	if err != nil {
+		return nil, err
+	}
+
+	events := []json.RawMessage{}
+	err = s.reader.ReadNDJSON(s.paths.ProjectEventsPath(projectID), func(raw []byte) error {
+		events = append(events, append(json.RawMessage{}, raw...))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := readComments(s.reader, s.paths, projectID)
+	if err != nil {
+		return nil, err
+	}
+	bundleComments := make([]*domain.Comment, 0, len(comments))
+	for i := range comments {
+		bundleComments = append(bundleComments, &comments[i])
+	}
+
+	return &domain.BundleProject{
+		Project:  project,
+		Schema:   schema,
+		Features: features.list(),
+		Tasks:    tasks.list(),
+		Issues:   issues.list(),
+		Events:   events,
+		Comments: bundleComments,
+	}, nil
+}
+
+func (f *entityFile[T]) list() []*T {
+	items := make([]*T, 0, len(f.order))
+	for _, id := range f.order {
+		items = append(items, f.items[id])
+	}
+	return items
+}
+
+// Import validates the bundle against the workspace and writes it. Nothing
+// is written unless every project passes validation.
+func (s *BundleService) Import(bundle *domain.Bundle, opts ImportOptions) (*ImportResult, error) {
+	if opts.Mode == "" {
+		opts.Mode = ImportModeCreate
+	}
+
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if bundle.BundleVersion != domain.BundleVersion {
+		return nil, domain.NewValidationError(fmt.Sprintf(
+			"Unsupported bundle version %q (expected %s).", bundle.BundleVersion, domain.BundleVersion,
+		))
+	}
+	if bundle.SchemaVersion != domain.CurrentSchemaVersion {
+		return nil, domain.NewValidationError(fmt.Sprintf(
+			"Bundle was exported with schema %s, but this workspace uses %s.\nMigrate the source workspace and export again.",
+			displaySchemaVersion(bundle.SchemaVersion), domain.CurrentSchemaVersion,
+		))
+	}
+
+	projects, err := prefixBundle(bundle.Projects, opts.ProjectPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if problems := s.validateImport(projects, opts.Mode); len(problems) > 0 {
+		if len(problems) > maxImportProblems {
+			problems = append(problems[:maxImportProblems], fmt.Sprintf("... and %d more", len(problems)-maxImportProblems))
+		}
+		return nil, domain.NewValidationError("Cannot import bundle:\n  " + strings.Join(problems, "\n  "))
+	}
+
+	// Projects are written one at a time; a failure part way through puts
+	// the whole workspace back as it was
+	backup, err := os.MkdirTemp("", "mandor-import-")
+	if err != nil {
+		return nil, domain.NewSystemError("Cannot create import backup", err)
+	}
+	if err := s.writer.BackupMandorDir(backup); err != nil {
+		os.RemoveAll(backup)
+		return nil, err
+	}
+
+	result := &ImportResult{Mode: opts.Mode, Projects: []ImportProjectResult{}}
+	for i := range projects {
+		projectResult, err := s.importProject(&projects[i], bundle.Projects[i].Project.ID, opts.Mode)
+		if err != nil {
+			if restoreErr := s.writer.RestoreMandorDir(backup); restoreErr != nil {
+				return nil, domain.NewSystemError(fmt.Sprintf("Import failed (%v) and the workspace could not be restored; a copy of .mandor is kept in %s", err, backup), restoreErr)
+			}
+			os.RemoveAll(backup)
+			return nil, err
+		}
+		result.Projects = append(result.Projects, *projectResult)
+	}
+
+	os.RemoveAll(backup)
+	return result, nil
+}
+
+// prefixBundle returns a copy of projects with prefix prepended to every
+// project ID and every feature, task and issue ID that belongs to one of
+// them, including references in depends_on, comments and events. Entity IDs
+// start with their project ID, so prepending keeps them well-formed. Only
+// ID-bearing fields are rewritten; free text that mentions an ID is kept.
+func prefixBundle(projects []domain.BundleProject, prefix string) ([]domain.BundleProject, error) {
+	projectIDs := make(map[string]bool)
+	for _, p := range projects {
+		if p.Project == nil {
+			return nil, domain.NewValidationError("Cannot import bundle: project entry without metadata")
+		}
+		projectIDs[p.Project.ID] = true
+	}
+
+	rewritten := make([]domain.BundleProject, len(projects))
+	for i, p := range projects {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, domain.NewSystemError("Cannot encode bundle project", err)
+		}
+		if prefix != "" {
+			var value interface{}
+			if err := json.Unmarshal(data, &value); err != nil {
+				return nil, domain.NewSystemError("Cannot decode bundle project", err)
+			}
+			value = prefixIDs(value, "", prefix, projectIDs)
+			if data, err = json.Marshal(value); err != nil {
+				return nil, domain.NewSystemError("Cannot encode bundle project", err)
+			}
+		}
+		if err := json.Unmarshal(data, &rewritten[i]); err != nil {
+			return nil, domain.NewSystemError("Cannot decode bundle project", err)
+		}
+		if prefix == "" {
+			continue
+		}
+		// Restore the usual field order of the rewritten events
+		for j, raw := range rewritten[i].Events {
+			obj := make(map[string]interface{})
+			if err := json.Unmarshal(raw, &obj); err != nil {
+				return nil, domain.NewValidationError("Cannot import bundle: invalid event in project " + p.Project.ID)
+			}
+			if rewritten[i].Events[j], err = canonicalObject("events.jsonl", obj); err != nil {
+				return nil, domain.NewSystemError("Cannot encode bundle event", err)
+			}
+		}
+	}
+	return rewritten, nil
+}
+
+// idKeys are the JSON keys whose values hold project or entity IDs
+var idKeys = map[string]bool{
+	"id":         true,
+	"project_id": true,
+	"feature_id": true,
+	"depends_on": true,
+	"entity_id":  true,
+	"reply_to":   true,
+}
+
+func prefixIDs(value interface{}, key, prefix string, projectIDs map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// A field diff holds the old and new value of the field it names
+		field, _ := v["field"].(string)
+		for k, child := range v {
+			childKey := k
+			if (k == "from" || k == "to") && idKeys[field] {
+				childKey = field
+			}
+			v[k] = prefixIDs(child, childKey, prefix, projectIDs)
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = prefixIDs(child, key, prefix, projectIDs)
+		}
+		return v
+	case string:
+		if !idKeys[key] {
+			return v
+		}
+		if projectIDs[v] && (key == "id" || key == "project_id") {
+			return prefix + v
+		}
+		for projectID := range projectIDs {
+			if strings.HasPrefix(v, projectID+"-feature-") || strings.HasPrefix(v, projectID+"-issue-") {
+				return prefix + v
+			}
+		}
+		return v
+	}
+	return value
+}
+
+// validateImport checks project IDs, ID uniqueness, feature references and
+// dependencies against the workspace as it will be after the import.
+func (s *BundleService) validateImport(projects []domain.BundleProject, mode string) []string {
+	var problems []string
+
+	importing := make(map[string]bool)
+	for _, p := range projects {
+		id := p.Project.ID
+		if !domain.ValidateProjectID(id) {
+			problems = append(problems, fmt.Sprintf("project %s: invalid project ID", id))
+		}
+		if importing[id] {
+			problems = append(problems, fmt.Sprintf("project %s: appears more than once in the bundle", id))
+		}
+		importing[id] = true
+
+		if s.reader.ProjectExists(id) && mode == ImportModeCreate {
+			problems = append(problems, fmt.Sprintf(
+				"project %s: already exists in this workspace (use --merge, --replace or --project-prefix)", id,
+			))
+		}
+	}
+
+	// owner maps every entity ID in the resulting workspace to its project and layer
+	owner := make(map[string]importedEntity)
+	existing, err := s.reader.ListProjects(true)
+	if err != nil {
+		return append(problems, err.Error())
+	}
+	for _, projectID := range existing {
+		if mode == ImportModeReplace && importing[projectID] {
+			continue
+		}
+		ids, err := s.entityIDs(projectID)
+		if err != nil {
+			return append(problems, err.Error())
+		}
+		for id, layer := range ids {
+			owner[id] = importedEntity{projectID: projectID, layer: layer}
+		}
+	}
+
+	for _, p := range projects {
+		projectID := p.Project.ID
+		add := func(layer, id, belongsTo string) {
+			if belongsTo != projectID {
+				problems = append(problems, fmt.Sprintf("%s %s: project_id %s does not match project %s", layer, id, belongsTo, projectID))
+			}
+			if other, ok := owner[id]; ok {
+				problems = append(problems, fmt.Sprintf("%s %s: ID already exists in project %s", layer, id, other.projectID))
+				return
+			}
+			owner[id] = importedEntity{projectID: projectID, layer: layer}
+		}
+		for _, f := range p.Features {
+			add("feature", f.ID, f.ProjectID)
+		}
+		for _, t := range p.Tasks {
+			add("task", t.ID, t.ProjectID)
+		}
+		for _, i := range p.Issues {
+			add("issue", i.ID, i.ProjectID)
+		}
+	}
+
+	for _, p := range projects {
+		projectID := p.Project.ID
+		checkDeps := func(layer, id string, deps []string) {
+			for _, dep := range deps {
+				if _, ok := owner[dep]; !ok {
+					problems = append(problems, fmt.Sprintf("%s %s: depends on unknown ID %s", layer, id, dep))
+				}
+			}
+		}
+		for _, f := range p.Features {
+			checkDeps("feature", f.ID, f.DependsOn)
+		}
+		for _, t := range p.Tasks {
+			if f := owner[t.FeatureID]; f.layer != "feature" || f.projectID != projectID {
+				problems = append(problems, fmt.Sprintf("task %s: feature %s not found in project %s", t.ID, t.FeatureID, projectID))
+			}
+			checkDeps("task", t.ID, t.DependsOn)
+		}
+		for _, i := range p.Issues {
+			checkDeps("issue", i.ID, i.DependsOn)
+		}
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+type importedEntity struct {
+	projectID string
+	layer     string
+}
+
+// entityIDs maps every feature, task and issue ID in a project to its layer
+func (s *BundleService) entityIDs(projectID string) (map[string]string, error) {
+	ids := make(map[string]string)
+	collect := func(layer, path string) error {
+		return s.reader.ReadNDJSON(path, func(raw []byte) error {
+			var entity struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &entity); err != nil {
+				return err
+			}
+			ids[entity.ID] = layer
+			return nil
+		})
+	}
+	if err := collect("feature", s.paths.ProjectFeaturesPath(projectID)); err != nil {
+		return nil, err
+	}
+	if err := collect("task", s.paths.ProjectTasksPath(projectID)); err != nil {
+		return nil, err
+	}
+	if err := collect("issue", s.paths.ProjectIssuesPath(projectID)); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *BundleService) importProject(p *domain.BundleProject, sourceID, mode string) (*ImportProjectResult, error) {
+	projectID := p.Project.ID
+	result := &ImportProjectResult{
+		ID:       projectID,
+		SourceID: sourceID,
+		Action:   "created",
+		Features: len(p.Features),
+		Tasks:    len(p.Tasks),
+		Issues:   len(p.Issues),
+		Events:   len(p.Events),
+		Comments: len(p.Comments),
+	}
+
+	features, tasks, issues, events, comments := p.Features, p.Tasks, p.Issues, p.Events, p.Comments
+	project := p.Project
+
+	if s.reader.ProjectExists(projectID) {
+		result.Action = "replaced"
+		if mode == ImportModeMerge {
+			result.Action = "merged"
+
+			current, err := s.exportProject(projectID)
+			if err != nil {
+				return nil, err
+			}
+			project = current.Project
+			features = append(current.Features, features...)
+			tasks = append(current.Tasks, tasks...)
+			issues = append(current.Issues, issues...)
+			events = append(current.Events, events...)
+			comments = mergeComments(current.Comments, comments)
+		}
+	} else if err := s.writer.CreateProjectDir(projectID); err != nil {
+		return nil, err
+	}
+
+	if mode != ImportModeMerge || result.Action == "created" {
+		if err := s.writer.WriteProjectMetadata(projectID, project); err != nil {
+			return nil, err
+		}
+		if p.Schema != nil {
+			if err := s.writer.WriteProjectSchema(projectID, p.Schema); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.writer.ReplaceFeatures(projectID, features, nil); err != nil {
+		return nil, err
+	}
+	if err := s.writer.ReplaceTasks(projectID, tasks, nil); err != nil {
+		return nil, err
+	}
+	if err := s.writer.ReplaceIssues(projectID, issues, nil); err != nil {
+		return nil, err
+	}
+	if err := s.writer.RewriteNDJSON(s.paths.ProjectEventsPath(projectID), events); err != nil {
+		return nil, err
+	}
+	if err := s.writer.ReplaceComments(projectID, comments); err != nil {
+		return nil, err
+	}
+
+	event := &domain.ProjectEvent{
+		Layer:    "project",
+		Type:     "imported",
+		ID:       projectID,
+		By:       util.GetGitUsername(),
+		Ts:       time.Now().UTC(),
+		Snapshot: project,
+		Import: &domain.ImportRecord{
+			Mode:     mode,
+			SourceID: sourceID,
+			Features: result.Features,
+			Tasks:    result.Tasks,
+			Issues:   result.Issues,
+		},
+	}
+	if err := s.writer.AppendProjectEvent(projectID, event); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// mergeComments appends the imported comments to current, skipping any
+// comment that is already present, and keeps the result in time order
+func mergeComments(current, imported []*domain.Comment) []*domain.Comment {
+	seen := make(map[string]bool, len(current))
+	merged := append([]*domain.Comment{}, current...)
+	for _, comment := range current {
+		seen[comment.ID] = true
+	}
+	for _, comment := range imported {
+		if !seen[comment.ID] {
+			seen[comment.ID] = true
+			merged = append(merged, comment)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Ts.Before(merged[j].Ts) })
+	return merged
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"mandor/internal/domain"
+)
+
+// cancelPolicy decides what happens to the open dependents of an item that
+// is being cancelled
+type cancelPolicy int
+
+const (
+	// cancelPolicyNone refuses to cancel an item that still has dependents
+	cancelPolicyNone cancelPolicy = iota
+	// cancelPolicyCascade cancels every dependent, recursively, with the same reason
+	cancelPolicyCascade
+	// cancelPolicyRewire moves dependents onto a replacement item
+	cancelPolicyRewire
+	// cancelPolicyKeepBlocked leaves dependents blocked on the cancelled item
+	cancelPolicyKeepBlocked
+)
+
+// resolveCancelPolicy picks the policy from the --cascade, --rewire and
+// --keep-blocked flags. --force is the older spelling of --keep-blocked.
+func resolveCancelPolicy(cascade bool, rewire *string, keepBlocked, force bool) (cancelPolicy, error) {
+	var policies []cancelPolicy
+	if cascade {
+		policies = append(policies, cancelPolicyCascade)
+	}
+	if rewire != nil {
+		if strings.TrimSpace(*rewire) == "" {
+			return cancelPolicyNone, domain.NewValidationError("--rewire requires the ID of the replacement.")
+		}
+		policies = append(policies, cancelPolicyRewire)
+	}
+	if keepBlocked || force {
+		policies = append(policies, cancelPolicyKeepBlocked)
+	}
+
+	switch len(policies) {
+	case 0:
+		return cancelPolicyNone, nil
+	case 1:
+		return policies[0], nil
+	}
+	return cancelPolicyNone, domain.NewValidationError("Use only one of --cascade, --rewire or --keep-blocked.")
+}
+
+// validateCancelPolicyFlags rejects dependent policies on an update that
+// does not cancel anything
+func validateCancelPolicyFlags(cancelling, cascade bool, rewire *string, keepBlocked bool) error {
+	if cancelling || (!cascade && rewire == nil && !keepBlocked) {
+		return nil
+	}
+	return domain.NewValidationError("--cascade, --rewire and --keep-blocked only apply when cancelling.")
+}
+
+// dependentsError explains how to cancel an item that still has dependents
+func dependentsError(layer, id string, dependents []string) error {
+	return domain.NewValidationError(fmt.Sprintf(
+		"%s %s has %d dependent(s):\n  %s\nUse --cascade, --rewire <id> or --keep-blocked to cancel it anyway.",
+		layer, id, len(dependents), strings.Join(dependents, "\n  "),
+	))
+}
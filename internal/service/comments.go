@@ -0,0 +1,177 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/util"
+)
+
+// Comments are progress notes and answers left on features, tasks and issues.
+// They live in the project's comments.jsonl, separate from the entity and its
+// events, so adding one never rewrites the entity.
+
+// maxCommentLength bounds a single comment body
+const maxCommentLength = 5000
+
+// validateComment checks the body and normalizes the author, defaulting to
+// the git user
+func validateComment(input *domain.CommentInput) error {
+	input.Body = strings.TrimSpace(input.Body)
+	if input.Body == "" {
+		return domain.NewValidationError("Comment text is required.")
+	}
+	if len(input.Body) > maxCommentLength {
+		return domain.NewValidationError(fmt.Sprintf("Comment is too long (%d chars, max %d).", len(input.Body), maxCommentLength))
+	}
+	input.Author = strings.TrimSpace(input.Author)
+	if input.Author == "" {
+		input.Author = util.GetGitUsername()
+	}
+	input.ReplyTo = strings.TrimSpace(input.ReplyTo)
+	return nil
+}
+
+// readComments returns every comment in projectID's comments.jsonl, oldest first
+func readComments(reader *fs.Reader, paths *fs.Paths, projectID string) ([]domain.Comment, error) {
+	var comments []domain.Comment
+	err := reader.ReadNDJSON(paths.ProjectCommentsPath(projectID), func(raw []byte) error {
+		var comment domain.Comment
+		if err := json.Unmarshal(raw, &comment); err != nil {
+			return err
+		}
+		comments = append(comments, comment)
+		return nil
+	})
+	return comments, err
+}
+
+// entityComments returns the comments on entityID, oldest first, and their
+// total. last > 0 keeps only the most recent last comments.
+func entityComments(reader *fs.Reader, paths *fs.Paths, projectID, entityID string, last int) ([]domain.Comment, int, error) {
+	all, err := readComments(reader, paths, projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+	var comments []domain.Comment
+	for _, comment := range all {
+		if comment.EntityID == entityID {
+			comments = append(comments, comment)
+		}
+	}
+	total := len(comments)
+	if last > 0 && total > last {
+		comments = comments[total-last:]
+	}
+	return comments, total, nil
+}
+
+// appendComment writes a new comment on input.EntityID. A reply must answer
+// an existing comment on the same entity.
+func appendComment(reader *fs.Reader, writer *fs.Writer, paths *fs.Paths, projectID, layer string, input *domain.CommentInput) (*domain.Comment, error) {
+	unlock, err := writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	existing, err := readComments(reader, paths, projectID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(existing))
+	replyFound := false
+	for _, comment := range existing {
+		ids[comment.ID] = true
+		if comment.ID == input.ReplyTo && comment.EntityID == input.EntityID {
+			replyFound = true
+		}
+	}
+	if input.ReplyTo != "" && !replyFound {
+		return nil, domain.NewValidationError(fmt.Sprintf("Comment not found on %s: %s", input.EntityID, input.ReplyTo))
+	}
+
+	var id string
+	for id == "" || ids[id] {
+		nanoid, err := util.GenerateID()
+		if err != nil {
+			return nil, domain.NewSystemError("Cannot generate comment ID", err)
+		}
+		id = "comment-" + nanoid
+	}
+
+	comment := &domain.Comment{
+		ID:       id,
+		Layer:    layer,
+		EntityID: input.EntityID,
+		Author:   input.Author,
+		Body:     input.Body,
+		ReplyTo:  input.ReplyTo,
+		Ts:       time.Now().UTC(),
+	}
+	if err := writer.AppendComment(projectID, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// ValidateCommentInput checks a comment on a task
+func (s *TaskService) ValidateCommentInput(input *domain.CommentInput) error {
+	projectID, _, err := s.ParseTaskID(input.EntityID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.reader.ReadTask(projectID, input.EntityID); err != nil {
+		return err
+	}
+	return validateComment(input)
+}
+
+// CommentTask appends a comment to a task
+func (s *TaskService) CommentTask(input *domain.CommentInput) (*domain.Comment, error) {
+	projectID, _, err := s.ParseTaskID(input.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	return appendComment(s.reader, s.writer, s.paths, projectID, "task", input)
+}
+
+// ValidateCommentInput checks a comment on a feature
+func (s *FeatureService) ValidateCommentInput(input *domain.CommentInput) error {
+	projectID := extractProjectIDFromFeatureID(input.EntityID)
+	if projectID == "" {
+		return domain.NewValidationError(fmt.Sprintf("Invalid feature ID format: %s", input.EntityID))
+	}
+	if _, err := s.reader.ReadFeature(projectID, input.EntityID); err != nil {
+		return err
+	}
+	return validateComment(input)
+}
+
+// CommentFeature appends a comment to a feature
+func (s *FeatureService) CommentFeature(input *domain.CommentInput) (*domain.Comment, error) {
+	projectID := extractProjectIDFromFeatureID(input.EntityID)
+	return appendComment(s.reader, s.writer, s.paths, projectID, "feature", input)
+}
+
+// ValidateCommentInput checks a comment on an issue
+func (s *IssueService) ValidateCommentInput(input *domain.CommentInput) error {
+	projectID := extractProjectIDFromIssueID(input.EntityID)
+	if projectID == "" {
+		return domain.NewValidationError(fmt.Sprintf("Invalid issue ID format: %s", input.EntityID))
+	}
+	if _, err := s.reader.ReadIssue(projectID, input.EntityID); err != nil {
+		return err
+	}
+	return validateComment(input)
+}
+
+// CommentIssue appends a comment to an issue
+func (s *IssueService) CommentIssue(input *domain.CommentInput) (*domain.Comment, error) {
+	projectID := extractProjectIDFromIssueID(input.EntityID)
+	return appendComment(s.reader, s.writer, s.paths, projectID, "issue", input)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// cycleAllowed reports whether the project's schema allows dependency cycles
+// for layer ("task", "feature" or "issue")
+func cycleAllowed(reader *fs.Reader, projectID, layer string) (bool, error) {
+	schema, err := reader.ReadProjectSchema(projectID)
+	if err != nil {
+		return false, err
+	}
+
+	var rule domain.DependencyRule
+	switch layer {
+	case "task":
+		rule = schema.Rules.Task
+	case "feature":
+		rule = schema.Rules.Feature
+	case "issue":
+		rule = schema.Rules.Issue
+	}
+	return rule.Cycle == domain.CycleAllowed, nil
+}
+
+// findCyclePath looks for a path from any of dependsOn back to selfID and
+// returns it as selfID -> ... -> selfID, or nil when there is none. depsOf
+// returns the current dependencies of an entity.
+func findCyclePath(selfID string, dependsOn []string, depsOf func(id string) []string) []string {
+	visited := make(map[string]bool)
+	var path []string
+
+	var dfs func(id string) bool
+	dfs = func(id string) bool {
+		path = append(path, id)
+		if id == selfID {
+			return true
+		}
+		if !visited[id] {
+			visited[id] = true
+			for _, dep := range depsOf(id) {
+				if dfs(dep) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	for _, depID := range dependsOn {
+		path = []string{selfID}
+		if dfs(depID) {
+			return path
+		}
+	}
+	return nil
+}
+
+// cycleError formats a detected cycle path
+func cycleError(path []string) error {
+	return domain.NewValidationError("Circular dependency detected: " + strings.Join(path, " -> "))
+}
+
+// findCycles returns the members of every dependency cycle in graph, one
+// sorted slice per strongly connected component. Edges to unknown IDs are
+// ignored.
+func findCycles(graph map[string][]string) [][]string {
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+	next := 0
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		index[id] = next
+		lowlink[id] = next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		selfLoop := false
+		for _, dep := range graph[id] {
+			if _, known := graph[dep]; !known {
+				continue
+			}
+			if dep == id {
+				selfLoop = true
+			}
+			if _, seen := index[dep]; !seen {
+				strongConnect(dep)
+				lowlink[id] = min(lowlink[id], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[id] = min(lowlink[id], index[dep])
+			}
+		}
+
+		if lowlink[id] != index[id] {
+			return
+		}
+
+		var members []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			members = append(members, top)
+			if top == id {
+				break
+			}
+		}
+		if len(members) > 1 || selfLoop {
+			sort.Strings(members)
+			cycles = append(cycles, members)
+		}
+	}
+
+	ids := make([]string, 0, len(graph))
+	for id := range graph {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			strongConnect(id)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
@@ -0,0 +1,72 @@
+package service
+
+// applyDependencyChanges returns current after replacing it with set (when
+// given), appending add and dropping remove. Order is kept and duplicates are
+// dropped. The second result reports whether the list differs from current.
+func applyDependencyChanges(current []string, set, add, remove *[]string) ([]string, bool) {
+	if set == nil && add == nil && remove == nil {
+		return current, false
+	}
+
+	base := current
+	if set != nil {
+		base = *set
+	}
+	var candidates []string
+	candidates = append(candidates, base...)
+	if add != nil {
+		candidates = append(candidates, *add...)
+	}
+
+	removed := make(map[string]bool)
+	if remove != nil {
+		for _, id := range *remove {
+			removed[id] = true
+		}
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, id := range candidates {
+		if id == "" || removed[id] || seen[id] {
+			continue
+		}
+		seen[id] = true
+		result = append(result, id)
+	}
+
+	if len(result) != len(current) {
+		return result, true
+	}
+	for i := range result {
+		if result[i] != current[i] {
+			return result, true
+		}
+	}
+	return result, false
+}
+
+// addedDependencies returns the IDs in next that are not in current
+func addedDependencies(current, next []string) []string {
+	existing := make(map[string]bool, len(current))
+	for _, id := range current {
+		existing[id] = true
+	}
+	var added []string
+	for _, id := range next {
+		if !existing[id] {
+			added = append(added, id)
+		}
+	}
+	return added
+}
+
+// containsID reports whether ids contains id
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,593 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mandor/internal/domain"
+	mfs "mandor/internal/fs"
+)
+
+// DoctorService checks workspace integrity and repairs safe inconsistencies
+type DoctorService struct {
+	reader *mfs.Reader
+	writer *mfs.Writer
+	paths  *mfs.Paths
+}
+
+// NewDoctorService creates a new doctor service
+func NewDoctorService() (*DoctorService, error) {
+	paths, err := mfs.NewPaths()
+	if err != nil {
+		return nil, err
+	}
+	return NewDoctorServiceWithPaths(paths), nil
+}
+
+// NewDoctorServiceWithPaths creates a doctor service rooted at paths
+func NewDoctorServiceWithPaths(paths *mfs.Paths) *DoctorService {
+	return &DoctorService{
+		reader: mfs.NewReader(paths),
+		writer: mfs.NewWriter(paths),
+		paths:  paths,
+	}
+}
+
+// Doctor finding codes. These are part of the CLI contract; scripts match on them.
+const (
+	DoctorDanglingDependency = "dangling_dependency"
+	DoctorMissingFeature     = "missing_feature"
+	DoctorDuplicateID        = "duplicate_id"
+	DoctorStaleBlocked       = "stale_blocked"
+	DoctorStaleReady         = "stale_ready"
+	DoctorLeftoverTmp        = "leftover_tmp"
+)
+
+// DoctorFinding is a single inconsistency found in the workspace
+type DoctorFinding struct {
+	Code      string `json:"code"`
+	ProjectID string `json:"project_id,omitempty"`
+	Layer     string `json:"layer,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Message   string `json:"message"`
+	Fixable   bool   `json:"fixable"`
+	Fixed     bool   `json:"fixed"`
+}
+
+// DoctorReport is the result of a doctor run
+type DoctorReport struct {
+	Findings []DoctorFinding `json:"findings"`
+	Fixed    int             `json:"fixed"`
+}
+
+// Unresolved returns the number of findings that are still present
+func (r *DoctorReport) Unresolved() int {
+	return len(r.Findings) - r.Fixed
+}
+
+func (s *DoctorService) WorkspaceInitialized() bool {
+	return s.reader.WorkspaceExists()
+}
+
+// doctorProject holds the de-duplicated entities of one project and tracks
+// which layers and entities were repaired.
+type doctorProject struct {
+	id       string
+	features []*domain.Feature
+	tasks    []*domain.Task
+	issues   []*domain.Issue
+
+	dirtyFeatures bool
+	dirtyTasks    bool
+	dirtyIssues   bool
+
+	featureEvents []*domain.FeatureEvent
+	taskEvents    []*domain.TaskEvent
+	issueEvents   []*domain.IssueEvent
+	projectEvents []*domain.ProjectEvent
+}
+
+// Check inspects every project in the workspace. With fix it repairs the safe
+// cases (duplicates, dangling dependencies, stale blocked or ready status,
+// .tmp files)
+// and records a system "repaired" event for each repair.
+func (s *DoctorService) Check(fix bool) (*DoctorReport, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	report := &DoctorReport{Findings: []DoctorFinding{}}
+	now := time.Now().UTC()
+
+	projectIDs, err := s.reader.ListProjects(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []*doctorProject
+	features := make(map[string]*domain.Feature)
+	tasks := make(map[string]*domain.Task)
+	issues := make(map[string]*domain.Issue)
+
+	for _, projectID := range projectIDs {
+		p := &doctorProject{id: projectID}
+
+		var dups []string
+		p.features, dups, err = readDeduplicated(s.reader, s.paths.ProjectFeaturesPath(projectID), func(f *domain.Feature) string { return f.ID })
+		if err != nil {
+			return nil, err
+		}
+		p.dirtyFeatures = s.reportDuplicates(report, p, "feature", dups, fix, now)
+
+		p.tasks, dups, err = readDeduplicated(s.reader, s.paths.ProjectTasksPath(projectID), func(t *domain.Task) string { return t.ID })
+		if err != nil {
+			return nil, err
+		}
+		p.dirtyTasks = s.reportDuplicates(report, p, "task", dups, fix, now)
+
+		p.issues, dups, err = readDeduplicated(s.reader, s.paths.ProjectIssuesPath(projectID), func(i *domain.Issue) string { return i.ID })
+		if err != nil {
+			return nil, err
+		}
+		p.dirtyIssues = s.reportDuplicates(report, p, "issue", dups, fix, now)
+
+		for _, f := range p.features {
+			features[f.ID] = f
+		}
+		for _, t := range p.tasks {
+			tasks[t.ID] = t
+		}
+		for _, i := range p.issues {
+			issues[i.ID] = i
+		}
+
+		projects = append(projects, p)
+	}
+
+	// Dangling dependencies and missing parents
+	for _, p := range projects {
+		for _, f := range p.features {
+			before := *f
+			f.DependsOn = s.checkDependencies(report, p.id, "feature", f.ID, f.DependsOn, func(id string) bool { return features[id] != nil }, fix)
+			if fix && len(f.DependsOn) != len(before.DependsOn) {
+				f.UpdatedAt = now
+				p.dirtyFeatures = true
+				p.featureEvents = append(p.featureEvents, featureRepairEvent(f, &before, now))
+			}
+		}
+		for _, t := range p.tasks {
+			if features[t.FeatureID] == nil {
+				report.Findings = append(report.Findings, DoctorFinding{
+					Code:      DoctorMissingFeature,
+					ProjectID: p.id,
+					Layer:     "task",
+					ID:        t.ID,
+					Message:   fmt.Sprintf("Task belongs to feature %s, which does not exist", t.FeatureID),
+				})
+			}
+
+			before := *t
+			t.DependsOn = s.checkDependencies(report, p.id, "task", t.ID, t.DependsOn, func(id string) bool { return tasks[id] != nil }, fix)
+			if fix && len(t.DependsOn) != len(before.DependsOn) {
+				t.UpdatedAt = now
+				p.dirtyTasks = true
+				p.taskEvents = append(p.taskEvents, taskRepairEvent(t, &before, now))
+			}
+		}
+		for _, i := range p.issues {
+			before := *i
+			i.DependsOn = s.checkDependencies(report, p.id, "issue", i.ID, i.DependsOn, func(id string) bool { return issues[id] != nil }, fix)
+			if fix && len(i.DependsOn) != len(before.DependsOn) {
+				i.UpdatedAt = now
+				p.dirtyIssues = true
+				p.issueEvents = append(p.issueEvents, issueRepairEvent(i, &before, now))
+			}
+		}
+	}
+
+	// Blocked entities whose dependencies are all satisfied
+	for _, p := range projects {
+		for _, f := range p.features {
+			if f.Status != domain.FeatureStatusBlocked || !allSatisfied(f.DependsOn, func(id string) bool {
+				dep := features[id]
+				return dep != nil && isFeatureFinished(dep.Status)
+			}) {
+				continue
+			}
+			finding := staleBlockedFinding(p.id, "feature", f.ID, fix)
+			if fix {
+				before := *f
+				f.Status = domain.FeatureStatusDraft
+				f.UpdatedAt = now
+				p.dirtyFeatures = true
+				p.featureEvents = append(p.featureEvents, featureRepairEvent(f, &before, now))
+				report.Fixed++
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+		for _, t := range p.tasks {
+			if t.Status != domain.TaskStatusBlocked || !allSatisfied(t.DependsOn, func(id string) bool {
+				dep := tasks[id]
+				return dep != nil && isTaskFinished(dep.Status)
+			}) {
+				continue
+			}
+			finding := staleBlockedFinding(p.id, "task", t.ID, fix)
+			if fix {
+				before := *t
+				t.Status = domain.TaskStatusReady
+				t.UpdatedAt = now
+				p.dirtyTasks = true
+				p.taskEvents = append(p.taskEvents, taskRepairEvent(t, &before, now))
+				report.Fixed++
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+		for _, i := range p.issues {
+			if i.Status != domain.IssueStatusBlocked || !allSatisfied(i.DependsOn, func(id string) bool {
+				dep := issues[id]
+				return dep != nil && isIssueFinished(dep.Status)
+			}) {
+				continue
+			}
+			finding := staleBlockedFinding(p.id, "issue", i.ID, fix)
+			if fix {
+				before := *i
+				i.Status = domain.IssueStatusReady
+				i.UpdatedAt = now
+				p.dirtyIssues = true
+				p.issueEvents = append(p.issueEvents, issueRepairEvent(i, &before, now))
+				report.Fixed++
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	// Ready entities with an unfinished dependency, such as one that was
+	// cancelled while cancelled still counted as finished
+	for _, p := range projects {
+		for _, f := range p.features {
+			if f.Status != domain.FeatureStatusDraft || allSatisfied(f.DependsOn, func(id string) bool {
+				dep := features[id]
+				return dep == nil || isFeatureFinished(dep.Status)
+			}) {
+				continue
+			}
+			finding := staleReadyFinding(p.id, "feature", f.ID, f.Status, fix)
+			if fix {
+				before := *f
+				f.Status = domain.FeatureStatusBlocked
+				f.UpdatedAt = now
+				p.dirtyFeatures = true
+				p.featureEvents = append(p.featureEvents, featureRepairEvent(f, &before, now))
+				report.Fixed++
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+		for _, t := range p.tasks {
+			if t.Status != domain.TaskStatusReady || allSatisfied(t.DependsOn, func(id string) bool {
+				dep := tasks[id]
+				return dep == nil || isTaskFinished(dep.Status)
+			}) {
+				continue
+			}
+			finding := staleReadyFinding(p.id, "task", t.ID, t.Status, fix)
+			if fix {
+				before := *t
+				t.Status = domain.TaskStatusBlocked
+				t.UpdatedAt = now
+				p.dirtyTasks = true
+				p.taskEvents = append(p.taskEvents, taskRepairEvent(t, &before, now))
+				report.Fixed++
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+		for _, i := range p.issues {
+			if i.Status != domain.IssueStatusReady || allSatisfied(i.DependsOn, func(id string) bool {
+				dep := issues[id]
+				return dep == nil || isIssueFinished(dep.Status)
+			}) {
+				continue
+			}
+			finding := staleReadyFinding(p.id, "issue", i.ID, i.Status, fix)
+			if fix {
+				before := *i
+				i.Status = domain.IssueStatusBlocked
+				i.UpdatedAt = now
+				p.dirtyIssues = true
+				p.issueEvents = append(p.issueEvents, issueRepairEvent(i, &before, now))
+				report.Fixed++
+			}
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	if err := s.checkTmpFiles(report, projects, fix, now); err != nil {
+		return nil, err
+	}
+
+	if fix {
+		for _, p := range projects {
+			if err := s.writeRepairs(p); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// readDeduplicated reads an entity file keeping the last line for each ID
+// (what fs.Reader returns) in order of first appearance, and reports IDs that
+// occur more than once.
+func readDeduplicated[T any](reader *mfs.Reader, path string, idOf func(*T) string) ([]*T, []string, error) {
+	file, err := readEntityFile(reader, path, idOf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts := make(map[string]int)
+	err = reader.ReadNDJSON(path, func(raw []byte) error {
+		var item T
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return err
+		}
+		counts[idOf(&item)]++
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]*T, 0, len(file.order))
+	var dups []string
+	for _, id := range file.order {
+		items = append(items, file.items[id])
+		if counts[id] > 1 {
+			dups = append(dups, id)
+		}
+	}
+	return items, dups, nil
+}
+
+func (s *DoctorService) reportDuplicates(report *DoctorReport, p *doctorProject, layer string, dups []string, fix bool, now time.Time) bool {
+	for _, id := range dups {
+		report.Findings = append(report.Findings, DoctorFinding{
+			Code:      DoctorDuplicateID,
+			ProjectID: p.id,
+			Layer:     layer,
+			ID:        id,
+			Message:   fmt.Sprintf("ID appears on more than one line of %ss.jsonl; the last line wins", layer),
+			Fixable:   true,
+			Fixed:     fix,
+		})
+		if fix {
+			report.Fixed++
+			p.projectEvents = append(p.projectEvents, &domain.ProjectEvent{
+				Layer:  "project",
+				Type:   "repaired",
+				ID:     p.id,
+				By:     "system",
+				Ts:     now,
+				Repair: &domain.RepairRecord{Code: DoctorDuplicateID, Layer: layer, ID: id},
+			})
+		}
+	}
+	return fix && len(dups) > 0
+}
+
+// checkDependencies reports dependency IDs that do not exist and, when
+// fixing, returns the dependency list without them.
+func (s *DoctorService) checkDependencies(report *DoctorReport, projectID, layer, id string, dependsOn []string, exists func(string) bool, fix bool) []string {
+	var kept []string
+	for _, depID := range dependsOn {
+		if exists(depID) {
+			kept = append(kept, depID)
+			continue
+		}
+		report.Findings = append(report.Findings, DoctorFinding{
+			Code:      DoctorDanglingDependency,
+			ProjectID: projectID,
+			Layer:     layer,
+			ID:        id,
+			Message:   fmt.Sprintf("depends_on references %s, which does not exist", depID),
+			Fixable:   true,
+			Fixed:     fix,
+		})
+		if fix {
+			report.Fixed++
+		} else {
+			kept = append(kept, depID)
+		}
+	}
+	return kept
+}
+
+func allSatisfied(dependsOn []string, satisfied func(string) bool) bool {
+	for _, depID := range dependsOn {
+		if !satisfied(depID) {
+			return false
+		}
+	}
+	return true
+}
+
+func staleBlockedFinding(projectID, layer, id string, fix bool) DoctorFinding {
+	return DoctorFinding{
+		Code:      DoctorStaleBlocked,
+		ProjectID: projectID,
+		Layer:     layer,
+		ID:        id,
+		Message:   "Status is blocked but every dependency is satisfied",
+		Fixable:   true,
+		Fixed:     fix,
+	}
+}
+
+func staleReadyFinding(projectID, layer, id, status string, fix bool) DoctorFinding {
+	return DoctorFinding{
+		Code:      DoctorStaleReady,
+		ProjectID: projectID,
+		Layer:     layer,
+		ID:        id,
+		Message:   fmt.Sprintf("Status is %s but a dependency is not finished", status),
+		Fixable:   true,
+		Fixed:     fix,
+	}
+}
+
+// checkTmpFiles finds temporary files left behind by interrupted writes
+func (s *DoctorService) checkTmpFiles(report *DoctorReport, projects []*doctorProject, fix bool, now time.Time) error {
+	projectsByID := make(map[string]*doctorProject)
+	for _, p := range projects {
+		projectsByID[p.id] = p
+	}
+
+	mandorDir := s.paths.MandorDirPath()
+	return filepath.WalkDir(mandorDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return domain.NewSystemError("Cannot scan .mandor directory", err)
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), mfs.TmpSuffix) {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(s.paths.WorkspaceRoot, path)
+		finding := DoctorFinding{
+			Code:    DoctorLeftoverTmp,
+			Path:    rel,
+			Message: "Temporary file left behind by an interrupted write",
+			Fixable: true,
+			Fixed:   fix,
+		}
+
+		// .mandor/projects/<id>/<file>.tmp belongs to a project
+		if projRel, err := filepath.Rel(s.paths.ProjectsDirPath(), path); err == nil && !strings.HasPrefix(projRel, "..") {
+			parts := strings.Split(projRel, string(filepath.Separator))
+			if len(parts) == 2 {
+				finding.ProjectID = parts[0]
+			}
+		}
+
+		if fix {
+			if err := os.Remove(path); err != nil {
+				return domain.NewSystemError("Cannot remove "+rel, err)
+			}
+			report.Fixed++
+			if p := projectsByID[finding.ProjectID]; p != nil {
+				p.projectEvents = append(p.projectEvents, &domain.ProjectEvent{
+					Layer:  "project",
+					Type:   "repaired",
+					ID:     p.id,
+					By:     "system",
+					Ts:     now,
+					Repair: &domain.RepairRecord{Code: DoctorLeftoverTmp, Path: rel},
+				})
+			}
+		}
+
+		report.Findings = append(report.Findings, finding)
+		return nil
+	})
+}
+
+func (s *DoctorService) writeRepairs(p *doctorProject) error {
+	if p.dirtyFeatures {
+		if err := s.writer.ReplaceFeatures(p.id, p.features, nil); err != nil {
+			return err
+		}
+	}
+	if p.dirtyTasks {
+		if err := s.writer.ReplaceTasks(p.id, p.tasks, nil); err != nil {
+			return err
+		}
+	}
+	if p.dirtyIssues {
+		if err := s.writer.ReplaceIssues(p.id, p.issues, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range p.projectEvents {
+		if err := s.writer.AppendProjectEvent(p.id, event); err != nil {
+			return err
+		}
+	}
+	for _, event := range p.featureEvents {
+		if err := s.writer.AppendFeatureEvent(p.id, event); err != nil {
+			return err
+		}
+	}
+	for _, event := range p.taskEvents {
+		if err := s.writer.AppendTaskEvent(p.id, event); err != nil {
+			return err
+		}
+	}
+	for _, event := range p.issueEvents {
+		if err := s.writer.AppendIssueEvent(p.id, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repairFields are the entity fields doctor --fix may change
+var repairFields = []string{"depends_on", "status"}
+
+func changedFieldNames(diff []domain.FieldChange) []string {
+	names := make([]string, 0, len(diff))
+	for _, change := range diff {
+		names = append(names, change.Field)
+	}
+	return names
+}
+
+func featureRepairEvent(f, before *domain.Feature, now time.Time) *domain.FeatureEvent {
+	diff := domain.DiffFields(before, f, repairFields)
+	return &domain.FeatureEvent{
+		Layer:    "feature",
+		Type:     "repaired",
+		ID:       f.ID,
+		By:       "system",
+		Ts:       now,
+		Changes:  changedFieldNames(diff),
+		Diff:     diff,
+		Snapshot: f,
+	}
+}
+
+func taskRepairEvent(t, before *domain.Task, now time.Time) *domain.TaskEvent {
+	diff := domain.DiffFields(before, t, repairFields)
+	return &domain.TaskEvent{
+		Layer:    "task",
+		Type:     "repaired",
+		ID:       t.ID,
+		By:       "system",
+		Ts:       now,
+		Changes:  changedFieldNames(diff),
+		Diff:     diff,
+		Snapshot: t,
+	}
+}
+
+func issueRepairEvent(i, before *domain.Issue, now time.Time) *domain.IssueEvent {
+	diff := domain.DiffFields(before, i, repairFields)
+	return &domain.IssueEvent{
+		Layer:    "issue",
+		Type:     "repaired",
+		ID:       i.ID,
+		By:       "system",
+		Ts:       now,
+		Changes:  changedFieldNames(diff),
+		Diff:     diff,
+		Snapshot: i,
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -41,6 +42,17 @@ func (s *FeatureService) WorkspaceInitialized() bool {
 	return s.reader.WorkspaceExists()
 }
 
+// ResolveFeatureID expands a unique prefix or suffix of a feature ID to the
+// full ID. projectID narrows the search; empty searches every project.
+func (s *FeatureService) ResolveFeatureID(projectID, ref string) (string, error) {
+	return idResolver{s.reader, s.paths}.resolve("feature", projectID, ref)
+}
+
+// ResolveFeatureIDs resolves each short feature ID in refs across all projects
+func (s *FeatureService) ResolveFeatureIDs(refs []string) ([]string, error) {
+	return idResolver{s.reader, s.paths}.resolveAll("feature", "", refs)
+}
+
 func (s *FeatureService) ValidateCreateInput(input *domain.FeatureCreateInput) error {
 	if !s.reader.ProjectExists(input.ProjectID) {
 		return domain.NewValidationError("Project not found: " + input.ProjectID)
@@ -66,19 +78,21 @@ func (s *FeatureService) ValidateCreateInput(input *domain.FeatureCreateInput) e
 		return domain.NewValidationError("Invalid scope. Valid options: frontend, backend, fullstack, cli, desktop, android, flutter, react-native, ios, swift")
 	}
 
-	// Apply default priority if not specified
+	// Apply the project's default priority if not specified
+	priorities, err := projectPriorityScheme(s.reader, input.ProjectID)
+	if err != nil {
+		return err
+	}
 	if input.Priority == "" {
-		// Use default priority from workspace config
-		ws, err := s.reader.ReadWorkspace()
-		if err == nil && ws.Config.DefaultPriority != "" {
-			input.Priority = ws.Config.DefaultPriority
-		} else {
-			input.Priority = "P3"
-		}
+		input.Priority = priorities.DefaultFor(s.reader, "P3")
+	}
+
+	if err := priorities.Validate(input.Priority); err != nil {
+		return err
 	}
 
-	if !domain.ValidatePriority(input.Priority) {
-		return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+	if err := validateProjectLabels(s.reader, input.ProjectID, input.Labels); err != nil {
+		return err
 	}
 
 	if err := s.validateDependencies(input.ProjectID, "", input.DependsOn); err != nil {
@@ -89,12 +103,28 @@ func (s *FeatureService) ValidateCreateInput(input *domain.FeatureCreateInput) e
 }
 
 func (s *FeatureService) validateDependencies(projectID, selfID string, dependsOn []string) error {
+	// Read schema to check if cross-project dependencies are allowed
+	schema, err := s.reader.ReadProjectSchema(projectID)
+	if err != nil {
+		return domain.NewSystemError("Cannot read project schema", err)
+	}
+	allowCrossProject := schema.Rules.Feature.Dependency != "same_project_only" && schema.Rules.Feature.Dependency != "disabled"
+
 	for _, depID := range dependsOn {
 		if depID == selfID {
 			return domain.NewValidationError("Self-dependency detected. Entity cannot depend on itself.")
 		}
 
-		dep, err := s.reader.ReadFeature(projectID, depID)
+		depProjectID := extractProjectIDFromFeatureID(depID)
+		if depProjectID == "" {
+			return domain.NewValidationError("Invalid feature ID format: " + depID)
+		}
+
+		if depProjectID != projectID && !allowCrossProject {
+			return domain.NewValidationError(fmt.Sprintf("Cross-project dependency detected: %s -> %s. Cross-project dependencies are disabled.", selfID, depID))
+		}
+
+		dep, err := s.reader.ReadFeature(depProjectID, depID)
 		if err != nil {
 			if _, ok := err.(*domain.MandorError); ok {
 				return domain.NewValidationError("Dependency not found: " + depID)
@@ -115,42 +145,32 @@ func (s *FeatureService) validateDependencies(projectID, selfID string, dependsO
 }
 
 func (s *FeatureService) validateNoCycle(projectID, selfID string, dependsOn []string) error {
-	visited := make(map[string]bool)
-	var dfs func(featureID string) bool
-
-	dfs = func(featureID string) bool {
-		if featureID == selfID {
-			return true
-		}
-		if visited[featureID] {
-			return false
-		}
-		visited[featureID] = true
-
-		f, err := s.reader.ReadFeature(projectID, featureID)
-		if err != nil {
-			return false
-		}
-
-		for _, dep := range f.DependsOn {
-			if dfs(dep) {
-				return true
-			}
-		}
-		return false
+	allowed, err := cycleAllowed(s.reader, projectID, "feature")
+	if err != nil || allowed {
+		return err
 	}
 
-	for _, depID := range dependsOn {
-		visited = make(map[string]bool)
-		if dfs(depID) {
-			return domain.NewValidationError("Circular dependency detected.")
+	path := findCyclePath(selfID, dependsOn, func(featureID string) []string {
+		f, err := s.reader.ReadFeature(extractProjectIDFromFeatureID(featureID), featureID)
+		if err != nil {
+			return nil
 		}
+		return f.DependsOn
+	})
+	if path != nil {
+		return cycleError(path)
 	}
 
 	return nil
 }
 
 func (s *FeatureService) CreateFeature(input *domain.FeatureCreateInput) (*domain.Feature, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	creator := util.GetGitUsername()
 	now := time.Now().UTC()
 
@@ -170,6 +190,8 @@ func (s *FeatureService) CreateFeature(input *domain.FeatureCreateInput) (*domai
 		Priority:  input.Priority,
 		Status:    domain.FeatureStatusDraft,
 		DependsOn: input.DependsOn,
+		Assignee:  strings.TrimSpace(input.Assignee),
+		Labels:    input.Labels,
 		CreatedAt: now,
 		UpdatedAt: now,
 		CreatedBy: creator,
@@ -177,7 +199,7 @@ func (s *FeatureService) CreateFeature(input *domain.FeatureCreateInput) (*domai
 	}
 
 	if len(input.DependsOn) > 0 {
-		allDone, err := s.checkDependenciesDone(input.ProjectID, input.DependsOn)
+		allDone, err := s.checkDependenciesDone(input.DependsOn)
 		if err != nil {
 			return nil, err
 		}
@@ -191,11 +213,12 @@ func (s *FeatureService) CreateFeature(input *domain.FeatureCreateInput) (*domai
 	}
 
 	event := &domain.FeatureEvent{
-		Layer: "feature",
-		Type:  "created",
-		ID:    featureID,
-		By:    creator,
-		Ts:    now,
+		Layer:    "feature",
+		Type:     "created",
+		ID:       featureID,
+		By:       creator,
+		Ts:       now,
+		Snapshot: feature,
 	}
 	if err := s.writer.AppendFeatureEvent(input.ProjectID, event); err != nil {
 		return nil, err
@@ -204,13 +227,13 @@ func (s *FeatureService) CreateFeature(input *domain.FeatureCreateInput) (*domai
 	return feature, nil
 }
 
-func (s *FeatureService) checkDependenciesDone(projectID string, dependsOn []string) (bool, error) {
+func (s *FeatureService) checkDependenciesDone(dependsOn []string) (bool, error) {
 	for _, depID := range dependsOn {
-		dep, err := s.reader.ReadFeature(projectID, depID)
+		dep, err := s.reader.ReadFeature(extractProjectIDFromFeatureID(depID), depID)
 		if err != nil {
 			return false, domain.NewValidationError("Dependency not found: " + depID)
 		}
-		if dep.Status != domain.FeatureStatusDone && dep.Status != domain.FeatureStatusCancelled {
+		if dep.Status != domain.FeatureStatusDone {
 			return false, nil
 		}
 	}
@@ -222,10 +245,15 @@ func (s *FeatureService) ListFeatures(input *domain.FeatureListInput) (*domain.F
 		return nil, domain.NewValidationError("Project not found: " + input.ProjectID)
 	}
 
+	progress, err := s.featureProgress(input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
 	var features []domain.FeatureListItem
 	deletedCount := 0
 
-	err := s.reader.ReadNDJSON(s.paths.ProjectFeaturesPath(input.ProjectID), func(raw []byte) error {
+	err = s.reader.ReadNDJSON(s.paths.ProjectFeaturesPath(input.ProjectID), func(raw []byte) error {
 		var f domain.Feature
 		if err := json.Unmarshal(raw, &f); err != nil {
 			return err
@@ -233,6 +261,9 @@ func (s *FeatureService) ListFeatures(input *domain.FeatureListInput) (*domain.F
 		if !input.IncludeDeleted && f.Status == domain.FeatureStatusCancelled {
 			return nil
 		}
+		if !matchLabels(f.Labels, input.Labels, input.LabelMatch) {
+			return nil
+		}
 
 		item := domain.FeatureListItem{
 			ID:        f.ID,
@@ -242,8 +273,11 @@ func (s *FeatureService) ListFeatures(input *domain.FeatureListInput) (*domain.F
 			Priority:  f.Priority,
 			Status:    f.Status,
 			DependsOn: len(f.DependsOn),
+			Assignee:  f.Assignee,
+			Labels:    f.Labels,
 			CreatedAt: f.CreatedAt.Format(time.RFC3339),
 			UpdatedAt: f.UpdatedAt.Format(time.RFC3339),
+			Progress:  progress[f.ID],
 		}
 		features = append(features, item)
 
@@ -276,24 +310,210 @@ func (s *FeatureService) GetFeatureDetail(input *domain.FeatureDetailInput) (*do
 
 	events, _ := s.reader.CountEventLines(input.ProjectID)
 
+	progress, err := s.featureProgress(input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, commentCount, err := entityComments(s.reader, s.paths, input.ProjectID, feature.ID, input.LastComments)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.FeatureDetailOutput{
-		ID:        feature.ID,
-		ProjectID: feature.ProjectID,
-		Name:      feature.Name,
-		Goal:      feature.Goal,
-		Scope:     feature.Scope,
-		Priority:  feature.Priority,
-		Status:    feature.Status,
-		DependsOn: feature.DependsOn,
-		Reason:    feature.Reason,
-		Events:    events,
-		CreatedAt: feature.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: feature.UpdatedAt.Format(time.RFC3339),
-		CreatedBy: feature.CreatedBy,
-		UpdatedBy: feature.UpdatedBy,
+		ID:           feature.ID,
+		ProjectID:    feature.ProjectID,
+		Name:         feature.Name,
+		Goal:         feature.Goal,
+		Scope:        feature.Scope,
+		Priority:     feature.Priority,
+		Status:       feature.Status,
+		DependsOn:    feature.DependsOn,
+		Reason:       feature.Reason,
+		Assignee:     feature.Assignee,
+		Labels:       feature.Labels,
+		Events:       events,
+		CommentCount: commentCount,
+		Comments:     comments,
+		CreatedAt:    feature.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    feature.UpdatedAt.Format(time.RFC3339),
+		CreatedBy:    feature.CreatedBy,
+		UpdatedBy:    feature.UpdatedBy,
+		Progress:     progress[feature.ID],
 	}, nil
 }
 
+// featureProgress counts done and total tasks per feature of projectID
+func (s *FeatureService) featureProgress(projectID string) (map[string]domain.FeatureProgress, error) {
+	progress := make(map[string]domain.FeatureProgress)
+	err := s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
+		var t domain.Task
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return err
+		}
+		if t.Status == domain.TaskStatusCancelled {
+			return nil
+		}
+		p := progress[t.FeatureID]
+		p.Total++
+		if t.Status == domain.TaskStatusDone {
+			p.Done++
+		}
+		p.Percent = p.Done * 100 / p.Total
+		progress[t.FeatureID] = p
+		return nil
+	})
+	return progress, err
+}
+
+// rollupFromTasks moves a feature along with its tasks: a draft feature
+// becomes active once one of its tasks is in progress, and, when the project
+// schema enables rules.rollup.auto_complete_features, an active feature whose
+// tasks are all done or cancelled, with at least one done, becomes done and
+// unblocks its dependents. Both moves go through validateStatusTransition.
+// A done feature with an open task again (a task was reopened) goes back to
+// active in every mode and blocks its dependents again. Each change is
+// recorded as a system event. It returns the feature's new status, or "" when
+// nothing changed.
+func (s *FeatureService) rollupFromTasks(projectID, featureID string) (string, error) {
+	feature, target, err := s.planRollup(projectID, featureID, nil)
+	if err != nil || target == "" {
+		return "", err
+	}
+	before := *feature
+	feature.Status = target
+
+	now := time.Now().UTC()
+	feature.UpdatedAt = now
+	if err := s.writer.ReplaceFeature(projectID, feature); err != nil {
+		return "", err
+	}
+	event := &domain.FeatureEvent{
+		Layer:    "feature",
+		Type:     feature.Status,
+		ID:       feature.ID,
+		By:       "system",
+		Ts:       now,
+		Changes:  []string{"status"},
+		Diff:     domain.DiffFields(before, feature, []string{"status"}),
+		Snapshot: feature,
+	}
+	if err := s.writer.AppendFeatureEvent(projectID, event); err != nil {
+		return "", err
+	}
+
+	switch {
+	case feature.Status == domain.FeatureStatusDone:
+		if _, err := s.unblockDependents(feature.ID); err != nil {
+			return "", err
+		}
+	case before.Status == domain.FeatureStatusDone:
+		plan, err := s.planReblockDependents(feature.ID, now)
+		if err != nil {
+			return "", err
+		}
+		if err := s.applyPlan(plan); err != nil {
+			return "", err
+		}
+	}
+	return feature.Status, nil
+}
+
+// planRollup works out, without writing anything, the status rollupFromTasks
+// would move featureID to, or "" for none. changed, when given, counts with
+// its in-memory status in place of the stored task so a dry run can show the
+// rollup.
+func (s *FeatureService) planRollup(projectID, featureID string, changed *domain.Task) (*domain.Feature, string, error) {
+	feature, err := s.reader.ReadFeature(projectID, featureID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var started bool
+	var open, done int
+	count := func(t *domain.Task) {
+		switch t.Status {
+		case domain.TaskStatusDone:
+			done++
+		case domain.TaskStatusCancelled:
+		case domain.TaskStatusInProgress:
+			started = true
+			open++
+		default:
+			open++
+		}
+	}
+	err = s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
+		var t domain.Task
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return err
+		}
+		if t.FeatureID != featureID || (changed != nil && t.ID == changed.ID) {
+			return nil
+		}
+		count(&t)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if changed != nil && changed.FeatureID == featureID {
+		count(changed)
+	}
+
+	target := ""
+	switch {
+	case feature.Status == domain.FeatureStatusDone && open > 0:
+		target = domain.FeatureStatusActive
+	case feature.Status == domain.FeatureStatusDraft && started:
+		target = domain.FeatureStatusActive
+	case open == 0 && done > 0:
+		schema, err := s.reader.ReadProjectSchema(projectID)
+		if err != nil {
+			// No schema.json means the rollup rules are off
+			if isValidationError(err) {
+				return feature, "", nil
+			}
+			return nil, "", err
+		}
+		if !schema.Rules.Rollup.AutoCompleteFeatures {
+			return feature, "", nil
+		}
+		target = domain.FeatureStatusDone
+	default:
+		return feature, "", nil
+	}
+	// Leaving done is a reopen, which the state machine only allows by hand
+	if feature.Status != domain.FeatureStatusDone {
+		if err := s.validateStatusTransition(projectID, feature, target); err != nil {
+			if isValidationError(err) {
+				return feature, "", nil
+			}
+			return nil, "", err
+		}
+	}
+
+	if target == domain.FeatureStatusDone {
+		// Strict rules still apply; leave the feature open for a manual close
+		after := *feature
+		after.Status = target
+		if err := s.checkStrictFeature(projectID, feature, &after); err != nil {
+			if isValidationError(err) {
+				return feature, "", nil
+			}
+			return nil, "", err
+		}
+	}
+	return feature, target, nil
+}
+
+// isValidationError reports whether err is a MandorError with the
+// validation exit code, as opposed to a system or permission failure
+func isValidationError(err error) bool {
+	var mErr *domain.MandorError
+	return errors.As(err, &mErr) && mErr.Code == domain.ExitValidationError
+}
+
 func (s *FeatureService) ValidateUpdateInput(input *domain.FeatureUpdateInput) error {
 	feature, err := s.reader.ReadFeature(input.ProjectID, input.FeatureID)
 	if err != nil {
@@ -312,14 +532,34 @@ func (s *FeatureService) ValidateUpdateInput(input *domain.FeatureUpdateInput) e
 		return domain.NewValidationError("Feature goal cannot be empty.")
 	}
 
-	if input.Priority != nil && !domain.ValidatePriority(*input.Priority) {
-		return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+	if input.Priority != nil {
+		if err := validateProjectPriority(s.reader, input.ProjectID, *input.Priority); err != nil {
+			return err
+		}
 	}
 
 	if input.Status != nil && !domain.ValidateFeatureStatus(*input.Status) {
 		return domain.NewValidationError("Invalid status. Valid options: draft, active, done, blocked, cancelled")
 	}
 
+	statusFlags := 0
+	for _, set := range []bool{input.Status != nil, input.Start, input.Complete, input.Cancel, input.Reopen} {
+		if set {
+			statusFlags++
+		}
+	}
+	if statusFlags > 1 {
+		return domain.NewValidationError("Use only one of --status, --start, --complete, --cancel or --reopen.")
+	}
+
+	cancelling := input.Cancel || (input.Status != nil && *input.Status == domain.FeatureStatusCancelled)
+	if err := validateCancelPolicyFlags(cancelling, input.Cascade, input.Rewire, input.KeepBlocked); err != nil {
+		return err
+	}
+	if _, err := resolveCancelPolicy(input.Cascade, input.Rewire, input.KeepBlocked, input.Force); err != nil {
+		return err
+	}
+
 	if input.Scope != nil && !domain.ValidateScope(*input.Scope) {
 		return domain.NewValidationError("Invalid scope. Valid options: frontend, backend, fullstack, cli, desktop, android, flutter, react-native, ios, swift")
 	}
@@ -334,24 +574,49 @@ func (s *FeatureService) ValidateUpdateInput(input *domain.FeatureUpdateInput) e
 }
 
 func (s *FeatureService) UpdateFeature(input *domain.FeatureUpdateInput) ([]string, error) {
-	feature, err := s.reader.ReadFeature(input.ProjectID, input.FeatureID)
+	return s.updateFeature(input, nil)
+}
+
+// PreviewFeatureUpdate runs input through the whole update in memory and
+// returns what it would change, without writing anything
+func (s *FeatureService) PreviewFeatureUpdate(input *domain.FeatureUpdateInput) (*domain.UpdatePreview, error) {
+	dryRun := *input
+	dryRun.DryRun = true
+	preview := &domain.UpdatePreview{}
+	if _, err := s.updateFeature(&dryRun, preview); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// updateFeature applies input. On a dry run it stops before the first write
+// and fills preview, when given, with the computed change set.
+func (s *FeatureService) updateFeature(input *domain.FeatureUpdateInput, preview *domain.UpdatePreview) ([]string, error) {
+	unlock, err := s.writer.Lock()
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
 
-	if input.DryRun {
-		return []string{"[DRY RUN] Would update feature: " + input.FeatureID}, nil
+	feature, err := s.reader.ReadFeature(input.ProjectID, input.FeatureID)
+	if err != nil {
+		return nil, err
 	}
 
+	before := *feature
 	var changes []string
 	updater := util.GetGitUsername()
 	now := time.Now().UTC()
 
 	if input.Reopen {
-		if feature.Status != domain.FeatureStatusCancelled {
-			return nil, domain.NewValidationError("Feature is not cancelled. Nothing to reopen.")
+		switch feature.Status {
+		case domain.FeatureStatusCancelled:
+			feature.Status = domain.FeatureStatusDraft
+		case domain.FeatureStatusDone:
+			feature.Status = domain.FeatureStatusActive
+		default:
+			return nil, domain.NewValidationError("Feature is not done or cancelled. Nothing to reopen.")
 		}
-		feature.Status = domain.FeatureStatusDraft
 		feature.Reason = ""
 		changes = append(changes, "status", "reason")
 	}
@@ -361,14 +626,6 @@ func (s *FeatureService) UpdateFeature(input *domain.FeatureUpdateInput) ([]stri
 			return nil, domain.NewValidationError("Feature is already cancelled.")
 		}
 
-		dependents, err := s.findDependents(input.ProjectID, input.FeatureID)
-		if err != nil {
-			return nil, err
-		}
-		if len(dependents) > 0 && !input.Force {
-			return nil, domain.NewValidationError("Feature has " + fmt.Sprintf("%d", len(dependents)) + " dependent(s). Use --force to cancel anyway.")
-		}
-
 		if input.Reason == nil || *input.Reason == "" {
 			return nil, domain.NewValidationError("Cancellation reason is required (--reason).")
 		}
@@ -398,8 +655,48 @@ func (s *FeatureService) UpdateFeature(input *domain.FeatureUpdateInput) ([]stri
 		changes = append(changes, "priority")
 	}
 
-	if input.Status != nil && *input.Status != feature.Status {
-		feature.Status = *input.Status
+	if input.Assignee != nil && strings.TrimSpace(*input.Assignee) != feature.Assignee {
+		feature.Assignee = strings.TrimSpace(*input.Assignee)
+		changes = append(changes, "assignee")
+	}
+
+	labels, labelsChanged := applyLabelChanges(feature.Labels, input.Labels, input.LabelsAdd, input.LabelsRemove)
+	if labelsChanged {
+		if err := validateProjectLabels(s.reader, input.ProjectID, addedDependencies(feature.Labels, labels)); err != nil {
+			return nil, err
+		}
+		feature.Labels = labels
+		changes = append(changes, "labels")
+	}
+
+	target := ""
+	switch {
+	case input.Start:
+		if feature.Status != domain.FeatureStatusDraft {
+			return nil, domain.NewValidationError(fmt.Sprintf("Feature is not in startable state (draft), currently %s.", feature.Status))
+		}
+		target = domain.FeatureStatusActive
+	case input.Complete:
+		if feature.Status != domain.FeatureStatusActive {
+			return nil, domain.NewValidationError(fmt.Sprintf("Feature must be active to complete, currently %s. Use --start first.", feature.Status))
+		}
+		target = domain.FeatureStatusDone
+	case input.Status != nil:
+		target = *input.Status
+	}
+
+	if target != "" && target != feature.Status {
+		if err := s.validateStatusTransition(input.ProjectID, feature, target); err != nil {
+			return nil, err
+		}
+		if target == domain.FeatureStatusCancelled {
+			if input.Reason == nil || *input.Reason == "" {
+				return nil, domain.NewValidationError("Cancellation reason is required (--reason).")
+			}
+			feature.Reason = *input.Reason
+			changes = append(changes, "reason")
+		}
+		feature.Status = target
 		changes = append(changes, "status")
 	}
 
@@ -408,6 +705,75 @@ func (s *FeatureService) UpdateFeature(input *domain.FeatureUpdateInput) ([]stri
 		changes = append(changes, "depends_on")
 	}
 
+	if input.Priority != nil {
+		if err := validateProjectPriority(s.reader, input.ProjectID, feature.Priority); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkStrictFeature(input.ProjectID, &before, feature); err != nil {
+		return nil, err
+	}
+
+	// Dependents of a cancelled feature follow the chosen policy
+	cancelling := feature.Status == domain.FeatureStatusCancelled && before.Status != domain.FeatureStatusCancelled
+	cancelPlan := &featurePlan{}
+	if cancelling {
+		policy, err := resolveCancelPolicy(input.Cascade, input.Rewire, input.KeepBlocked, input.Force)
+		if err != nil {
+			return nil, err
+		}
+		cancelPlan, err = s.planCancelDependents(feature, policy, input.Rewire, updater, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unblockPlan := &featurePlan{}
+	if feature.Status == domain.FeatureStatusDone && before.Status != domain.FeatureStatusDone {
+		unblockPlan, err = s.planUnblockDependents(input.FeatureID, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reblockPlan := &featurePlan{}
+	if isFeatureFinished(before.Status) && !isFeatureFinished(feature.Status) && !cancelling {
+		reblockPlan, err = s.planReblockDependents(input.FeatureID, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if input.DryRun {
+		var rollups []domain.StatusChange
+		if feature.Status == domain.FeatureStatusActive && before.Status != domain.FeatureStatusActive {
+			activation, err := NewProjectServiceWithPaths(s.paths).planActivation(input.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			if activation != nil {
+				rollups = append(rollups, *activation)
+			}
+		}
+		p := &domain.UpdatePreview{
+			Layer:      "feature",
+			ID:         input.FeatureID,
+			Changes:    changes,
+			Diff:       domain.DiffFields(before, feature, changes),
+			StatusFrom: before.Status,
+			StatusTo:   feature.Status,
+			Unblocked:  unblockPlan.ids(),
+			Reblocked:  reblockPlan.ids(),
+			Rollups:    rollups,
+			Dependents: cancelPlan.lines,
+			Warnings:   append(cancelPlan.warnings, reblockPlan.warnings...),
+		}
+		if preview != nil {
+			*preview = *p
+		}
+		return p.Lines(), nil
+	}
+
 	feature.UpdatedAt = now
 	feature.UpdatedBy = updater
 
@@ -415,116 +781,450 @@ func (s *FeatureService) UpdateFeature(input *domain.FeatureUpdateInput) ([]stri
 		return nil, err
 	}
 
+	if len(cancelPlan.features) > 0 {
+		changes = append(changes, cancelPlan.change)
+	}
+	if len(unblockPlan.features) > 0 {
+		changes = append(changes, "dependent_unblocked")
+	}
+	if len(reblockPlan.features) > 0 {
+		changes = append(changes, "dependent_blocked")
+	}
+
 	event := &domain.FeatureEvent{
-		Layer:   "feature",
-		Type:    "updated",
-		ID:      input.FeatureID,
-		By:      updater,
-		Ts:      now,
-		Changes: changes,
+		Layer:    "feature",
+		Type:     "updated",
+		ID:       input.FeatureID,
+		By:       updater,
+		Ts:       now,
+		Changes:  changes,
+		Diff:     domain.DiffFields(before, feature, changes),
+		Snapshot: feature,
 	}
 	if err := s.writer.AppendFeatureEvent(input.ProjectID, event); err != nil {
 		return nil, err
 	}
 
-	// If feature is marked as done, unblock dependent features
-	if input.Status != nil && *input.Status == domain.FeatureStatusDone {
-		if unblocked, err := s.unblockDependents(input.ProjectID, input.FeatureID); err == nil && unblocked {
-			changes = append(changes, "dependent_unblocked")
+	// Dependents are written after the feature's own event
+	for _, plan := range []*featurePlan{cancelPlan, unblockPlan, reblockPlan} {
+		if err := s.applyPlan(plan); err != nil {
+			return nil, err
 		}
 	}
 
+	// The first active feature starts the project
+	if feature.Status == domain.FeatureStatusActive && before.Status != domain.FeatureStatusActive {
+		activated, err := NewProjectServiceWithPaths(s.paths).activateProject(input.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if activated {
+			changes = append(changes, "project_active")
+		}
+	}
+
+	// What happened to each dependent is reported, not recorded as a field
+	changes = append(changes, cancelPlan.lines...)
+	changes = append(changes, cancelPlan.warnings...)
+	changes = append(changes, reblockPlan.warnings...)
+
 	return changes, nil
 }
 
-func (s *FeatureService) unblockDependents(projectID, doneFeatureID string) (bool, error) {
-	unblockedAny := false
-	now := time.Now().UTC()
+// validateStatusTransition enforces the feature state machine:
+//
+//	draft   -> active, blocked, cancelled
+//	active  -> done, blocked, cancelled
+//	blocked -> draft, active, cancelled (only once every dependency is done)
+//	done    -> blocked, cancelled
+//
+// cancelled features change only through --reopen. A feature can
+// only be done when none of its tasks are open.
+func (s *FeatureService) validateStatusTransition(projectID string, feature *domain.Feature, next string) error {
+	validTransitions := map[string][]string{
+		domain.FeatureStatusDraft:   {domain.FeatureStatusActive, domain.FeatureStatusBlocked, domain.FeatureStatusCancelled},
+		domain.FeatureStatusActive:  {domain.FeatureStatusDone, domain.FeatureStatusBlocked, domain.FeatureStatusCancelled},
+		domain.FeatureStatusBlocked: {domain.FeatureStatusDraft, domain.FeatureStatusActive, domain.FeatureStatusCancelled},
+		domain.FeatureStatusDone:    {domain.FeatureStatusBlocked, domain.FeatureStatusCancelled},
+	}
+
+	allowed, ok := validTransitions[feature.Status]
+	if !ok {
+		return domain.NewValidationError(fmt.Sprintf("Cannot transition from %s. Use --reopen first.", feature.Status))
+	}
+	valid := false
+	for _, allowedStatus := range allowed {
+		if next == allowedStatus {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return domain.NewValidationError(fmt.Sprintf("Invalid status transition from %s to %s", feature.Status, next))
+	}
+
+	if feature.Status == domain.FeatureStatusBlocked && next != domain.FeatureStatusCancelled {
+		allDone, err := s.checkDependenciesDone(feature.DependsOn)
+		if err != nil {
+			return err
+		}
+		if !allDone {
+			return domain.NewValidationError(fmt.Sprintf("Feature %s still has unfinished dependencies.", feature.ID))
+		}
+	}
 
-	// Load all features in this project
-	var allFeatures []*domain.Feature
-	err := s.reader.ReadNDJSON(s.paths.ProjectFeaturesPath(projectID), func(raw []byte) error {
-		var feature domain.Feature
-		if err := json.Unmarshal(raw, &feature); err != nil {
+	if next == domain.FeatureStatusDone {
+		open, err := s.openTasks(projectID, feature.ID)
+		if err != nil {
 			return err
 		}
-		allFeatures = append(allFeatures, &feature)
+		if len(open) > 0 {
+			return domain.NewValidationError(fmt.Sprintf(
+				"Feature %s has %d open task(s):\n  %s",
+				feature.ID, len(open), strings.Join(open, "\n  "),
+			))
+		}
+	}
+
+	return nil
+}
+
+// openTasks lists the tasks of featureID that are neither done nor cancelled
+func (s *FeatureService) openTasks(projectID, featureID string) ([]string, error) {
+	var open []string
+	err := s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
+		var t domain.Task
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return err
+		}
+		if t.FeatureID == featureID && t.Status != domain.TaskStatusDone && t.Status != domain.TaskStatusCancelled {
+			open = append(open, fmt.Sprintf("%s (%s)", t.ID, t.Status))
+		}
 		return nil
 	})
+	return open, err
+}
+
+// unblockDependents moves blocked features that depend on doneFeatureID, in
+// any project, back to draft once all of their dependencies are done
+func (s *FeatureService) unblockDependents(doneFeatureID string) (bool, error) {
+	plan, err := s.planUnblockDependents(doneFeatureID, time.Now().UTC())
 	if err != nil {
 		return false, err
 	}
+	if err := s.applyPlan(plan); err != nil {
+		return false, err
+	}
+	return len(plan.features) > 0, nil
+}
 
-	// Track which features need to be written back
-	featuresToWrite := make(map[string]*domain.Feature)
-	eventsToAppend := []*domain.FeatureEvent{}
+// planUnblockDependents works out what unblockDependents would change.
+// doneFeatureID counts as done even before it is written so the plan can be
+// shown on a dry run.
+func (s *FeatureService) planUnblockDependents(doneFeatureID string, now time.Time) (*featurePlan, error) {
+	all, err := s.workspaceFeatures()
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]string, len(all))
+	for _, f := range all {
+		status[f.ID] = f.Status
+	}
+	status[doneFeatureID] = domain.FeatureStatusDone
 
-	// Process all features to find those that should unblock
-	for _, feature := range allFeatures {
-		if feature.Status != domain.FeatureStatusBlocked {
+	plan := &featurePlan{}
+	for _, f := range all {
+		if f.Status != domain.FeatureStatusBlocked || !containsID(f.DependsOn, doneFeatureID) {
 			continue
 		}
-
-		hasDone := false
 		allDone := true
-		for _, depID := range feature.DependsOn {
-			if depID == doneFeatureID {
-				hasDone = true
-			}
-			dep, err := s.reader.ReadFeature(projectID, depID)
-			if err != nil {
-				return false, err
-			}
-			if dep.Status != domain.FeatureStatusDone && dep.Status != domain.FeatureStatusCancelled {
+		for _, depID := range f.DependsOn {
+			if !isFeatureFinished(status[depID]) {
 				allDone = false
+				break
 			}
 		}
+		if !allDone {
+			continue
+		}
 
-		if hasDone && allDone {
-			feature.Status = domain.FeatureStatusDraft
-			feature.UpdatedAt = now
-			featuresToWrite[feature.ID] = feature
-			unblockedAny = true
-
-			event := &domain.FeatureEvent{
-				Layer: "feature",
-				Type:  "unblocked",
-				ID:    feature.ID,
-				By:    "system",
-				Ts:    now,
+		f.Status = domain.FeatureStatusDraft
+		f.UpdatedAt = now
+		plan.features = append(plan.features, f)
+		plan.events = append(plan.events, &domain.FeatureEvent{
+			Layer:    "feature",
+			Type:     "unblocked",
+			ID:       f.ID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: f,
+		})
+	}
+	return plan, nil
+}
+
+// planReblockDependents is the reverse of planUnblockDependents: once
+// reopenedID is no longer done, draft features that depend on it go back to
+// blocked with a system event. Dependents already active are left alone and
+// reported as warnings.
+func (s *FeatureService) planReblockDependents(reopenedID string, now time.Time) (*featurePlan, error) {
+	all, err := s.workspaceFeatures()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &featurePlan{}
+	for _, f := range all {
+		if !containsID(f.DependsOn, reopenedID) {
+			continue
+		}
+		switch f.Status {
+		case domain.FeatureStatusDraft:
+			f.Status = domain.FeatureStatusBlocked
+			f.UpdatedAt = now
+			plan.features = append(plan.features, f)
+			plan.events = append(plan.events, &domain.FeatureEvent{
+				Layer:    "feature",
+				Type:     "blocked",
+				ID:       f.ID,
+				By:       "system",
+				Ts:       now,
+				Snapshot: f,
+			})
+		case domain.FeatureStatusActive:
+			plan.warnings = append(plan.warnings, fmt.Sprintf("warning: %s is active but its dependency %s is no longer done", f.ID, reopenedID))
+		}
+	}
+	return plan, nil
+}
+
+// extractProjectIDFromFeatureID extracts the project ID from a feature ID
+// Feature ID format: <project>-feature-<nanoid>
+func extractProjectIDFromFeatureID(featureID string) string {
+	parts := strings.Split(featureID, "-feature-")
+	if len(parts) != 2 {
+		return ""
+	}
+	return parts[0]
+}
+
+// isFeatureFinished reports whether status satisfies a dependency. A
+// cancelled feature does not: its dependents stay blocked until they are
+// rewired.
+func isFeatureFinished(status string) bool {
+	return status == domain.FeatureStatusDone
+}
+
+// featurePlan is a set of changes to other features that an update causes.
+// It is worked out before anything is written so a dry run can show it.
+type featurePlan struct {
+	// change is recorded in the causing update's event when the plan
+	// writes anything
+	change   string
+	lines    []string
+	warnings []string
+	features []*domain.Feature
+	events   []*domain.FeatureEvent
+}
+
+// ids returns the IDs of the features the plan changes
+func (p *featurePlan) ids() []string {
+	var ids []string
+	for _, f := range p.features {
+		ids = append(ids, f.ID)
+	}
+	return ids
+}
+
+// workspaceFeatures reads the features of every project
+func (s *FeatureService) workspaceFeatures() ([]*domain.Feature, error) {
+	projects, err := s.reader.ListProjects(false)
+	if err != nil {
+		return nil, err
+	}
+	var all []*domain.Feature
+	for _, projectID := range projects {
+		err := s.reader.ReadNDJSON(s.paths.ProjectFeaturesPath(projectID), func(raw []byte) error {
+			var f domain.Feature
+			if err := json.Unmarshal(raw, &f); err != nil {
+				return err
 			}
-			eventsToAppend = append(eventsToAppend, event)
+			all = append(all, &f)
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
+	return all, nil
+}
 
-	// If we have updates, write them
-	if unblockedAny {
-		if err := s.writer.ReplaceFeatures(projectID, allFeatures, featuresToWrite); err != nil {
-			return false, err
+// planCancelDependents works out, without writing anything, how policy treats
+// the open features in every project that depend on cancelled
+func (s *FeatureService) planCancelDependents(cancelled *domain.Feature, policy cancelPolicy, rewire *string, updater string, now time.Time) (*featurePlan, error) {
+	all, err := s.workspaceFeatures()
+	if err != nil {
+		return nil, err
+	}
+
+	dependentsOf := func(id string) []*domain.Feature {
+		var found []*domain.Feature
+		for _, f := range all {
+			if !isFeatureFinished(f.Status) && f.Status != domain.FeatureStatusCancelled && containsID(f.DependsOn, id) {
+				found = append(found, f)
+			}
 		}
-		for _, event := range eventsToAppend {
-			if err := s.writer.AppendFeatureEvent(projectID, event); err != nil {
-				return false, err
+		return found
+	}
+
+	plan := &featurePlan{}
+	direct := dependentsOf(cancelled.ID)
+	if len(direct) == 0 {
+		return plan, nil
+	}
+
+	systemEvent := func(f *domain.Feature, eventType string) *domain.FeatureEvent {
+		snapshot := *f
+		return &domain.FeatureEvent{Layer: "feature", Type: eventType, ID: f.ID, By: "system", Ts: now, Snapshot: &snapshot}
+	}
+	updatedEvent := func(before, f *domain.Feature, changes []string) *domain.FeatureEvent {
+		snapshot := *f
+		return &domain.FeatureEvent{
+			Layer:    "feature",
+			Type:     "updated",
+			ID:       f.ID,
+			By:       updater,
+			Ts:       now,
+			Changes:  changes,
+			Diff:     domain.DiffFields(*before, f, changes),
+			Snapshot: &snapshot,
+		}
+	}
+
+	switch policy {
+	case cancelPolicyNone:
+		var ids []string
+		for _, f := range direct {
+			ids = append(ids, fmt.Sprintf("%s (%s)", f.ID, f.Status))
+		}
+		return nil, dependentsError("Feature", cancelled.ID, ids)
+
+	case cancelPolicyCascade:
+		plan.change = "dependent_cancelled"
+		seen := map[string]bool{cancelled.ID: true}
+		queue := direct
+		for len(queue) > 0 {
+			f := queue[0]
+			queue = queue[1:]
+			if seen[f.ID] {
+				continue
+			}
+			seen[f.ID] = true
+
+			// Started work is never thrown away; it and its dependents are left alone
+			if f.Status == domain.FeatureStatusActive {
+				plan.warnings = append(plan.warnings, fmt.Sprintf("warning: %s is active; --cascade left it and its dependents alone", f.ID))
+				continue
+			}
+
+			before := *f
+			f.Status = domain.FeatureStatusCancelled
+			f.Reason = cancelled.Reason
+			f.UpdatedAt = now
+			f.UpdatedBy = updater
+			plan.features = append(plan.features, f)
+			plan.events = append(plan.events, updatedEvent(&before, f, []string{"status", "reason"}))
+			plan.lines = append(plan.lines, "cancelled dependent: "+f.ID)
+			queue = append(queue, dependentsOf(f.ID)...)
+		}
+
+	case cancelPolicyRewire:
+		plan.change = "dependent_rewired"
+		replacementID := *rewire
+		if replacementID == cancelled.ID {
+			return nil, domain.NewValidationError("Cannot rewire dependents onto the feature being cancelled.")
+		}
+		for _, f := range direct {
+			if err := s.validateDependencies(f.ProjectID, f.ID, []string{replacementID}); err != nil {
+				return nil, err
+			}
+			before := *f
+			f.DependsOn, _ = applyDependencyChanges(f.DependsOn, nil, &[]string{replacementID}, &[]string{cancelled.ID})
+			f.UpdatedAt = now
+			f.UpdatedBy = updater
+			plan.features = append(plan.features, f)
+			plan.events = append(plan.events, updatedEvent(&before, f, []string{"depends_on"}))
+			plan.lines = append(plan.lines, fmt.Sprintf("rewired dependent: %s (%s -> %s)", f.ID, cancelled.ID, replacementID))
+
+			allDone, err := s.checkDependenciesDone(f.DependsOn)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case f.Status == domain.FeatureStatusBlocked && allDone:
+				f.Status = domain.FeatureStatusDraft
+				plan.events = append(plan.events, systemEvent(f, "unblocked"))
+			case f.Status == domain.FeatureStatusDraft && !allDone:
+				f.Status = domain.FeatureStatusBlocked
+				plan.events = append(plan.events, systemEvent(f, "blocked"))
+			}
+		}
+
+	case cancelPolicyKeepBlocked:
+		plan.change = "dependent_blocked"
+		for _, f := range direct {
+			switch f.Status {
+			case domain.FeatureStatusDraft:
+				f.Status = domain.FeatureStatusBlocked
+				f.UpdatedAt = now
+				plan.features = append(plan.features, f)
+				plan.events = append(plan.events, systemEvent(f, "blocked"))
+				plan.lines = append(plan.lines, "blocked dependent: "+f.ID)
+			case domain.FeatureStatusActive:
+				plan.warnings = append(plan.warnings, fmt.Sprintf("warning: %s is active but its dependency %s is cancelled", f.ID, cancelled.ID))
+			default:
+				plan.lines = append(plan.lines, fmt.Sprintf("kept blocked: %s (%s)", f.ID, f.Status))
 			}
 		}
 	}
 
-	return unblockedAny, nil
+	return plan, nil
 }
 
-func (s *FeatureService) findDependents(projectID, featureID string) ([]string, error) {
-	var dependents []string
-	err := s.reader.ReadNDJSON(s.paths.ProjectFeaturesPath(projectID), func(raw []byte) error {
-		var f domain.Feature
-		if err := json.Unmarshal(raw, &f); err != nil {
-			return err
+// applyPlan writes the features changed by a plan and their events
+func (s *FeatureService) applyPlan(plan *featurePlan) error {
+	var projectIDs []string
+	toWrite := make(map[string]map[string]*domain.Feature)
+	for _, f := range plan.features {
+		if toWrite[f.ProjectID] == nil {
+			toWrite[f.ProjectID] = make(map[string]*domain.Feature)
+			projectIDs = append(projectIDs, f.ProjectID)
 		}
-		for _, dep := range f.DependsOn {
-			if dep == featureID {
-				dependents = append(dependents, f.ID)
+		toWrite[f.ProjectID][f.ID] = f
+	}
+
+	for _, projectID := range projectIDs {
+		var all []*domain.Feature
+		err := s.reader.ReadNDJSON(s.paths.ProjectFeaturesPath(projectID), func(raw []byte) error {
+			var f domain.Feature
+			if err := json.Unmarshal(raw, &f); err != nil {
+				return err
 			}
+			all = append(all, &f)
+			return nil
+		})
+		if err != nil {
+			return err
 		}
-		return nil
-	})
-	return dependents, err
+		if err := s.writer.ReplaceFeatures(projectID, all, toWrite[projectID]); err != nil {
+			return err
+		}
+	}
+
+	for _, event := range plan.events {
+		if err := s.writer.AppendFeatureEvent(event.Snapshot.ProjectID, event); err != nil {
+			return err
+		}
+	}
+	return nil
 }
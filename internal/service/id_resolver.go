@@ -0,0 +1,139 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// maxIDCandidates caps the candidates listed for an ambiguous short ID
+const maxIDCandidates = 10
+
+// idResolver expands short ID references (a unique suffix of a full feature,
+// task or issue ID, e.g. "Xy9z", or a prefix ending at a "-") to the full ID.
+type idResolver struct {
+	reader *fs.Reader
+	paths  *fs.Paths
+}
+
+// idLayers maps a layer to the marker found in its full IDs and its file
+var idLayers = map[string]struct {
+	marker string
+	file   func(p *fs.Paths, projectID string) string
+}{
+	"feature": {"-feature-", (*fs.Paths).ProjectFeaturesPath},
+	"task":    {"-task-", (*fs.Paths).ProjectTasksPath},
+	"issue":   {"-issue-", (*fs.Paths).ProjectIssuesPath},
+}
+
+// resolve returns the full ID that ref refers to. Full IDs are returned
+// unchanged, as is a ref matching nothing, so callers report "not found" in
+// their usual way. projectID narrows the search; empty searches every project.
+func (r idResolver) resolve(layer, projectID, ref string) (string, error) {
+	// A feature ID is also a prefix of its tasks' IDs; never let one stand in
+	// for the other
+	if other := idLayerOf(ref); other != "" && other != layer {
+		return "", domain.NewValidationError(fmt.Sprintf("%s is %s %s ID, not %s %s ID.", ref, article(other), other, article(layer), layer))
+	}
+
+	info := idLayers[layer]
+	if ref == "" || strings.Contains(ref, info.marker) {
+		return ref, nil
+	}
+
+	projectIDs := []string{projectID}
+	if projectID == "" {
+		var err error
+		projectIDs, err = r.reader.ListProjects(true)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	seen := make(map[string]bool)
+	var candidates []string
+	for _, pid := range projectIDs {
+		err := r.reader.ReadNDJSON(info.file(r.paths, pid), func(raw []byte) error {
+			var entity struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &entity); err != nil {
+				return err
+			}
+			id := entity.ID
+			if !seen[id] && (strings.HasSuffix(id, ref) || hasSegmentPrefix(id, ref)) {
+				seen[id] = true
+				candidates = append(candidates, id)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return ref, nil
+	case 1:
+		return candidates[0], nil
+	}
+
+	sort.Strings(candidates)
+	listed := candidates
+	if len(listed) > maxIDCandidates {
+		listed = listed[:maxIDCandidates]
+	}
+	msg := fmt.Sprintf("Ambiguous %s ID %q matches %d %ss:\n  %s", layer, ref, len(candidates), layer, strings.Join(listed, "\n  "))
+	if len(candidates) > len(listed) {
+		msg += fmt.Sprintf("\n  ... and %d more", len(candidates)-len(listed))
+	}
+	return "", domain.NewValidationError(msg + "\nUse a longer ID.")
+}
+
+// idLayerOf returns the layer whose marker ref carries, or "" for a short ref.
+// Task IDs embed their feature's marker, so "-task-" is checked first.
+func idLayerOf(ref string) string {
+	for _, layer := range []string{"task", "issue", "feature"} {
+		if strings.Contains(ref, idLayers[layer].marker) {
+			return layer
+		}
+	}
+	return ""
+}
+
+// hasSegmentPrefix reports whether ref is a prefix of id that ends where one
+// of id's "-" separated segments ends
+func hasSegmentPrefix(id, ref string) bool {
+	if !strings.HasPrefix(id, ref) {
+		return false
+	}
+	return strings.HasSuffix(ref, "-") || len(id) == len(ref) || id[len(ref)] == '-'
+}
+
+func article(layer string) string {
+	if layer == "issue" {
+		return "an"
+	}
+	return "a"
+}
+
+// resolveAll resolves every ref in refs
+func (r idResolver) resolveAll(layer, projectID string, refs []string) ([]string, error) {
+	if refs == nil {
+		return nil, nil
+	}
+	resolved := make([]string, len(refs))
+	for i, ref := range refs {
+		id, err := r.resolve(layer, projectID, ref)
+		if err != nil {
+			return nil, err
+		}
+		resolved[i] = id
+	}
+	return resolved, nil
+}
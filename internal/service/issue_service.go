@@ -41,6 +41,17 @@ func (s *IssueService) WorkspaceInitialized() bool {
 	return s.reader.WorkspaceExists()
 }
 
+// ResolveIssueID expands a unique prefix or suffix of an issue ID to the full
+// ID. projectID narrows the search; empty searches every project.
+func (s *IssueService) ResolveIssueID(projectID, ref string) (string, error) {
+	return idResolver{s.reader, s.paths}.resolve("issue", projectID, ref)
+}
+
+// ResolveIssueIDs resolves each short issue ID in refs across all projects
+func (s *IssueService) ResolveIssueIDs(refs []string) ([]string, error) {
+	return idResolver{s.reader, s.paths}.resolveAll("issue", "", refs)
+}
+
 func (s *IssueService) ValidateCreateInput(input *domain.IssueCreateInput) error {
 	if !s.reader.ProjectExists(input.ProjectID) {
 		return domain.NewValidationError("Project not found: " + input.ProjectID)
@@ -82,19 +93,21 @@ func (s *IssueService) ValidateCreateInput(input *domain.IssueCreateInput) error
 		return domain.NewValidationError("Implementation steps are required (--implementation-steps).")
 	}
 
-	// Apply default priority if not specified
+	// Apply the project's default priority if not specified
+	priorities, err := projectPriorityScheme(s.reader, input.ProjectID)
+	if err != nil {
+		return err
+	}
 	if input.Priority == "" {
-		// Use default priority from workspace config, fallback to P2 for issues
-		ws, err := s.reader.ReadWorkspace()
-		if err == nil && ws.Config.DefaultPriority != "" {
-			input.Priority = ws.Config.DefaultPriority
-		} else {
-			input.Priority = "P2"
-		}
+		input.Priority = priorities.DefaultFor(s.reader, "P2")
+	}
+
+	if err := priorities.Validate(input.Priority); err != nil {
+		return err
 	}
 
-	if !domain.ValidatePriority(input.Priority) {
-		return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+	if err := validateProjectLabels(s.reader, input.ProjectID, input.Labels); err != nil {
+		return err
 	}
 
 	if err := s.validateDependencies(input.ProjectID, "", input.DependsOn); err != nil {
@@ -149,42 +162,32 @@ func (s *IssueService) validateDependencies(projectID, selfID string, dependsOn
 }
 
 func (s *IssueService) validateNoCycle(projectID, selfID string, dependsOn []string) error {
-	visited := make(map[string]bool)
-	var dfs func(issueID string) bool
-
-	dfs = func(issueID string) bool {
-		if issueID == selfID {
-			return true
-		}
-		if visited[issueID] {
-			return false
-		}
-		visited[issueID] = true
-
-		i, err := s.reader.ReadIssue(projectID, issueID)
-		if err != nil {
-			return false
-		}
-
-		for _, dep := range i.DependsOn {
-			if dfs(dep) {
-				return true
-			}
-		}
-		return false
+	allowed, err := cycleAllowed(s.reader, projectID, "issue")
+	if err != nil || allowed {
+		return err
 	}
 
-	for _, depID := range dependsOn {
-		visited = make(map[string]bool)
-		if dfs(depID) {
-			return domain.NewValidationError("Circular dependency detected.")
+	path := findCyclePath(selfID, dependsOn, func(issueID string) []string {
+		i, err := s.reader.ReadIssue(extractProjectIDFromIssueID(issueID), issueID)
+		if err != nil {
+			return nil
 		}
+		return i.DependsOn
+	})
+	if path != nil {
+		return cycleError(path)
 	}
 
 	return nil
 }
 
 func (s *IssueService) CreateIssue(input *domain.IssueCreateInput) (*domain.Issue, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	creator := util.GetGitUsername()
 	now := time.Now().UTC()
 
@@ -208,10 +211,12 @@ func (s *IssueService) CreateIssue(input *domain.IssueCreateInput) (*domain.Issu
 		AffectedTests:       input.AffectedTests,
 		ImplementationSteps: input.ImplementationSteps,
 		LibraryNeeds:        input.LibraryNeeds,
+		Assignee:            strings.TrimSpace(input.Assignee),
+		Labels:              input.Labels,
 		CreatedAt:           now,
-		LastUpdatedAt:       now,
+		UpdatedAt:           now,
 		CreatedBy:           creator,
-		LastUpdatedBy:       creator,
+		UpdatedBy:           creator,
 	}
 
 	if len(input.DependsOn) > 0 {
@@ -233,11 +238,12 @@ func (s *IssueService) CreateIssue(input *domain.IssueCreateInput) (*domain.Issu
 	}
 
 	event := &domain.IssueEvent{
-		Layer: "issue",
-		Type:  "created",
-		ID:    issueID,
-		By:    creator,
-		Ts:    now,
+		Layer:    "issue",
+		Type:     "created",
+		ID:       issueID,
+		By:       creator,
+		Ts:       now,
+		Snapshot: issue,
 	}
 	if err := s.writer.AppendIssueEvent(input.ProjectID, event); err != nil {
 		return nil, err
@@ -245,11 +251,12 @@ func (s *IssueService) CreateIssue(input *domain.IssueCreateInput) (*domain.Issu
 
 	if issue.Status == domain.IssueStatusReady {
 		readyEvent := &domain.IssueEvent{
-			Layer: "issue",
-			Type:  "ready",
-			ID:    issueID,
-			By:    "system",
-			Ts:    now,
+			Layer:    "issue",
+			Type:     "ready",
+			ID:       issueID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: issue,
 		}
 		if err := s.writer.AppendIssueEvent(input.ProjectID, readyEvent); err != nil {
 			return nil, err
@@ -258,11 +265,12 @@ func (s *IssueService) CreateIssue(input *domain.IssueCreateInput) (*domain.Issu
 
 	if issue.Status == domain.IssueStatusBlocked {
 		blockedEvent := &domain.IssueEvent{
-			Layer: "issue",
-			Type:  "blocked",
-			ID:    issueID,
-			By:    "system",
-			Ts:    now,
+			Layer:    "issue",
+			Type:     "blocked",
+			ID:       issueID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: issue,
 		}
 		if err := s.writer.AppendIssueEvent(input.ProjectID, blockedEvent); err != nil {
 			return nil, err
@@ -274,7 +282,11 @@ func (s *IssueService) CreateIssue(input *domain.IssueCreateInput) (*domain.Issu
 
 func (s *IssueService) checkDependenciesResolved(projectID string, dependsOn []string) (bool, error) {
 	for _, depID := range dependsOn {
-		dep, err := s.reader.ReadIssue(projectID, depID)
+		depProjectID := extractProjectIDFromIssueID(depID)
+		if depProjectID == "" {
+			depProjectID = projectID
+		}
+		dep, err := s.reader.ReadIssue(depProjectID, depID)
 		if err != nil {
 			return false, domain.NewValidationError("Dependency not found: " + depID)
 		}
@@ -285,11 +297,22 @@ func (s *IssueService) checkDependenciesResolved(projectID string, dependsOn []s
 	return true, nil
 }
 
+// PriorityScheme returns the priority levels configured for projectID
+func (s *IssueService) PriorityScheme(projectID string) (*PriorityScheme, error) {
+	return projectPriorityScheme(s.reader, projectID)
+}
+
 func (s *IssueService) ListIssues(input *domain.IssueListInput) (*domain.IssueListOutput, error) {
 	if !s.reader.ProjectExists(input.ProjectID) {
 		return nil, domain.NewValidationError("Project not found: " + input.ProjectID)
 	}
 
+	if input.Priority != "" {
+		if err := validateProjectPriority(s.reader, input.ProjectID, input.Priority); err != nil {
+			return nil, err
+		}
+	}
+
 	var issues []domain.IssueListItem
 	deletedCount := 0
 
@@ -314,6 +337,14 @@ func (s *IssueService) ListIssues(input *domain.IssueListInput) (*domain.IssueLi
 			return nil
 		}
 
+		if input.Assignee != "" && i.Assignee != input.Assignee {
+			return nil
+		}
+
+		if !matchLabels(i.Labels, input.Labels, input.LabelMatch) {
+			return nil
+		}
+
 		item := domain.IssueListItem{
 			ID:                       i.ID,
 			Name:                     i.Name,
@@ -326,8 +357,10 @@ func (s *IssueService) ListIssues(input *domain.IssueListInput) (*domain.IssueLi
 			AffectedTestsCount:       len(i.AffectedTests),
 			ImplementationStepsCount: len(i.ImplementationSteps),
 			LibraryNeedsCount:        len(i.LibraryNeeds),
+			Assignee:                 i.Assignee,
+			Labels:                   i.Labels,
 			CreatedAt:                i.CreatedAt.Format(time.RFC3339),
-			LastUpdatedAt:            i.LastUpdatedAt.Format(time.RFC3339),
+			LastUpdatedAt:            i.UpdatedAt.Format(time.RFC3339),
 		}
 		issues = append(issues, item)
 
@@ -360,6 +393,11 @@ func (s *IssueService) GetIssueDetail(input *domain.IssueDetailInput) (*domain.I
 
 	events, _ := s.reader.CountEventLines(input.ProjectID)
 
+	comments, commentCount, err := entityComments(s.reader, s.paths, input.ProjectID, issue.ID, input.LastComments)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.IssueDetailOutput{
 		ID:                  issue.ID,
 		ProjectID:           issue.ProjectID,
@@ -374,11 +412,15 @@ func (s *IssueService) GetIssueDetail(input *domain.IssueDetailInput) (*domain.I
 		AffectedTests:       issue.AffectedTests,
 		ImplementationSteps: issue.ImplementationSteps,
 		LibraryNeeds:        issue.LibraryNeeds,
+		Assignee:            issue.Assignee,
+		Labels:              issue.Labels,
 		Events:              events,
+		CommentCount:        commentCount,
+		Comments:            comments,
 		CreatedAt:           issue.CreatedAt.Format(time.RFC3339),
-		LastUpdatedAt:       issue.LastUpdatedAt.Format(time.RFC3339),
+		LastUpdatedAt:       issue.UpdatedAt.Format(time.RFC3339),
 		CreatedBy:           issue.CreatedBy,
-		LastUpdatedBy:       issue.LastUpdatedBy,
+		LastUpdatedBy:       issue.UpdatedBy,
 	}, nil
 }
 
@@ -404,16 +446,18 @@ func (s *IssueService) ValidateUpdateInput(input *domain.IssueUpdateInput) error
 		return domain.NewValidationError("Invalid issue type. Valid types: bug, improvement, debt, security, performance")
 	}
 
-	if input.Priority != nil && !domain.ValidatePriority(*input.Priority) {
-		return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+	if input.Priority != nil {
+		if err := validateProjectPriority(s.reader, input.ProjectID, *input.Priority); err != nil {
+			return err
+		}
 	}
 
 	if input.Status != nil && !domain.ValidateIssueStatus(*input.Status) {
 		return domain.NewValidationError("Invalid status. Valid options: open, ready, in_progress, blocked, resolved, wontfix, cancelled")
 	}
 
-	if input.DependsOn != nil {
-		if err := s.validateDependencies(input.ProjectID, input.IssueID, *input.DependsOn); err != nil {
+	if deps, changed := applyDependencyChanges(issue.DependsOn, input.DependsOn, input.DependsAdd, input.DependsRemove); changed {
+		if err := s.validateDependencies(input.ProjectID, input.IssueID, addedDependencies(issue.DependsOn, deps)); err != nil {
 			return err
 		}
 	}
@@ -422,15 +466,36 @@ func (s *IssueService) ValidateUpdateInput(input *domain.IssueUpdateInput) error
 }
 
 func (s *IssueService) UpdateIssue(input *domain.IssueUpdateInput) ([]string, error) {
-	issue, err := s.reader.ReadIssue(input.ProjectID, input.IssueID)
+	return s.updateIssue(input, nil)
+}
+
+// PreviewIssueUpdate runs input through the whole update in memory and
+// returns what it would change, without writing anything
+func (s *IssueService) PreviewIssueUpdate(input *domain.IssueUpdateInput) (*domain.UpdatePreview, error) {
+	dryRun := *input
+	dryRun.DryRun = true
+	preview := &domain.UpdatePreview{}
+	if _, err := s.updateIssue(&dryRun, preview); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// updateIssue applies input. On a dry run it stops before the first write and
+// fills preview, when given, with the computed change set.
+func (s *IssueService) updateIssue(input *domain.IssueUpdateInput, preview *domain.UpdatePreview) ([]string, error) {
+	unlock, err := s.writer.Lock()
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
 
-	if input.DryRun {
-		return []string{"[DRY RUN] Would update issue: " + input.IssueID}, nil
+	issue, err := s.reader.ReadIssue(input.ProjectID, input.IssueID)
+	if err != nil {
+		return nil, err
 	}
 
+	before := *issue
 	var changes []string
 	updater := util.GetGitUsername()
 	now := time.Now().UTC()
@@ -478,8 +543,26 @@ func (s *IssueService) UpdateIssue(input *domain.IssueUpdateInput) ([]string, er
 		changes = append(changes, "priority")
 	}
 
-	if input.DependsOn != nil {
-		issue.DependsOn = *input.DependsOn
+	if input.Assignee != nil && strings.TrimSpace(*input.Assignee) != issue.Assignee {
+		issue.Assignee = strings.TrimSpace(*input.Assignee)
+		changes = append(changes, "assignee")
+	}
+
+	labels, labelsChanged := applyLabelChanges(issue.Labels, input.Labels, input.LabelsAdd, input.LabelsRemove)
+	if labelsChanged {
+		if err := validateProjectLabels(s.reader, input.ProjectID, addedDependencies(issue.Labels, labels)); err != nil {
+			return nil, err
+		}
+		issue.Labels = labels
+		changes = append(changes, "labels")
+	}
+
+	deps, depsChanged := applyDependencyChanges(issue.DependsOn, input.DependsOn, input.DependsAdd, input.DependsRemove)
+	if depsChanged {
+		if err := s.validateDependencies(input.ProjectID, input.IssueID, addedDependencies(issue.DependsOn, deps)); err != nil {
+			return nil, err
+		}
+		issue.DependsOn = deps
 		changes = append(changes, "depends_on")
 	}
 
@@ -539,36 +622,140 @@ func (s *IssueService) UpdateIssue(input *domain.IssueUpdateInput) ([]string, er
 		changes = append(changes, "status")
 	}
 
-	issue.LastUpdatedAt = now
-	issue.LastUpdatedBy = updater
-
-	if err := s.writer.ReplaceIssue(input.ProjectID, issue); err != nil {
+	if input.Priority != nil {
+		if err := validateProjectPriority(s.reader, input.ProjectID, issue.Priority); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkStrictIssue(input.ProjectID, &before, issue); err != nil {
 		return nil, err
 	}
 
-	if input.Resolve || input.WontFix {
-		unblocked, err := s.unblockDependents(input.ProjectID, issue.ID)
+	// A dependency change can block an open issue or release a blocked one
+	recomputed := ""
+	if depsChanged && input.Status == nil && !input.Start && !input.Resolve && !input.WontFix && !input.Cancel && !input.Reopen {
+		recomputed, err = s.recomputeBlockedStatus(issue)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unblockPlan := &issuePlan{}
+	if isIssueFinished(issue.Status) && !isIssueFinished(before.Status) {
+		unblockPlan, err = s.planUnblockDependents(issue.ID, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reblockPlan := &issuePlan{}
+	if isIssueFinished(before.Status) && !isIssueFinished(issue.Status) {
+		reblockPlan, err = s.planReblockDependents(issue.ID, now)
 		if err != nil {
 			return nil, err
 		}
-		if unblocked {
-			changes = append(changes, "dependent_unblocked")
+	}
+
+	if input.DryRun {
+		diffFields := changes
+		if recomputed != "" {
+			diffFields = append(diffFields, "status")
 		}
+		p := &domain.UpdatePreview{
+			Layer:      "issue",
+			ID:         input.IssueID,
+			Changes:    changes,
+			Diff:       domain.DiffFields(before, issue, diffFields),
+			StatusFrom: before.Status,
+			StatusTo:   issue.Status,
+			Unblocked:  unblockPlan.ids(),
+			Reblocked:  reblockPlan.ids(),
+			Warnings:   reblockPlan.warnings,
+		}
+		if preview != nil {
+			*preview = *p
+		}
+		return p.Lines(), nil
+	}
+
+	issue.UpdatedAt = now
+	issue.UpdatedBy = updater
+
+	if err := s.writer.ReplaceIssue(input.ProjectID, issue); err != nil {
+		return nil, err
+	}
+
+	if len(unblockPlan.issues) > 0 {
+		changes = append(changes, "dependent_unblocked")
+	}
+	if len(reblockPlan.issues) > 0 {
+		changes = append(changes, "dependent_blocked")
 	}
+	warnings := reblockPlan.warnings
 
 	event := &domain.IssueEvent{
-		Layer:   "issue",
-		Type:    "updated",
-		ID:      input.IssueID,
-		By:      updater,
-		Ts:      now,
-		Changes: changes,
+		Layer:    "issue",
+		Type:     "updated",
+		ID:       input.IssueID,
+		By:       updater,
+		Ts:       now,
+		Changes:  changes,
+		Diff:     domain.DiffFields(before, issue, changes),
+		Snapshot: issue,
 	}
 	if err := s.writer.AppendIssueEvent(input.ProjectID, event); err != nil {
 		return nil, err
 	}
 
-	return changes, nil
+	if recomputed != "" {
+		statusEvent := &domain.IssueEvent{
+			Layer:    "issue",
+			Type:     recomputed,
+			ID:       input.IssueID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: issue,
+		}
+		if err := s.writer.AppendIssueEvent(input.ProjectID, statusEvent); err != nil {
+			return nil, err
+		}
+		changes = append(changes, "status_"+recomputed)
+	}
+
+	// Dependents are written after the issue's own events
+	for _, plan := range []*issuePlan{unblockPlan, reblockPlan} {
+		if err := s.applyPlan(plan); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(changes, warnings...), nil
+}
+
+// recomputeBlockedStatus moves an open or ready issue with an unresolved
+// dependency to blocked, and a blocked issue whose dependencies are all
+// resolved to ready. It returns the new status, or "" when it did not change.
+func (s *IssueService) recomputeBlockedStatus(issue *domain.Issue) (string, error) {
+	switch issue.Status {
+	case domain.IssueStatusOpen, domain.IssueStatusReady, domain.IssueStatusBlocked:
+	default:
+		return "", nil
+	}
+
+	allResolved, err := s.checkDependenciesResolved(issue.ProjectID, issue.DependsOn)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case issue.Status != domain.IssueStatusBlocked && !allResolved:
+		issue.Status = domain.IssueStatusBlocked
+	case issue.Status == domain.IssueStatusBlocked && allResolved:
+		issue.Status = domain.IssueStatusReady
+	default:
+		return "", nil
+	}
+	return issue.Status, nil
 }
 
 func (s *IssueService) validateStatusTransition(current, next string) error {
@@ -637,84 +824,167 @@ func (s *IssueService) GetIssueEvents(projectID, issueID string) ([]domain.Issue
 	return events, err
 }
 
-func (s *IssueService) unblockDependents(projectID, resolvedIssueID string) (bool, error) {
-	unblockedAny := false
-	now := time.Now().UTC()
+// issuePlan is a set of changes to other issues that an update causes. It is
+// worked out before anything is written so a dry run can show it.
+type issuePlan struct {
+	warnings []string
+	issues   []*domain.Issue
+	events   []*domain.IssueEvent
+}
 
-	// Load all issues into memory first
-	var allIssues []*domain.Issue
-	err := s.reader.ReadNDJSON(s.paths.ProjectIssuesPath(projectID), func(raw []byte) error {
-		var issue domain.Issue
-		if err := json.Unmarshal(raw, &issue); err != nil {
-			return err
-		}
-		allIssues = append(allIssues, &issue)
-		return nil
-	})
-	if err != nil {
-		return false, err
+// ids returns the IDs of the issues the plan changes
+func (p *issuePlan) ids() []string {
+	var ids []string
+	for _, i := range p.issues {
+		ids = append(ids, i.ID)
 	}
+	return ids
+}
 
-	// Track which issues need to be written back
-	issuesToWrite := make(map[string]*domain.Issue)
-	eventsToAppend := []*domain.IssueEvent{}
+// workspaceIssues reads the issues of every project
+func (s *IssueService) workspaceIssues() ([]*domain.Issue, error) {
+	projects, err := s.reader.ListProjects(false)
+	if err != nil {
+		return nil, err
+	}
+	var all []*domain.Issue
+	for _, projectID := range projects {
+		err := s.reader.ReadNDJSON(s.paths.ProjectIssuesPath(projectID), func(raw []byte) error {
+			var i domain.Issue
+			if err := json.Unmarshal(raw, &i); err != nil {
+				return err
+			}
+			all = append(all, &i)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return all, nil
+}
 
-	// Process all issues to find those that should unblock
-	for _, issue := range allIssues {
-		if issue.Status != domain.IssueStatusBlocked {
-			continue
+// applyPlan writes the issues changed by a plan and their events
+func (s *IssueService) applyPlan(plan *issuePlan) error {
+	var projectIDs []string
+	toWrite := make(map[string]map[string]*domain.Issue)
+	for _, i := range plan.issues {
+		if toWrite[i.ProjectID] == nil {
+			toWrite[i.ProjectID] = make(map[string]*domain.Issue)
+			projectIDs = append(projectIDs, i.ProjectID)
 		}
+		toWrite[i.ProjectID][i.ID] = i
+	}
 
-		hasResolved := false
-		allResolved := true
-		for _, depID := range issue.DependsOn {
-			if depID == resolvedIssueID {
-				hasResolved = true
-			}
-			// Parse the dependency ID to get the project it belongs to
-			depProjectID := extractProjectIDFromIssueID(depID)
-			if depProjectID == "" {
-				return false, domain.NewValidationError("Invalid issue ID format: " + depID)
-			}
-			dep, err := s.reader.ReadIssue(depProjectID, depID)
-			if err != nil {
-				return false, err
-			}
-			if dep.Status != domain.IssueStatusResolved && dep.Status != domain.IssueStatusWontFix {
-				allResolved = false
+	for _, projectID := range projectIDs {
+		var all []*domain.Issue
+		err := s.reader.ReadNDJSON(s.paths.ProjectIssuesPath(projectID), func(raw []byte) error {
+			var i domain.Issue
+			if err := json.Unmarshal(raw, &i); err != nil {
+				return err
 			}
+			all = append(all, &i)
+			return nil
+		})
+		if err != nil {
+			return err
 		}
+		if err := s.writer.ReplaceIssues(projectID, all, toWrite[projectID]); err != nil {
+			return err
+		}
+	}
 
-		if hasResolved && allResolved {
-			issue.Status = domain.IssueStatusReady
-			issue.LastUpdatedAt = now
-			issuesToWrite[issue.ID] = issue
-			unblockedAny = true
-
-			event := &domain.IssueEvent{
-				Layer: "issue",
-				Type:  "ready",
-				ID:    issue.ID,
-				By:    "system",
-				Ts:    now,
-			}
-			eventsToAppend = append(eventsToAppend, event)
+	for _, event := range plan.events {
+		if err := s.writer.AppendIssueEvent(event.Snapshot.ProjectID, event); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	// If we have updates, write them
-	if unblockedAny {
-		if err := s.writer.ReplaceIssues(projectID, allIssues, issuesToWrite); err != nil {
-			return false, err
+// planUnblockDependents works out which blocked issues, in any project, become
+// ready once resolvedIssueID is resolved. resolvedIssueID counts as resolved
+// even before it is written so the plan can be shown on a dry run.
+func (s *IssueService) planUnblockDependents(resolvedIssueID string, now time.Time) (*issuePlan, error) {
+	all, err := s.workspaceIssues()
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]string, len(all))
+	for _, i := range all {
+		status[i.ID] = i.Status
+	}
+	status[resolvedIssueID] = domain.IssueStatusResolved
+
+	plan := &issuePlan{}
+	for _, i := range all {
+		if i.Status != domain.IssueStatusBlocked || !containsID(i.DependsOn, resolvedIssueID) {
+			continue
 		}
-		for _, event := range eventsToAppend {
-			if err := s.writer.AppendIssueEvent(projectID, event); err != nil {
-				return false, err
+		allResolved := true
+		for _, depID := range i.DependsOn {
+			if !isIssueFinished(status[depID]) {
+				allResolved = false
+				break
 			}
 		}
+		if !allResolved {
+			continue
+		}
+
+		i.Status = domain.IssueStatusReady
+		i.UpdatedAt = now
+		plan.issues = append(plan.issues, i)
+		plan.events = append(plan.events, &domain.IssueEvent{
+			Layer:    "issue",
+			Type:     "ready",
+			ID:       i.ID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: i,
+		})
 	}
+	return plan, nil
+}
+
+// planReblockDependents is the reverse of planUnblockDependents: once
+// reopenedID is no longer resolved, open or ready issues that depend on it go
+// back to blocked with a system event. Dependents already in progress are left
+// alone and reported as warnings.
+func (s *IssueService) planReblockDependents(reopenedID string, now time.Time) (*issuePlan, error) {
+	all, err := s.workspaceIssues()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &issuePlan{}
+	for _, i := range all {
+		if !containsID(i.DependsOn, reopenedID) {
+			continue
+		}
+		switch i.Status {
+		case domain.IssueStatusOpen, domain.IssueStatusReady:
+			i.Status = domain.IssueStatusBlocked
+			i.UpdatedAt = now
+			plan.issues = append(plan.issues, i)
+			plan.events = append(plan.events, &domain.IssueEvent{
+				Layer:    "issue",
+				Type:     "blocked",
+				ID:       i.ID,
+				By:       "system",
+				Ts:       now,
+				Snapshot: i,
+			})
+		case domain.IssueStatusInProgress:
+			plan.warnings = append(plan.warnings, fmt.Sprintf("warning: %s is in_progress but its dependency %s is no longer resolved", i.ID, reopenedID))
+		}
+	}
+	return plan, nil
+}
 
-	return unblockedAny, nil
+// isIssueFinished reports whether status satisfies a dependency
+func isIssueFinished(status string) bool {
+	return status == domain.IssueStatusResolved || status == domain.IssueStatusWontFix
 }
 
 // extractProjectIDFromIssueID extracts the project ID from an issue ID
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// Label filter modes for list commands
+const (
+	LabelMatchAll = "all"
+	LabelMatchAny = "any"
+)
+
+// ParseLabels splits comma separated label lists, trims every label and drops
+// duplicates. Labels cannot contain whitespace.
+func ParseLabels(values ...string) ([]string, error) {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			label := strings.TrimSpace(part)
+			if label == "" {
+				continue
+			}
+			if strings.IndexFunc(label, unicode.IsSpace) >= 0 {
+				return nil, domain.NewValidationError(fmt.Sprintf("Invalid label: '%s'. Labels cannot contain spaces.", label))
+			}
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	return labels, nil
+}
+
+// ValidateLabelMatch checks the --label-match value of a list command
+func ValidateLabelMatch(match string) error {
+	if match == "" || match == LabelMatchAll || match == LabelMatchAny {
+		return nil
+	}
+	return domain.NewValidationError(fmt.Sprintf("Invalid label match: '%s'. Valid options: all, any", match))
+}
+
+// matchLabels reports whether have satisfies the filter: every wanted label
+// for "all", at least one for "any". An empty filter matches everything.
+func matchLabels(have, want []string, match string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, label := range want {
+		found := containsID(have, label)
+		if match == LabelMatchAny && found {
+			return true
+		}
+		if match != LabelMatchAny && !found {
+			return false
+		}
+	}
+	return match != LabelMatchAny
+}
+
+// applyLabelChanges applies --labels, --labels-add and --labels-remove to
+// current, with the same semantics as the dependency flags
+func applyLabelChanges(current []string, set, add, remove *[]string) ([]string, bool) {
+	return applyDependencyChanges(current, set, add, remove)
+}
+
+// projectAllowedLabels reads rules.labels.allowed from the project's
+// schema.json. Projects without a schema.json allow any label.
+func projectAllowedLabels(reader *fs.Reader, projectID string) ([]string, error) {
+	schema, err := reader.ReadProjectSchema(projectID)
+	if err != nil {
+		var mErr *domain.MandorError
+		if errors.As(err, &mErr) && mErr.Code == domain.ExitValidationError {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return schema.Rules.Labels.Allowed, nil
+}
+
+// validateProjectLabels rejects labels that projectID's schema does not allow
+func validateProjectLabels(reader *fs.Reader, projectID string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	allowed, err := projectAllowedLabels(reader, projectID)
+	if err != nil || len(allowed) == 0 {
+		return err
+	}
+	for _, label := range labels {
+		if !containsID(allowed, label) {
+			return domain.NewValidationError(fmt.Sprintf(
+				"Label '%s' is not allowed in project %s. Allowed labels: %s", label, projectID, strings.Join(allowed, ", "),
+			))
+		}
+	}
+	return nil
+}
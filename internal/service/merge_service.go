@@ -0,0 +1,414 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// MergeDriverName is the git merge driver name written to .gitattributes
+const MergeDriverName = "mandor"
+
+// mergeAttributesLine routes every Mandor JSONL file through the driver
+const mergeAttributesLine = ".mandor/**/*.jsonl merge=" + MergeDriverName
+
+// MergeService merges concurrent edits of .mandor JSONL files for git
+type MergeService struct {
+	paths *fs.Paths
+}
+
+// NewMergeService creates a new merge service
+func NewMergeService() (*MergeService, error) {
+	paths, err := fs.NewPaths()
+	if err != nil {
+		return nil, err
+	}
+	return NewMergeServiceWithPaths(paths), nil
+}
+
+// NewMergeServiceWithPaths creates a merge service rooted at paths
+func NewMergeServiceWithPaths(paths *fs.Paths) *MergeService {
+	return &MergeService{paths: paths}
+}
+
+// MergeFiles implements the git merge driver contract: base (%O), ours (%A)
+// and theirs (%B) are read, and the merged result is written back to ours.
+func (s *MergeService) MergeFiles(basePath, oursPath, theirsPath string) error {
+	base, err := os.ReadFile(basePath)
+	if err != nil && !os.IsNotExist(err) {
+		return domain.NewSystemError("Cannot read merge base", err)
+	}
+	ours, err := os.ReadFile(oursPath)
+	if err != nil {
+		return domain.NewSystemError("Cannot read current version", err)
+	}
+	theirs, err := os.ReadFile(theirsPath)
+	if err != nil {
+		return domain.NewSystemError("Cannot read other version", err)
+	}
+
+	merged, err := MergeJSONL(base, ours, theirs)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(oursPath)
+	if err != nil {
+		return domain.NewSystemError("Cannot stat current version", err)
+	}
+	if err := os.WriteFile(oursPath, merged, info.Mode().Perm()); err != nil {
+		return domain.NewSystemError("Cannot write merge result", err)
+	}
+	return nil
+}
+
+// MergeDriverInstall describes what InstallMergeDriver changed
+type MergeDriverInstall struct {
+	Attributes        string `json:"attributes"`
+	AttributesUpdated bool   `json:"attributes_updated"`
+	Driver            string `json:"driver"`
+}
+
+// InstallMergeDriver registers the driver in .gitattributes at the workspace
+// root and in the repository's git config.
+func (s *MergeService) InstallMergeDriver() (*MergeDriverInstall, error) {
+	root := s.paths.WorkspaceRoot
+	if _, err := runGit(root, "rev-parse", "--git-dir"); err != nil {
+		return nil, domain.NewValidationError("Not a git repository: " + root)
+	}
+
+	result := &MergeDriverInstall{
+		Attributes: filepath.Join(root, ".gitattributes"),
+		Driver:     "mandor merge-driver %O %A %B",
+	}
+
+	existing, err := os.ReadFile(result.Attributes)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, domain.NewSystemError("Cannot read .gitattributes", err)
+	}
+	if !containsLine(string(existing), mergeAttributesLine) {
+		content := string(existing)
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		content += mergeAttributesLine + "\n"
+		if err := os.WriteFile(result.Attributes, []byte(content), 0644); err != nil {
+			if os.IsPermission(err) {
+				return nil, domain.NewPermissionError("Permission denied. Cannot write .gitattributes.")
+			}
+			return nil, domain.NewSystemError("Cannot write .gitattributes", err)
+		}
+		result.AttributesUpdated = true
+	}
+
+	settings := [][2]string{
+		{"merge." + MergeDriverName + ".name", "Mandor entity-aware JSONL merge"},
+		{"merge." + MergeDriverName + ".driver", result.Driver},
+	}
+	for _, setting := range settings {
+		if out, err := runGit(root, "config", setting[0], setting[1]); err != nil {
+			return nil, domain.NewSystemError("Cannot set git config "+setting[0]+": "+strings.TrimSpace(out), err)
+		}
+	}
+
+	return result, nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func containsLine(content, line string) bool {
+	for _, l := range strings.Split(content, "\n") {
+		if strings.TrimSpace(l) == line {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeJSONL performs a three-way merge of a Mandor JSONL file. Event logs
+// (objects carrying "layer" and "ts") are union-merged and ordered by ts;
+// entity files are merged per id and per field, and when both sides changed
+// the same field the side with the newer updated_at wins.
+func MergeJSONL(base, ours, theirs []byte) ([]byte, error) {
+	baseObjs, baseIndented, err := parseMergeObjects(base)
+	if err != nil {
+		return nil, domain.NewValidationError("Cannot parse merge base: " + err.Error())
+	}
+	ourObjs, oursIndented, err := parseMergeObjects(ours)
+	if err != nil {
+		return nil, domain.NewValidationError("Cannot parse current version: " + err.Error())
+	}
+	theirObjs, theirsIndented, err := parseMergeObjects(theirs)
+	if err != nil {
+		return nil, domain.NewValidationError("Cannot parse other version: " + err.Error())
+	}
+
+	all := append(append(append([]*mergeObject{}, baseObjs...), ourObjs...), theirObjs...)
+	if len(all) > 0 && allEvents(all) {
+		return encodeMergeObjects(mergeEvents(ourObjs, theirObjs), false)
+	}
+
+	merged, err := mergeEntities(baseObjs, ourObjs, theirObjs)
+	if err != nil {
+		return nil, err
+	}
+	return encodeMergeObjects(merged, oursIndented || theirsIndented || baseIndented)
+}
+
+// mergeObject is a JSON object that keeps its field order
+type mergeObject struct {
+	keys   []string
+	values map[string]json.RawMessage
+}
+
+func (o *mergeObject) str(key string) string {
+	var value string
+	json.Unmarshal(o.values[key], &value)
+	return value
+}
+
+func (o *mergeObject) timestamp(key string) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, o.str(key))
+	return t
+}
+
+func (o *mergeObject) set(key string, value json.RawMessage) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *mergeObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(o.values[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// parseMergeObjects decodes a stream of JSON objects. indented reports a
+// single pretty-printed object, the layout used by project.jsonl.
+func parseMergeObjects(data []byte) ([]*mergeObject, bool, error) {
+	var objs []*mergeObject
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, false, err
+		}
+		obj, err := decodeMergeObject(raw)
+		if err != nil {
+			return nil, false, err
+		}
+		objs = append(objs, obj)
+	}
+	indented := len(objs) == 1 && bytes.Count(bytes.TrimSpace(data), []byte("\n")) > 0
+	return objs, indented, nil
+}
+
+func decodeMergeObject(raw json.RawMessage) (*mergeObject, error) {
+	obj := &mergeObject{values: make(map[string]json.RawMessage)}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('{') {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err != nil {
+			return nil, err
+		}
+		obj.set(key, compact.Bytes())
+	}
+	return obj, nil
+}
+
+func encodeMergeObjects(objs []*mergeObject, indented bool) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, obj := range objs {
+		var data []byte
+		var err error
+		if indented {
+			data, err = json.MarshalIndent(obj, "", "  ")
+		} else {
+			data, err = json.Marshal(obj)
+		}
+		if err != nil {
+			return nil, domain.NewSystemError("Cannot encode merge result", err)
+		}
+		buf.Write(data)
+		if !indented {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func allEvents(objs []*mergeObject) bool {
+	for _, obj := range objs {
+		_, hasLayer := obj.values["layer"]
+		_, hasTs := obj.values["ts"]
+		if !hasLayer || !hasTs {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeEvents unions both logs, dropping identical lines, ordered by ts.
+// Events with the same ts keep their order of appearance (ours first).
+func mergeEvents(ours, theirs []*mergeObject) []*mergeObject {
+	seen := make(map[string]bool)
+	var merged []*mergeObject
+	for _, obj := range append(append([]*mergeObject{}, ours...), theirs...) {
+		data, _ := json.Marshal(obj)
+		if seen[string(data)] {
+			continue
+		}
+		seen[string(data)] = true
+		merged = append(merged, obj)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].timestamp("ts").Before(merged[j].timestamp("ts"))
+	})
+	return merged
+}
+
+// mergeEntities merges entity records by id. An entity deleted on one side
+// stays deleted unless the other side changed it.
+func mergeEntities(base, ours, theirs []*mergeObject) ([]*mergeObject, error) {
+	baseByID, err := indexByID(base)
+	if err != nil {
+		return nil, err
+	}
+	oursByID, err := indexByID(ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsByID, err := indexByID(theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	listed := make(map[string]bool)
+	for _, list := range [][]*mergeObject{ours, theirs} {
+		for _, obj := range list {
+			id := obj.str("id")
+			if !listed[id] {
+				listed[id] = true
+				order = append(order, id)
+			}
+		}
+	}
+
+	var merged []*mergeObject
+	for _, id := range order {
+		b, o, t := baseByID[id], oursByID[id], theirsByID[id]
+		switch {
+		case o != nil && t != nil:
+			merged = append(merged, mergeEntity(b, o, t))
+		case o != nil:
+			if b == nil || !sameObject(b, o) {
+				merged = append(merged, o)
+			}
+		case t != nil:
+			if b == nil || !sameObject(b, t) {
+				merged = append(merged, t)
+			}
+		}
+	}
+	return merged, nil
+}
+
+func indexByID(objs []*mergeObject) (map[string]*mergeObject, error) {
+	byID := make(map[string]*mergeObject, len(objs))
+	for _, obj := range objs {
+		id := obj.str("id")
+		if id == "" {
+			return nil, domain.NewValidationError("Cannot merge: record without an id")
+		}
+		byID[id] = obj
+	}
+	return byID, nil
+}
+
+// mergeEntity merges one record field by field. A field changed on only one
+// side takes that change; a field changed on both sides takes the value from
+// the side with the newer updated_at (ours on a tie).
+func mergeEntity(base, ours, theirs *mergeObject) *mergeObject {
+	theirsNewer := theirs.timestamp("updated_at").After(ours.timestamp("updated_at"))
+
+	merged := &mergeObject{values: make(map[string]json.RawMessage)}
+	keys := append(append([]string{}, ours.keys...), theirs.keys...)
+	for _, key := range keys {
+		if _, done := merged.values[key]; done {
+			continue
+		}
+		o, inOurs := ours.values[key]
+		t, inTheirs := theirs.values[key]
+		var b json.RawMessage
+		inBase := false
+		if base != nil {
+			b, inBase = base.values[key]
+		}
+
+		oursChanged := inOurs != inBase || !bytes.Equal(o, b)
+		theirsChanged := inTheirs != inBase || !bytes.Equal(t, b)
+
+		useTheirs := theirsChanged && (!oursChanged || theirsNewer)
+		if useTheirs {
+			if inTheirs {
+				merged.set(key, t)
+			}
+		} else if inOurs {
+			merged.set(key, o)
+		}
+	}
+	return merged
+}
+
+func sameObject(a, b *mergeObject) bool {
+	if len(a.values) != len(b.values) {
+		return false
+	}
+	for key, value := range a.values {
+		if !bytes.Equal(value, b.values[key]) {
+			return false
+		}
+	}
+	return true
+}
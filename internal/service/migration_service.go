@@ -0,0 +1,319 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// MigrationService upgrades the on-disk layout of a workspace
+type MigrationService struct {
+	reader *fs.Reader
+	writer *fs.Writer
+	paths  *fs.Paths
+}
+
+// NewMigrationService creates a new migration service
+func NewMigrationService() (*MigrationService, error) {
+	paths, err := fs.NewPaths()
+	if err != nil {
+		return nil, err
+	}
+	return NewMigrationServiceWithPaths(paths), nil
+}
+
+// NewMigrationServiceWithPaths creates a migration service rooted at paths
+func NewMigrationServiceWithPaths(paths *fs.Paths) *MigrationService {
+	return &MigrationService{
+		reader: fs.NewReader(paths),
+		writer: fs.NewWriter(paths),
+		paths:  paths,
+	}
+}
+
+// migration upgrades a workspace to version. migrateObject rewrites one JSON
+// object read from a project file (e.g. "issues.jsonl") in place.
+type migration struct {
+	version       string
+	description   string
+	migrateObject func(file string, obj map[string]interface{})
+}
+
+// migrations is the ordered registry; each entry upgrades from the previous version.
+var migrations = []migration{
+	{
+		version:       "mandor.v2",
+		description:   "Rename issue last_updated_at/last_updated_by to updated_at/updated_by",
+		migrateObject: migrateIssueUpdatedFields,
+	},
+}
+
+// MigrationResult describes a migrate run
+type MigrationResult struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Applied []string `json:"applied"`
+	Backup  string   `json:"backup,omitempty"`
+	Files   int      `json:"files"`
+}
+
+func (s *MigrationService) WorkspaceInitialized() bool {
+	return s.reader.WorkspaceExists()
+}
+
+// pendingMigrations returns the migrations needed to bring version up to date
+func pendingMigrations(version string) ([]migration, error) {
+	current, ok := domain.SchemaVersionNumber(version)
+	if !ok {
+		return nil, domain.NewValidationError("Unrecognized workspace schema version: " + version)
+	}
+	latest, _ := domain.SchemaVersionNumber(domain.CurrentSchemaVersion)
+	if current > latest {
+		return nil, domain.NewValidationError(fmt.Sprintf(
+			"Workspace schema %s is newer than this mandor supports (%s). Upgrade mandor.",
+			version, domain.CurrentSchemaVersion,
+		))
+	}
+
+	var pending []migration
+	for _, m := range migrations {
+		n, _ := domain.SchemaVersionNumber(m.version)
+		if n > current {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// CheckSchemaVersion returns an error when the workspace on disk was written by
+// an older (or newer) schema than this build understands. A missing workspace
+// is not an error here; commands report that themselves.
+func (s *MigrationService) CheckSchemaVersion() error {
+	if !s.reader.WorkspaceExists() {
+		return nil
+	}
+	ws, err := s.reader.ReadWorkspace()
+	if err != nil {
+		return err
+	}
+
+	pending, err := pendingMigrations(ws.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return domain.NewValidationError(fmt.Sprintf(
+			"Workspace schema %s is older than %s.\nRun `mandor migrate` to upgrade (.mandor is backed up first).",
+			displaySchemaVersion(ws.SchemaVersion), domain.CurrentSchemaVersion,
+		))
+	}
+	return nil
+}
+
+// Migrate backs up .mandor and applies every pending migration to all project
+// files, then bumps the workspace schema version.
+func (s *MigrationService) Migrate() (*MigrationResult, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	ws, err := s.reader.ReadWorkspace()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{
+		From:    displaySchemaVersion(ws.SchemaVersion),
+		To:      domain.CurrentSchemaVersion,
+		Applied: []string{},
+	}
+
+	pending, err := pendingMigrations(ws.SchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	// The backup lives outside the workspace so it never ends up in a commit
+	backup, err := os.MkdirTemp("", "mandor-backup-"+time.Now().UTC().Format("20060102T150405Z")+"-")
+	if err != nil {
+		return nil, domain.NewSystemError("Cannot create migration backup", err)
+	}
+	if err := s.writer.BackupMandorDir(backup); err != nil {
+		os.RemoveAll(backup)
+		return nil, err
+	}
+	result.Backup = backup
+
+	// A failure part way leaves some files migrated; put the whole of .mandor back
+	if err := s.migrateWorkspace(ws, pending, result); err != nil {
+		if restoreErr := s.writer.RestoreMandorDir(backup); restoreErr != nil {
+			return nil, domain.NewSystemError(fmt.Sprintf("Migration failed (%v) and the workspace could not be restored; a copy of .mandor is kept in %s", err, backup), restoreErr)
+		}
+		os.RemoveAll(backup)
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// migrateWorkspace applies pending to every project file and bumps the
+// workspace schema version
+func (s *MigrationService) migrateWorkspace(ws *domain.Workspace, pending []migration, result *MigrationResult) error {
+	projectIDs, err := s.reader.ListProjects(true)
+	if err != nil {
+		return err
+	}
+
+	for _, projectID := range projectIDs {
+		files, err := filepath.Glob(filepath.Join(s.paths.ProjectDirPath(projectID), "*.jsonl"))
+		if err != nil {
+			return domain.NewSystemError("Cannot list project files", err)
+		}
+		for _, path := range files {
+			if err := s.migrateFile(path, pending); err != nil {
+				return err
+			}
+			result.Files++
+		}
+	}
+
+	for _, m := range pending {
+		result.Applied = append(result.Applied, m.version+": "+m.description)
+	}
+
+	ws.SchemaVersion = domain.CurrentSchemaVersion
+	ws.LastUpdatedAt = time.Now().UTC()
+	return s.writer.WriteWorkspace(ws)
+}
+
+// migrateFile applies migrations to every object in path. project.jsonl holds
+// a single indented object; every other file is NDJSON.
+func (s *MigrationService) migrateFile(path string, pending []migration) error {
+	name := filepath.Base(path)
+
+	var lines []json.RawMessage
+	err := s.reader.ReadNDJSON(path, func(raw []byte) error {
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return err
+		}
+		for _, m := range pending {
+			m.migrateObject(name, obj)
+		}
+		line, err := canonicalObject(name, obj)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return domain.NewSystemError("Cannot migrate "+path, err)
+	}
+
+	if name == "project.jsonl" {
+		if len(lines) == 0 {
+			return nil
+		}
+		var project domain.Project
+		if err := json.Unmarshal(lines[len(lines)-1], &project); err != nil {
+			return domain.NewSystemError("Cannot migrate "+path, err)
+		}
+		return s.writer.WriteProjectMetadata(filepath.Base(filepath.Dir(path)), &project)
+	}
+
+	return s.writer.RewriteNDJSON(path, lines)
+}
+
+// canonicalObject re-encodes a migrated object through its domain type so
+// fields keep their usual order. Unknown files are written as-is.
+func canonicalObject(file string, obj map[string]interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var typed interface{}
+	switch file {
+	case "features.jsonl":
+		typed = &domain.Feature{}
+	case "tasks.jsonl":
+		typed = &domain.Task{}
+	case "issues.jsonl":
+		typed = &domain.Issue{}
+	case "project.jsonl":
+		typed = &domain.Project{}
+	case "events.jsonl":
+		switch obj["layer"] {
+		case "feature":
+			typed = &domain.FeatureEvent{}
+		case "task":
+			typed = &domain.TaskEvent{}
+		case "issue":
+			typed = &domain.IssueEvent{}
+		case "project":
+			typed = &domain.ProjectEvent{}
+		}
+	}
+	if typed == nil {
+		return data, nil
+	}
+
+	if err := json.Unmarshal(data, typed); err != nil {
+		return nil, err
+	}
+	return json.Marshal(typed)
+}
+
+func displaySchemaVersion(version string) string {
+	if version == "" {
+		return "mandor.v1"
+	}
+	return version
+}
+
+// migrateIssueUpdatedFields brings issues in line with tasks and features,
+// which record updated_at/updated_by. Issue snapshots inside events are
+// renamed too.
+func migrateIssueUpdatedFields(file string, obj map[string]interface{}) {
+	switch file {
+	case "issues.jsonl":
+		renameFields(obj)
+	case "events.jsonl":
+		if obj["layer"] != "issue" {
+			return
+		}
+		if snapshot, ok := obj["snapshot"].(map[string]interface{}); ok {
+			renameFields(snapshot)
+		}
+		if diff, ok := obj["diff"].([]interface{}); ok {
+			for _, entry := range diff {
+				if change, ok := entry.(map[string]interface{}); ok {
+					if field, ok := change["field"].(string); ok && strings.HasPrefix(field, "last_updated_") {
+						change["field"] = strings.TrimPrefix(field, "last_")
+					}
+				}
+			}
+		}
+	}
+}
+
+func renameFields(obj map[string]interface{}) {
+	for _, field := range []string{"last_updated_at", "last_updated_by"} {
+		if value, ok := obj[field]; ok {
+			obj[strings.TrimPrefix(field, "last_")] = value
+			delete(obj, field)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// defaultPriorityLevels is used for projects whose schema defines no levels
+var defaultPriorityLevels = []string{"P0", "P1", "P2", "P3", "P4", "P5"}
+
+// PriorityScheme is the ordered set of priority levels of one project.
+// Levels are listed from most to least urgent.
+type PriorityScheme struct {
+	Levels  []string
+	Default string
+}
+
+// projectPriorityScheme reads the priority levels configured in the project's
+// schema.json, falling back to P0-P5 when none are defined or the project has
+// no schema.json
+func projectPriorityScheme(reader *fs.Reader, projectID string) (*PriorityScheme, error) {
+	schema, err := reader.ReadProjectSchema(projectID)
+	if err != nil {
+		var mErr *domain.MandorError
+		if errors.As(err, &mErr) && mErr.Code == domain.ExitValidationError {
+			return &PriorityScheme{Levels: defaultPriorityLevels}, nil
+		}
+		return nil, err
+	}
+
+	levels := schema.Rules.Priority.Levels
+	if len(levels) == 0 {
+		levels = defaultPriorityLevels
+	}
+	return &PriorityScheme{Levels: levels, Default: schema.Rules.Priority.Default}, nil
+}
+
+// Valid reports whether priority is one of the scheme's levels
+func (p *PriorityScheme) Valid(priority string) bool {
+	return p.Rank(priority) < len(p.Levels)
+}
+
+// Rank returns the position of priority in the scheme. Unknown priorities
+// rank after every configured level.
+func (p *PriorityScheme) Rank(priority string) int {
+	for i, level := range p.Levels {
+		if level == priority {
+			return i
+		}
+	}
+	return len(p.Levels)
+}
+
+// Validate returns a validation error listing the allowed levels when
+// priority is not part of the scheme
+func (p *PriorityScheme) Validate(priority string) error {
+	if p.Valid(priority) {
+		return nil
+	}
+	return domain.NewValidationError(fmt.Sprintf(
+		"Invalid priority: '%s'. Valid options: %s", priority, strings.Join(p.Levels, ", "),
+	))
+}
+
+// DefaultFor picks the priority for a new entity: the schema default, then the
+// workspace default_priority, then fallback, each only if it is a valid level.
+// The middle level is used when none of them apply.
+func (p *PriorityScheme) DefaultFor(reader *fs.Reader, fallback string) string {
+	candidates := []string{p.Default}
+	if ws, err := reader.ReadWorkspace(); err == nil {
+		candidates = append(candidates, ws.Config.DefaultPriority)
+	}
+	candidates = append(candidates, fallback)
+
+	for _, candidate := range candidates {
+		if candidate != "" && p.Valid(candidate) {
+			return candidate
+		}
+	}
+	return p.Levels[len(p.Levels)/2]
+}
+
+// validateProjectPriority requires priority to be one of projectID's levels
+func validateProjectPriority(reader *fs.Reader, projectID, priority string) error {
+	scheme, err := projectPriorityScheme(reader, projectID)
+	if err != nil {
+		return err
+	}
+	return scheme.Validate(priority)
+}
+
+// PriorityRanker ranks priorities against the scheme of the project that owns
+// each entity, reading every schema at most once
+type PriorityRanker struct {
+	reader  *fs.Reader
+	schemes map[string]*PriorityScheme
+}
+
+func newPriorityRanker(reader *fs.Reader) *PriorityRanker {
+	return &PriorityRanker{reader: reader, schemes: make(map[string]*PriorityScheme)}
+}
+
+// Scheme returns the priority scheme of projectID
+func (r *PriorityRanker) Scheme(projectID string) *PriorityScheme {
+	if scheme, ok := r.schemes[projectID]; ok {
+		return scheme
+	}
+	scheme, err := projectPriorityScheme(r.reader, projectID)
+	if err != nil {
+		scheme = &PriorityScheme{Levels: defaultPriorityLevels}
+	}
+	r.schemes[projectID] = scheme
+	return scheme
+}
+
+// Rank returns the position of priority within projectID's levels
+func (r *PriorityRanker) Rank(projectID, priority string) int {
+	return r.Scheme(projectID).Rank(priority)
+}
+
+// workspacePriorityScheme merges the levels of every project in order of first
+// appearance, for filters that are not scoped to a single project
+func workspacePriorityScheme(reader *fs.Reader) (*PriorityScheme, error) {
+	projects, err := reader.ListProjects(false)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &PriorityScheme{}
+	seen := make(map[string]bool)
+	for _, projectID := range projects {
+		scheme, err := projectPriorityScheme(reader, projectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, level := range scheme.Levels {
+			if !seen[level] {
+				seen[level] = true
+				merged.Levels = append(merged.Levels, level)
+			}
+		}
+	}
+	if len(merged.Levels) == 0 {
+		merged.Levels = defaultPriorityLevels
+	}
+	return merged, nil
+}
+
+// parsePriorityLevels splits a comma separated level list and rejects empty
+// or duplicate entries
+func parsePriorityLevels(value string) ([]string, error) {
+	var levels []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		level := strings.TrimSpace(part)
+		if level == "" {
+			return nil, domain.NewValidationError("Priority levels cannot be empty.")
+		}
+		if seen[level] {
+			return nil, domain.NewValidationError("Duplicate priority level: " + level)
+		}
+		seen[level] = true
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
@@ -1,6 +1,9 @@
 package service
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"mandor/internal/domain"
@@ -55,6 +58,12 @@ func (s *ProjectService) ValidateCreateInput(input *domain.ProjectCreateInput) e
 }
 
 func (s *ProjectService) CreateProject(input *domain.ProjectCreateInput) error {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	creator := util.GetGitUsername()
 	now := time.Now().UTC()
 
@@ -84,11 +93,12 @@ func (s *ProjectService) CreateProject(input *domain.ProjectCreateInput) error {
 	}
 
 	event := &domain.ProjectEvent{
-		Layer: "project",
-		Type:  "created",
-		ID:    input.ID,
-		By:    creator,
-		Ts:    now,
+		Layer:    "project",
+		Type:     "created",
+		ID:       input.ID,
+		By:       creator,
+		Ts:       now,
+		Snapshot: project,
 	}
 	if err := s.writer.AppendProjectEvent(input.ID, event); err != nil {
 		return err
@@ -208,15 +218,70 @@ func (s *ProjectService) ValidateUpdateInput(input *domain.ProjectUpdateInput) e
 		return domain.NewPermissionError("Permission denied. Cannot write to " + s.paths.ProjectMetadataPath(input.ID))
 	}
 
+	if input.PriorityLevels != nil || input.PriorityDefault != nil {
+		schema, err := s.reader.ReadProjectSchema(input.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := updatedPriorityConfig(schema.Rules.Priority, input); err != nil {
+			return err
+		}
+	}
+
+	if input.AllowedLabels != nil {
+		if _, err := ParseLabels(*input.AllowedLabels); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// updatedPriorityConfig applies --priority-levels and --priority-default to
+// the current config. The default must be one of the resulting levels.
+func updatedPriorityConfig(current domain.PriorityConfig, input *domain.ProjectUpdateInput) (domain.PriorityConfig, error) {
+	updated := domain.PriorityConfig{
+		Levels:  append([]string(nil), current.Levels...),
+		Default: current.Default,
+	}
+	if len(updated.Levels) == 0 {
+		updated.Levels = append([]string(nil), defaultPriorityLevels...)
+	}
+
+	if input.PriorityLevels != nil {
+		levels, err := parsePriorityLevels(*input.PriorityLevels)
+		if err != nil {
+			return current, err
+		}
+		updated.Levels = levels
+	}
+	if input.PriorityDefault != nil {
+		updated.Default = strings.TrimSpace(*input.PriorityDefault)
+	}
+
+	scheme := &PriorityScheme{Levels: updated.Levels}
+	if !scheme.Valid(updated.Default) {
+		return current, domain.NewValidationError(fmt.Sprintf(
+			"Default priority '%s' is not one of the levels: %s. Set it with --priority-default.",
+			updated.Default, strings.Join(updated.Levels, ", "),
+		))
+	}
+	return updated, nil
+}
+
 func (s *ProjectService) UpdateProject(input *domain.ProjectUpdateInput) ([]string, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	project, err := s.reader.ReadProjectMetadata(input.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	before := *project
 	var changes []string
 	updater := util.GetGitUsername()
 	now := time.Now().UTC()
@@ -252,8 +317,11 @@ func (s *ProjectService) UpdateProject(input *domain.ProjectUpdateInput) ([]stri
 		return nil, err
 	}
 
+	diff := domain.DiffFields(before, project, changes)
+
 	schemaChanged := false
-	if input.TaskDep != nil || input.FeatureDep != nil || input.IssueDep != nil {
+	if input.TaskDep != nil || input.FeatureDep != nil || input.IssueDep != nil || input.PriorityLevels != nil || input.PriorityDefault != nil ||
+		input.AutoCompleteFeatures != nil || input.AllowedLabels != nil {
 		schema, err := s.reader.ReadProjectSchema(input.ID)
 		if err != nil {
 			return nil, err
@@ -263,6 +331,7 @@ func (s *ProjectService) UpdateProject(input *domain.ProjectUpdateInput) ([]stri
 			if !domain.ValidateDependencyRule(*input.TaskDep) {
 				return nil, domain.NewValidationError("Invalid value for --task-dep. Valid options: same_project_only, cross_project_allowed, disabled")
 			}
+			diff = append(diff, domain.FieldChange{Field: "task_dep", From: schema.Rules.Task.Dependency, To: *input.TaskDep})
 			schema.Rules.Task.Dependency = *input.TaskDep
 			changes = append(changes, "task_dep")
 			schemaChanged = true
@@ -272,6 +341,7 @@ func (s *ProjectService) UpdateProject(input *domain.ProjectUpdateInput) ([]stri
 			if !domain.ValidateDependencyRule(*input.FeatureDep) {
 				return nil, domain.NewValidationError("Invalid value for --feature-dep. Valid options: same_project_only, cross_project_allowed, disabled")
 			}
+			diff = append(diff, domain.FieldChange{Field: "feature_dep", From: schema.Rules.Feature.Dependency, To: *input.FeatureDep})
 			schema.Rules.Feature.Dependency = *input.FeatureDep
 			changes = append(changes, "feature_dep")
 			schemaChanged = true
@@ -281,11 +351,49 @@ func (s *ProjectService) UpdateProject(input *domain.ProjectUpdateInput) ([]stri
 			if !domain.ValidateDependencyRule(*input.IssueDep) {
 				return nil, domain.NewValidationError("Invalid value for --issue-dep. Valid options: same_project_only, cross_project_allowed, disabled")
 			}
+			diff = append(diff, domain.FieldChange{Field: "issue_dep", From: schema.Rules.Issue.Dependency, To: *input.IssueDep})
 			schema.Rules.Issue.Dependency = *input.IssueDep
 			changes = append(changes, "issue_dep")
 			schemaChanged = true
 		}
 
+		if input.PriorityLevels != nil || input.PriorityDefault != nil {
+			priority, err := updatedPriorityConfig(schema.Rules.Priority, input)
+			if err != nil {
+				return nil, err
+			}
+			if input.PriorityLevels != nil {
+				diff = append(diff, domain.FieldChange{Field: "priority_levels", From: schema.Rules.Priority.Levels, To: priority.Levels})
+				changes = append(changes, "priority_levels")
+			}
+			if priority.Default != schema.Rules.Priority.Default {
+				diff = append(diff, domain.FieldChange{Field: "priority_default", From: schema.Rules.Priority.Default, To: priority.Default})
+				changes = append(changes, "priority_default")
+			}
+			schema.Rules.Priority = priority
+			schemaChanged = true
+		}
+
+		if input.AutoCompleteFeatures != nil && *input.AutoCompleteFeatures != schema.Rules.Rollup.AutoCompleteFeatures {
+			diff = append(diff, domain.FieldChange{Field: "auto_complete_features", From: schema.Rules.Rollup.AutoCompleteFeatures, To: *input.AutoCompleteFeatures})
+			schema.Rules.Rollup.AutoCompleteFeatures = *input.AutoCompleteFeatures
+			changes = append(changes, "auto_complete_features")
+			schemaChanged = true
+		}
+
+		if input.AllowedLabels != nil {
+			allowed, err := ParseLabels(*input.AllowedLabels)
+			if err != nil {
+				return nil, err
+			}
+			if _, changed := applyLabelChanges(schema.Rules.Labels.Allowed, &allowed, nil, nil); changed {
+				diff = append(diff, domain.FieldChange{Field: "allowed_labels", From: schema.Rules.Labels.Allowed, To: allowed})
+				schema.Rules.Labels.Allowed = allowed
+				changes = append(changes, "allowed_labels")
+				schemaChanged = true
+			}
+		}
+
 		if schemaChanged {
 			if err := s.writer.WriteProjectSchema(input.ID, schema); err != nil {
 				return nil, err
@@ -294,12 +402,14 @@ func (s *ProjectService) UpdateProject(input *domain.ProjectUpdateInput) ([]stri
 	}
 
 	event := &domain.ProjectEvent{
-		Layer:   "project",
-		Type:    "updated",
-		ID:      input.ID,
-		By:      updater,
-		Ts:      now,
-		Changes: changes,
+		Layer:    "project",
+		Type:     "updated",
+		ID:       input.ID,
+		By:       updater,
+		Ts:       now,
+		Changes:  changes,
+		Diff:     diff,
+		Snapshot: project,
 	}
 	if err := s.writer.AppendProjectEvent(input.ID, event); err != nil {
 		return nil, err
@@ -341,6 +451,12 @@ func (s *ProjectService) DeleteProject(input *domain.ProjectDeleteInput) (string
 		return "[DRY RUN] Would soft delete project: " + input.ID, nil
 	}
 
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
 	project, err := s.reader.ReadProjectMetadata(input.ID)
 	if err != nil {
 		return "", err
@@ -356,17 +472,6 @@ func (s *ProjectService) DeleteProject(input *domain.ProjectDeleteInput) (string
 	updater := util.GetGitUsername()
 	now := time.Now().UTC()
 
-	event := &domain.ProjectEvent{
-		Layer: "project",
-		Type:  "deleted",
-		ID:    input.ID,
-		By:    updater,
-		Ts:    now,
-	}
-	if err := s.writer.AppendProjectEvent(input.ID, event); err != nil {
-		return "", err
-	}
-
 	project.Status = domain.ProjectStatusDeleted
 	project.UpdatedAt = now
 	project.UpdatedBy = updater
@@ -375,6 +480,18 @@ func (s *ProjectService) DeleteProject(input *domain.ProjectDeleteInput) (string
 		return "", err
 	}
 
+	event := &domain.ProjectEvent{
+		Layer:    "project",
+		Type:     "deleted",
+		ID:       input.ID,
+		By:       updater,
+		Ts:       now,
+		Snapshot: project,
+	}
+	if err := s.writer.AppendProjectEvent(input.ID, event); err != nil {
+		return "", err
+	}
+
 	return "Project deleted: " + input.ID, nil
 }
 
@@ -384,8 +501,8 @@ func (s *ProjectService) ValidateReopenInput(input *domain.ProjectReopenInput) e
 		return err
 	}
 
-	if project.Status != domain.ProjectStatusDeleted {
-		return domain.NewValidationError("Project is not deleted: " + input.ID + ". Nothing to reopen.")
+	if project.Status != domain.ProjectStatusDeleted && project.Status != domain.ProjectStatusDone {
+		return domain.NewValidationError("Project is not deleted or done: " + input.ID + ". Nothing to reopen.")
 	}
 
 	if !s.writer.CheckProjectWritable(input.ID) {
@@ -396,26 +513,103 @@ func (s *ProjectService) ValidateReopenInput(input *domain.ProjectReopenInput) e
 }
 
 func (s *ProjectService) ReopenProject(input *domain.ProjectReopenInput) (string, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
 	project, err := s.reader.ReadProjectMetadata(input.ID)
 	if err != nil {
 		return "", err
 	}
 
+	before := *project
 	updater := util.GetGitUsername()
 	now := time.Now().UTC()
 
+	// A deleted project starts over; a done project goes back to work
+	project.Status = domain.ProjectStatusInitial
+	if before.Status == domain.ProjectStatusDone {
+		project.Status = domain.ProjectStatusActive
+	}
+	project.UpdatedAt = now
+	project.UpdatedBy = updater
+
+	if err := s.writer.WriteProjectMetadata(input.ID, project); err != nil {
+		return "", err
+	}
+
 	event := &domain.ProjectEvent{
-		Layer: "project",
-		Type:  "reopened",
-		ID:    input.ID,
-		By:    updater,
-		Ts:    now,
+		Layer:    "project",
+		Type:     "reopened",
+		ID:       input.ID,
+		By:       updater,
+		Ts:       now,
+		Changes:  []string{"status"},
+		Diff:     domain.DiffFields(before, project, []string{"status"}),
+		Snapshot: project,
 	}
 	if err := s.writer.AppendProjectEvent(input.ID, event); err != nil {
 		return "", err
 	}
 
-	project.Status = domain.ProjectStatusInitial
+	return "Project reopened: " + input.ID, nil
+}
+
+func (s *ProjectService) ValidateCompleteInput(input *domain.ProjectCompleteInput) error {
+	project, err := s.reader.ReadProjectMetadata(input.ID)
+	if err != nil {
+		return err
+	}
+
+	switch project.Status {
+	case domain.ProjectStatusDeleted:
+		return domain.NewValidationError("Project is deleted: " + input.ID + ". Use `mandor project reopen` first.")
+	case domain.ProjectStatusDone:
+		return domain.NewValidationError("Project is already done: " + input.ID)
+	}
+
+	open, err := s.openWork(input.ID)
+	if err != nil {
+		return err
+	}
+	if len(open) > 0 {
+		return domain.NewValidationError(fmt.Sprintf(
+			"Project %s has %d open feature(s)/issue(s):\n  %s",
+			input.ID, len(open), strings.Join(open, "\n  "),
+		))
+	}
+
+	if !s.writer.CheckProjectWritable(input.ID) {
+		return domain.NewPermissionError("Permission denied. Cannot write to " + s.paths.ProjectMetadataPath(input.ID))
+	}
+
+	return nil
+}
+
+func (s *ProjectService) CompleteProject(input *domain.ProjectCompleteInput) (string, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	// Re-check under the lock; work may have been added since validation
+	if err := s.ValidateCompleteInput(input); err != nil {
+		return "", err
+	}
+
+	project, err := s.reader.ReadProjectMetadata(input.ID)
+	if err != nil {
+		return "", err
+	}
+
+	before := *project
+	updater := util.GetGitUsername()
+	now := time.Now().UTC()
+
+	project.Status = domain.ProjectStatusDone
 	project.UpdatedAt = now
 	project.UpdatedBy = updater
 
@@ -423,9 +617,107 @@ func (s *ProjectService) ReopenProject(input *domain.ProjectReopenInput) (string
 		return "", err
 	}
 
-	return "Project reopened: " + input.ID, nil
+	event := &domain.ProjectEvent{
+		Layer:    "project",
+		Type:     domain.ProjectStatusDone,
+		ID:       input.ID,
+		By:       updater,
+		Ts:       now,
+		Changes:  []string{"status"},
+		Diff:     domain.DiffFields(before, project, []string{"status"}),
+		Snapshot: project,
+	}
+	if err := s.writer.AppendProjectEvent(input.ID, event); err != nil {
+		return "", err
+	}
+
+	return "Project completed: " + input.ID, nil
+}
+
+// openWork lists the features of projectID that are neither done nor
+// cancelled and the issues that are not in a terminal status
+func (s *ProjectService) openWork(projectID string) ([]string, error) {
+	var open []string
+	err := s.reader.ReadNDJSON(s.paths.ProjectFeaturesPath(projectID), func(raw []byte) error {
+		var f domain.Feature
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return err
+		}
+		if f.Status != domain.FeatureStatusDone && f.Status != domain.FeatureStatusCancelled {
+			open = append(open, fmt.Sprintf("%s (%s)", f.ID, f.Status))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.reader.ReadNDJSON(s.paths.ProjectIssuesPath(projectID), func(raw []byte) error {
+		var i domain.Issue
+		if err := json.Unmarshal(raw, &i); err != nil {
+			return err
+		}
+		if !domain.IsIssueTerminalStatus(i.Status) {
+			open = append(open, fmt.Sprintf("%s (%s)", i.ID, i.Status))
+		}
+		return nil
+	})
+	return open, err
+}
+
+// planActivation reports, without writing anything, whether activateProject
+// would move projectID to active
+func (s *ProjectService) planActivation(projectID string) (*domain.StatusChange, error) {
+	project, err := s.reader.ReadProjectMetadata(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Status != domain.ProjectStatusInitial {
+		return nil, nil
+	}
+	return &domain.StatusChange{Layer: "project", ID: projectID, From: project.Status, To: domain.ProjectStatusActive}, nil
+}
+
+// activateProject moves an initial project to active once work on it starts,
+// recording a system event. It reports whether the project changed.
+func (s *ProjectService) activateProject(projectID string) (bool, error) {
+	project, err := s.reader.ReadProjectMetadata(projectID)
+	if err != nil {
+		return false, err
+	}
+	if project.Status != domain.ProjectStatusInitial {
+		return false, nil
+	}
+
+	before := *project
+	now := time.Now().UTC()
+	project.Status = domain.ProjectStatusActive
+	project.UpdatedAt = now
+
+	if err := s.writer.WriteProjectMetadata(projectID, project); err != nil {
+		return false, err
+	}
+
+	event := &domain.ProjectEvent{
+		Layer:    "project",
+		Type:     domain.ProjectStatusActive,
+		ID:       projectID,
+		By:       "system",
+		Ts:       now,
+		Changes:  []string{"status"},
+		Diff:     domain.DiffFields(before, project, []string{"status"}),
+		Snapshot: project,
+	}
+	if err := s.writer.AppendProjectEvent(projectID, event); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (s *ProjectService) GetProject(projectID string) (*domain.Project, error) {
 	return s.reader.ReadProjectMetadata(projectID)
 }
+
+func (s *ProjectService) GetProjectSchema(projectID string) (*domain.ProjectSchema, error) {
+	return s.reader.ReadProjectSchema(projectID)
+}
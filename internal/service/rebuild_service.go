@@ -0,0 +1,400 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// RebuildService regenerates entity files by replaying events.jsonl
+type RebuildService struct {
+	reader *fs.Reader
+	writer *fs.Writer
+	paths  *fs.Paths
+}
+
+// NewRebuildService creates a new rebuild service
+func NewRebuildService() (*RebuildService, error) {
+	paths, err := fs.NewPaths()
+	if err != nil {
+		return nil, err
+	}
+	return NewRebuildServiceWithPaths(paths), nil
+}
+
+// NewRebuildServiceWithPaths creates a rebuild service rooted at paths
+func NewRebuildServiceWithPaths(paths *fs.Paths) *RebuildService {
+	return &RebuildService{
+		reader: fs.NewReader(paths),
+		writer: fs.NewWriter(paths),
+		paths:  paths,
+	}
+}
+
+// RebuildResult is the outcome of replaying one or more projects
+type RebuildResult struct {
+	Verify   bool                   `json:"verify"`
+	Projects []ProjectRebuildResult `json:"projects"`
+}
+
+// ProjectRebuildResult describes the replayed state of a single project
+type ProjectRebuildResult struct {
+	ProjectID  string            `json:"project_id"`
+	Events     int               `json:"events"`
+	Features   int               `json:"features"`
+	Tasks      int               `json:"tasks"`
+	Issues     int               `json:"issues"`
+	Mismatches []RebuildMismatch `json:"mismatches,omitempty"`
+}
+
+// RebuildMismatch is a difference between replayed state and the entity files
+type RebuildMismatch struct {
+	Layer  string   `json:"layer"`
+	ID     string   `json:"id"`
+	Reason string   `json:"reason"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Mismatch reasons reported by --verify
+const (
+	MismatchMissingFromFiles  = "missing_from_files"
+	MismatchMissingFromEvents = "missing_from_events"
+	MismatchNoPayload         = "no_payload"
+	MismatchDiffers           = "differs"
+)
+
+// MismatchCount returns the total number of mismatches across all projects
+func (r *RebuildResult) MismatchCount() int {
+	count := 0
+	for _, p := range r.Projects {
+		count += len(p.Mismatches)
+	}
+	return count
+}
+
+func (s *RebuildService) WorkspaceInitialized() bool {
+	return s.reader.WorkspaceExists()
+}
+
+// Rebuild replays events for one project (or all when projectID is empty).
+// With verify it only compares replayed state to the files; otherwise the
+// entity files are rewritten from the replayed state.
+func (s *RebuildService) Rebuild(projectID string, verify bool) (*RebuildResult, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var projectIDs []string
+	if projectID != "" {
+		if !s.reader.ProjectExists(projectID) {
+			return nil, domain.NewValidationError("Project not found: " + projectID)
+		}
+		projectIDs = []string{projectID}
+	} else {
+		projectIDs, err = s.reader.ListProjects(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &RebuildResult{Verify: verify, Projects: []ProjectRebuildResult{}}
+	for _, id := range projectIDs {
+		state, err := s.replayProject(id)
+		if err != nil {
+			return nil, err
+		}
+
+		projectResult := ProjectRebuildResult{
+			ProjectID: id,
+			Events:    state.events,
+			Features:  len(state.features.order),
+			Tasks:     len(state.tasks.order),
+			Issues:    len(state.issues.order),
+		}
+
+		mismatches, err := s.verifyProject(id, state)
+		if err != nil {
+			return nil, err
+		}
+
+		if verify {
+			projectResult.Mismatches = mismatches
+		} else {
+			var missing []string
+			for _, m := range mismatches {
+				if m.Reason == MismatchNoPayload || m.Reason == MismatchMissingFromEvents {
+					missing = append(missing, m.ID)
+				}
+			}
+			if len(missing) > 0 {
+				return nil, domain.NewValidationError(fmt.Sprintf(
+					"Cannot rebuild project %s: %d entit(ies) have no payload in events.jsonl (recorded before event snapshots were introduced):\n  %s",
+					id, len(missing), strings.Join(missing, "\n  "),
+				))
+			}
+			if err := s.writeProject(id, state); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Projects = append(result.Projects, projectResult)
+	}
+
+	return result, nil
+}
+
+// projectReplay is the in-memory state produced by replaying events.jsonl
+type projectReplay struct {
+	events   int
+	project  *domain.Project
+	features *replayLog[domain.Feature]
+	tasks    *replayLog[domain.Task]
+	issues   *replayLog[domain.Issue]
+}
+
+// replayLog tracks entities of one layer in order of first appearance
+type replayLog[T any] struct {
+	layer     string
+	order     []string
+	items     map[string]*T
+	noPayload map[string]bool
+}
+
+func newReplayLog[T any](layer string) *replayLog[T] {
+	return &replayLog[T]{layer: layer, items: make(map[string]*T), noPayload: make(map[string]bool)}
+}
+
+// apply folds one event into the log. A snapshot replaces the entity; older
+// events without one fall back to their field diff or status-only type.
+func (l *replayLog[T]) apply(id, eventType string, snapshot *T, diff []domain.FieldChange) error {
+	if snapshot != nil {
+		if _, ok := l.items[id]; !ok {
+			l.order = append(l.order, id)
+		}
+		copied := *snapshot
+		l.items[id] = &copied
+		delete(l.noPayload, id)
+		return nil
+	}
+
+	item, ok := l.items[id]
+	if !ok {
+		l.noPayload[id] = true
+		return nil
+	}
+
+	fields := make(map[string]interface{})
+	for _, change := range diff {
+		fields[change.Field] = change.To
+	}
+	if status := replayStatus(l.layer, eventType); status != "" {
+		fields["status"] = status
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return patchFields(item, fields)
+}
+
+// replayStatus maps system event types that imply a status change. An
+// unblocked feature returns to draft; tasks and issues return to ready.
+func replayStatus(layer, eventType string) string {
+	switch eventType {
+	case "ready":
+		return "ready"
+	case "blocked":
+		return "blocked"
+	case "unblocked":
+		if layer == "feature" {
+			return domain.FeatureStatusDraft
+		}
+		return domain.TaskStatusReady
+	}
+	return ""
+}
+
+// patchFields overwrites JSON fields of item in place
+func patchFields(item interface{}, fields map[string]interface{}) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]interface{})
+	if err := json.Unmarshal(data, &current); err != nil {
+		return err
+	}
+	for field, value := range fields {
+		current[field] = value
+	}
+	data, err = json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, item)
+}
+
+func (l *replayLog[T]) list() []*T {
+	items := make([]*T, 0, len(l.order))
+	for _, id := range l.order {
+		items = append(items, l.items[id])
+	}
+	return items
+}
+
+func (s *RebuildService) replayProject(projectID string) (*projectReplay, error) {
+	state := &projectReplay{
+		features: newReplayLog[domain.Feature]("feature"),
+		tasks:    newReplayLog[domain.Task]("task"),
+		issues:   newReplayLog[domain.Issue]("issue"),
+	}
+
+	err := s.reader.ReadNDJSON(s.paths.ProjectEventsPath(projectID), func(raw []byte) error {
+		var header struct {
+			Layer string `json:"layer"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return err
+		}
+		state.events++
+
+		switch header.Layer {
+		case "project":
+			var event domain.ProjectEvent
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return err
+			}
+			if event.Snapshot != nil {
+				state.project = event.Snapshot
+			}
+		case "feature":
+			var event domain.FeatureEvent
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return err
+			}
+			return state.features.apply(event.ID, event.Type, event.Snapshot, event.Diff)
+		case "task":
+			var event domain.TaskEvent
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return err
+			}
+			return state.tasks.apply(event.ID, event.Type, event.Snapshot, event.Diff)
+		case "issue":
+			var event domain.IssueEvent
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return err
+			}
+			return state.issues.apply(event.ID, event.Type, event.Snapshot, event.Diff)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (s *RebuildService) writeProject(projectID string, state *projectReplay) error {
+	if state.project != nil {
+		if err := s.writer.WriteProjectMetadata(projectID, state.project); err != nil {
+			return err
+		}
+	}
+	if err := s.writer.ReplaceFeatures(projectID, state.features.list(), nil); err != nil {
+		return err
+	}
+	if err := s.writer.ReplaceTasks(projectID, state.tasks.list(), nil); err != nil {
+		return err
+	}
+	return s.writer.ReplaceIssues(projectID, state.issues.list(), nil)
+}
+
+func (s *RebuildService) verifyProject(projectID string, state *projectReplay) ([]RebuildMismatch, error) {
+	var mismatches []RebuildMismatch
+
+	if state.project != nil {
+		current, err := s.reader.ReadProjectMetadata(projectID)
+		if err != nil {
+			return nil, err
+		}
+		if fields := domain.ChangedFields(current, state.project); len(fields) > 0 {
+			mismatches = append(mismatches, RebuildMismatch{Layer: "project", ID: projectID, Reason: MismatchDiffers, Fields: fields})
+		}
+	}
+
+	features, err := readEntityFile[domain.Feature](s.reader, s.paths.ProjectFeaturesPath(projectID), func(f *domain.Feature) string { return f.ID })
+	if err != nil {
+		return nil, err
+	}
+	mismatches = append(mismatches, compareReplay("feature", state.features, features)...)
+
+	tasks, err := readEntityFile[domain.Task](s.reader, s.paths.ProjectTasksPath(projectID), func(t *domain.Task) string { return t.ID })
+	if err != nil {
+		return nil, err
+	}
+	mismatches = append(mismatches, compareReplay("task", state.tasks, tasks)...)
+
+	issues, err := readEntityFile[domain.Issue](s.reader, s.paths.ProjectIssuesPath(projectID), func(i *domain.Issue) string { return i.ID })
+	if err != nil {
+		return nil, err
+	}
+	mismatches = append(mismatches, compareReplay("issue", state.issues, issues)...)
+
+	return mismatches, nil
+}
+
+// entityFile is the current content of an entity JSONL file, keyed by ID
+type entityFile[T any] struct {
+	order []string
+	items map[string]*T
+}
+
+func readEntityFile[T any](reader *fs.Reader, path string, idOf func(*T) string) (*entityFile[T], error) {
+	file := &entityFile[T]{items: make(map[string]*T)}
+	err := reader.ReadNDJSON(path, func(raw []byte) error {
+		var item T
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return err
+		}
+		id := idOf(&item)
+		if _, ok := file.items[id]; !ok {
+			file.order = append(file.order, id)
+		}
+		file.items[id] = &item
+		return nil
+	})
+	return file, err
+}
+
+func compareReplay[T any](layer string, replayed *replayLog[T], current *entityFile[T]) []RebuildMismatch {
+	var mismatches []RebuildMismatch
+
+	for _, id := range current.order {
+		if replayed.noPayload[id] {
+			mismatches = append(mismatches, RebuildMismatch{Layer: layer, ID: id, Reason: MismatchNoPayload})
+			continue
+		}
+		item, ok := replayed.items[id]
+		if !ok {
+			mismatches = append(mismatches, RebuildMismatch{Layer: layer, ID: id, Reason: MismatchMissingFromEvents})
+			continue
+		}
+		if fields := domain.ChangedFields(current.items[id], item); len(fields) > 0 {
+			mismatches = append(mismatches, RebuildMismatch{Layer: layer, ID: id, Reason: MismatchDiffers, Fields: fields})
+		}
+	}
+
+	for _, id := range replayed.order {
+		if _, ok := current.items[id]; !ok {
+			mismatches = append(mismatches, RebuildMismatch{Layer: layer, ID: id, Reason: MismatchMissingFromFiles})
+		}
+	}
+
+	return mismatches
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"mandor/internal/domain"
@@ -27,14 +28,45 @@ func NewStatusService() (*StatusService, error) {
 	}, nil
 }
 
+// NewStatusServiceWithPaths creates a status service with custom paths
+func NewStatusServiceWithPaths(paths *fs.Paths) *StatusService {
+	return &StatusService{
+		reader: fs.NewReader(paths),
+		paths:  paths,
+	}
+}
+
 // WorkspaceStatus represents the overall workspace status
 type WorkspaceStatus struct {
 	Workspace    *domain.Workspace `json:"workspace"`
 	Projects     []ProjectSummary  `json:"projects"`
 	Dependencies DependencySummary `json:"dependencies"`
 	Totals       TotalStats        `json:"totals"`
+	Assignees    []AssigneeSummary `json:"assignees"`
+	Labels       []LabelSummary    `json:"labels"`
+}
+
+// AssigneeSummary counts the open features, tasks and issues of one assignee
+// across the projects in the status
+type AssigneeSummary struct {
+	Assignee string `json:"assignee"`
+	Features int    `json:"features"`
+	Tasks    int    `json:"tasks"`
+	Issues   int    `json:"issues"`
 }
 
+// LabelSummary counts the open features, tasks and issues carrying one label
+// across the projects in the status
+type LabelSummary struct {
+	Label    string `json:"label"`
+	Features int    `json:"features"`
+	Tasks    int    `json:"tasks"`
+	Issues   int    `json:"issues"`
+}
+
+// unassignedKey is the ByAssignee key for open items nobody is assigned to
+const unassignedKey = "unassigned"
+
 // ProjectSummary represents a project in status output
 type ProjectSummary struct {
 	ID    string              `json:"id"`
@@ -44,9 +76,11 @@ type ProjectSummary struct {
 
 // DependencySummary represents dependency statistics
 type DependencySummary struct {
-	CrossProjectCount int      `json:"cross_project_count"`
-	CircularDeps      int      `json:"circular_dependencies"`
-	BlockingItems     []string `json:"blocking_items"`
+	CrossProjectCount int `json:"cross_project_count"`
+	CircularDeps      int `json:"circular_dependencies"`
+	// Cycles lists the member IDs of each dependency cycle
+	Cycles        [][]string `json:"cycles"`
+	BlockingItems []string   `json:"blocking_items"`
 }
 
 // TotalStats represents workspace-wide totals
@@ -70,6 +104,8 @@ func (s *StatusService) GetWorkspaceStatus(projectID string) (*WorkspaceStatus,
 		Projects:     []ProjectSummary{},
 		Dependencies: DependencySummary{},
 		Totals:       TotalStats{},
+		Assignees:    []AssigneeSummary{},
+		Labels:       []LabelSummary{},
 	}
 
 	// Get projects to analyze
@@ -106,9 +142,76 @@ func (s *StatusService) GetWorkspaceStatus(projectID string) (*WorkspaceStatus,
 		status.Totals.Blocked += projectStatus.Stats.Tasks.BlockedCount
 	}
 
+	status.Assignees = summarizeAssignees(status.Projects)
+	status.Labels = summarizeLabels(status.Projects)
+
+	cycles, err := s.FindCycles(projectID)
+	if err != nil {
+		return nil, err
+	}
+	status.Dependencies.Cycles = cycles
+	status.Dependencies.CircularDeps = len(cycles)
+
 	return status, nil
 }
 
+// FindCycles detects dependency cycles among the tasks, features and issues of
+// every active project, so cycles that cross projects are found too. When
+// projectID is set only cycles with a member in that project are returned.
+// Cancelled entities are left out of the graph.
+func (s *StatusService) FindCycles(projectID string) ([][]string, error) {
+	projects, err := s.reader.ListProjects(false)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := make(map[string][]string)
+	owner := make(map[string]string)
+	for _, pid := range projects {
+		files := []string{
+			s.paths.ProjectTasksPath(pid),
+			s.paths.ProjectFeaturesPath(pid),
+			s.paths.ProjectIssuesPath(pid),
+		}
+		for _, path := range files {
+			err := s.reader.ReadNDJSON(path, func(raw []byte) error {
+				var entity struct {
+					ID        string   `json:"id"`
+					Status    string   `json:"status"`
+					DependsOn []string `json:"depends_on"`
+				}
+				if err := json.Unmarshal(raw, &entity); err != nil {
+					return err
+				}
+				if entity.ID == "" || entity.Status == "cancelled" {
+					return nil
+				}
+				graph[entity.ID] = entity.DependsOn
+				owner[entity.ID] = pid
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cycles := [][]string{}
+	for _, members := range findCycles(graph) {
+		if projectID == "" {
+			cycles = append(cycles, members)
+			continue
+		}
+		for _, id := range members {
+			if owner[id] == projectID {
+				cycles = append(cycles, members)
+				break
+			}
+		}
+	}
+	return cycles, nil
+}
+
 // GetProjectStatus retrieves detailed status for a single project
 func (s *StatusService) GetProjectStatus(projectID string) (*ProjectSummary, error) {
 	if !s.reader.ProjectExists(projectID) {
@@ -148,6 +251,9 @@ func (s *StatusService) GetProjectStatus(projectID string) (*ProjectSummary, err
 		if ok {
 			stats.Features.ByStatus[status]++
 			stats.Features.Total++
+			if status != domain.FeatureStatusDone && status != domain.FeatureStatusCancelled {
+				countOpen(&stats.Features, feature)
+			}
 		}
 
 		return nil
@@ -167,6 +273,9 @@ func (s *StatusService) GetProjectStatus(projectID string) (*ProjectSummary, err
 			if status == "blocked" {
 				stats.Tasks.BlockedCount++
 			}
+			if status != domain.TaskStatusDone && status != domain.TaskStatusCancelled {
+				countOpen(&stats.Tasks, task)
+			}
 		}
 
 		return nil
@@ -182,6 +291,9 @@ func (s *StatusService) GetProjectStatus(projectID string) (*ProjectSummary, err
 		if status, ok := issue["status"].(string); ok {
 			stats.Issues.ByStatus[status]++
 			stats.Issues.Total++
+			if !domain.IsIssueTerminalStatus(status) {
+				countOpen(&stats.Issues, issue)
+			}
 		}
 
 		if issueType, ok := issue["type"].(string); ok {
@@ -197,3 +309,84 @@ func (s *StatusService) GetProjectStatus(projectID string) (*ProjectSummary, err
 		Stats: stats,
 	}, nil
 }
+
+// countOpen adds an open entity to stats.ByAssignee and stats.ByLabel
+func countOpen(stats *domain.EntityStats, entity map[string]interface{}) {
+	assignee, _ := entity["assignee"].(string)
+	if assignee == "" {
+		assignee = unassignedKey
+	}
+	if stats.ByAssignee == nil {
+		stats.ByAssignee = make(map[string]int)
+	}
+	stats.ByAssignee[assignee]++
+
+	labels, _ := entity["labels"].([]interface{})
+	for _, raw := range labels {
+		label, ok := raw.(string)
+		if !ok || label == "" {
+			continue
+		}
+		if stats.ByLabel == nil {
+			stats.ByLabel = make(map[string]int)
+		}
+		stats.ByLabel[label]++
+	}
+}
+
+// openWork is the number of open features, tasks and issues under one key
+type openWork struct {
+	features, tasks, issues int
+}
+
+// tallyOpenWork totals one per-key breakdown of EntityStats across projects
+func tallyOpenWork(projects []ProjectSummary, breakdown func(*domain.EntityStats) map[string]int) map[string]*openWork {
+	totals := make(map[string]*openWork)
+	entry := func(key string) *openWork {
+		if _, ok := totals[key]; !ok {
+			totals[key] = &openWork{}
+		}
+		return totals[key]
+	}
+	for _, project := range projects {
+		for key, n := range breakdown(&project.Stats.Features) {
+			entry(key).features += n
+		}
+		for key, n := range breakdown(&project.Stats.Tasks) {
+			entry(key).tasks += n
+		}
+		for key, n := range breakdown(&project.Stats.Issues) {
+			entry(key).issues += n
+		}
+	}
+	return totals
+}
+
+// summarizeAssignees totals the per-assignee counts of every project, sorted by
+// assignee with unassigned work last
+func summarizeAssignees(projects []ProjectSummary) []AssigneeSummary {
+	totals := tallyOpenWork(projects, func(stats *domain.EntityStats) map[string]int { return stats.ByAssignee })
+	summaries := make([]AssigneeSummary, 0, len(totals))
+	for name, work := range totals {
+		summaries = append(summaries, AssigneeSummary{Assignee: name, Features: work.features, Tasks: work.tasks, Issues: work.issues})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i].Assignee, summaries[j].Assignee
+		if (a == unassignedKey) != (b == unassignedKey) {
+			return b == unassignedKey
+		}
+		return a < b
+	})
+	return summaries
+}
+
+// summarizeLabels totals the per-label counts of every project, sorted by label
+func summarizeLabels(projects []ProjectSummary) []LabelSummary {
+	totals := tallyOpenWork(projects, func(stats *domain.EntityStats) map[string]int { return stats.ByLabel })
+	summaries := make([]LabelSummary, 0, len(totals))
+	for label, work := range totals {
+		summaries = append(summaries, LabelSummary{Label: label, Features: work.features, Tasks: work.tasks, Issues: work.issues})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Label < summaries[j].Label })
+	return summaries
+}
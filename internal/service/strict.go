@@ -0,0 +1,123 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+)
+
+// Strict mode is on for a project when its own Strict flag is set or the
+// workspace config has strict_mode enabled. It adds these rules:
+//
+//   - a task can only move to in_progress while its feature is active
+//   - a task can only be done when it has test_cases
+//   - a feature can only be done when every task has test_cases
+//   - an issue can only be resolved from in_progress
+
+// strictMode reports whether strict rules apply to projectID
+func strictMode(reader *fs.Reader, projectID string) (bool, error) {
+	project, err := reader.ReadProjectMetadata(projectID)
+	if err != nil {
+		return false, err
+	}
+	if project.Strict {
+		return true, nil
+	}
+
+	ws, err := reader.ReadWorkspace()
+	if err != nil {
+		return false, err
+	}
+	return ws.Config.StrictMode, nil
+}
+
+// checkStrictTask validates a task status change under strict mode
+func (s *TaskService) checkStrictTask(projectID string, before, after *domain.Task) error {
+	if before.Status == after.Status {
+		return nil
+	}
+	strict, err := strictMode(s.reader, projectID)
+	if err != nil || !strict {
+		return err
+	}
+
+	switch after.Status {
+	case domain.TaskStatusInProgress:
+		feature, err := s.reader.ReadFeature(projectID, after.FeatureID)
+		if err != nil {
+			return err
+		}
+		if feature.Status != domain.FeatureStatusActive {
+			return domain.NewValidationError(fmt.Sprintf(
+				"Strict mode: cannot start task %s while feature %s is %s. Set the feature to active first.",
+				after.ID, feature.ID, feature.Status,
+			))
+		}
+	case domain.TaskStatusDone:
+		if len(after.TestCases) == 0 {
+			return domain.NewValidationError(fmt.Sprintf(
+				"Strict mode: task %s cannot be done without test cases. Add them with --test-cases.", after.ID,
+			))
+		}
+	}
+	return nil
+}
+
+// checkStrictFeature validates a feature status change under strict mode
+func (s *FeatureService) checkStrictFeature(projectID string, before, after *domain.Feature) error {
+	if before.Status == after.Status || after.Status != domain.FeatureStatusDone {
+		return nil
+	}
+	strict, err := strictMode(s.reader, projectID)
+	if err != nil || !strict {
+		return err
+	}
+
+	var untested []string
+	err = s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
+		var t domain.Task
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return err
+		}
+		if t.FeatureID != after.ID || t.Status == domain.TaskStatusCancelled {
+			return nil
+		}
+		if len(t.TestCases) == 0 {
+			untested = append(untested, t.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(untested) > 0 {
+		return domain.NewValidationError(fmt.Sprintf(
+			"Strict mode: feature %s has task(s) without test cases:\n  %s",
+			after.ID, strings.Join(untested, "\n  "),
+		))
+	}
+	return nil
+}
+
+// checkStrictIssue validates an issue status change under strict mode
+func (s *IssueService) checkStrictIssue(projectID string, before, after *domain.Issue) error {
+	if before.Status == after.Status || after.Status != domain.IssueStatusResolved {
+		return nil
+	}
+	strict, err := strictMode(s.reader, projectID)
+	if err != nil || !strict {
+		return err
+	}
+
+	if before.Status != domain.IssueStatusInProgress {
+		return domain.NewValidationError(fmt.Sprintf(
+			"Strict mode: issue %s must be in_progress before it is resolved (currently %s). Use --start first.",
+			after.ID, before.Status,
+		))
+	}
+	return nil
+}
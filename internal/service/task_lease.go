@@ -0,0 +1,338 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"mandor/internal/domain"
+)
+
+// Agents claim a ready task for a lease. The claim moves the task to
+// in_progress; the agent extends the lease with a heartbeat and gives the task
+// back with release. A claim whose lease runs out is reaped back to ready by
+// the next mandor command.
+
+// ValidateClaimInput checks the agent, the lease and the optional filters
+func (s *TaskService) ValidateClaimInput(input *domain.TaskClaimInput) error {
+	if err := validateLease(input.Agent, input.Lease); err != nil {
+		return err
+	}
+	if input.ProjectID != "" && !s.reader.ProjectExists(input.ProjectID) {
+		return domain.NewValidationError("Project not found: " + input.ProjectID)
+	}
+	if input.FeatureID != "" {
+		projectID, err := s.extractProjectIDFromFeatureID(input.FeatureID)
+		if err != nil {
+			return err
+		}
+		if _, err := s.reader.ReadFeature(projectID, input.FeatureID); err != nil {
+			return domain.NewValidationError("Feature not found: " + input.FeatureID)
+		}
+	}
+	return nil
+}
+
+func (s *TaskService) ValidateHeartbeatInput(input *domain.TaskHeartbeatInput) error {
+	return validateLease(input.Agent, input.Lease)
+}
+
+func (s *TaskService) ValidateReleaseInput(input *domain.TaskReleaseInput) error {
+	if strings.TrimSpace(input.Agent) == "" {
+		return domain.NewValidationError("Agent name is required (--agent).")
+	}
+	return nil
+}
+
+func validateLease(agent string, lease time.Duration) error {
+	if strings.TrimSpace(agent) == "" {
+		return domain.NewValidationError("Agent name is required (--agent).")
+	}
+	if lease <= 0 {
+		return domain.NewValidationError("Lease must be a positive duration (--lease), e.g. 30m.")
+	}
+	return nil
+}
+
+// ClaimTask moves the most urgent ready task to in_progress and records
+// input.Agent as its claimant until the lease runs out. Ties on priority go to
+// the oldest task. The workspace lock makes the pick atomic, so two agents
+// never claim the same task.
+func (s *TaskService) ClaimTask(input *domain.TaskClaimInput) (*domain.Task, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	all, err := s.workspaceTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*domain.Task
+	for _, t := range all {
+		if t.Status != domain.TaskStatusReady {
+			continue
+		}
+		if input.ProjectID != "" && t.ProjectID != input.ProjectID {
+			continue
+		}
+		if input.FeatureID != "" && t.FeatureID != input.FeatureID {
+			continue
+		}
+		candidates = append(candidates, t)
+	}
+
+	ranker := newPriorityRanker(s.reader)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri := ranker.Rank(candidates[i].ProjectID, candidates[i].Priority)
+		rj := ranker.Rank(candidates[j].ProjectID, candidates[j].Priority)
+		if ri != rj {
+			return ri < rj
+		}
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+
+	now := time.Now().UTC()
+	expires := now.Add(input.Lease)
+	for _, task := range candidates {
+		before := *task
+		task.Status = domain.TaskStatusInProgress
+		task.ClaimedBy = input.Agent
+		task.LeaseExpiresAt = &expires
+		task.UpdatedAt = now
+		task.UpdatedBy = input.Agent
+
+		// Strict mode may refuse to start a task; try the next one
+		if err := s.checkStrictTask(task.ProjectID, &before, task); err != nil {
+			var mErr *domain.MandorError
+			if errors.As(err, &mErr) && mErr.Code == domain.ExitValidationError {
+				continue
+			}
+			return nil, err
+		}
+
+		if err := s.writer.ReplaceTask(task.ProjectID, task); err != nil {
+			return nil, err
+		}
+		changes := []string{"status", "claimed_by", "lease_expires_at"}
+		event := &domain.TaskEvent{
+			Layer:    "task",
+			Type:     "claimed",
+			ID:       task.ID,
+			By:       input.Agent,
+			Ts:       now,
+			Changes:  changes,
+			Diff:     domain.DiffFields(before, task, changes),
+			Snapshot: task,
+		}
+		if err := s.writer.AppendTaskEvent(task.ProjectID, event); err != nil {
+			return nil, err
+		}
+
+		// Starting a task moves its feature and project along, as in UpdateTask
+		if _, err := NewFeatureServiceWithPaths(s.paths).rollupFromTasks(task.ProjectID, task.FeatureID); err != nil {
+			return nil, err
+		}
+		if _, err := NewProjectServiceWithPaths(s.paths).activateProject(task.ProjectID); err != nil {
+			return nil, err
+		}
+		return task, nil
+	}
+
+	return nil, domain.NewValidationError("No ready tasks to claim.")
+}
+
+// HeartbeatTask extends the lease of a task claimed by input.Agent
+func (s *TaskService) HeartbeatTask(input *domain.TaskHeartbeatInput) (*domain.Task, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	task, err := s.claimedTask(input.TaskID, input.Agent)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *task
+	now := time.Now().UTC()
+	expires := now.Add(input.Lease)
+	task.LeaseExpiresAt = &expires
+	task.UpdatedAt = now
+	task.UpdatedBy = input.Agent
+
+	if err := s.writer.ReplaceTask(task.ProjectID, task); err != nil {
+		return nil, err
+	}
+	changes := []string{"lease_expires_at"}
+	event := &domain.TaskEvent{
+		Layer:    "task",
+		Type:     "heartbeat",
+		ID:       task.ID,
+		By:       input.Agent,
+		Ts:       now,
+		Changes:  changes,
+		Diff:     domain.DiffFields(before, task, changes),
+		Snapshot: task,
+	}
+	if err := s.writer.AppendTaskEvent(task.ProjectID, event); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ReleaseTask gives a task claimed by input.Agent back: the claim is cleared
+// and the task returns to ready, or to blocked if a dependency was added while
+// it was claimed
+func (s *TaskService) ReleaseTask(input *domain.TaskReleaseInput) (*domain.Task, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	task, err := s.claimedTask(input.TaskID, input.Agent)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *task
+	now := time.Now().UTC()
+	if err := s.returnToReady(task, now); err != nil {
+		return nil, err
+	}
+	task.UpdatedBy = input.Agent
+
+	if err := s.writer.ReplaceTask(task.ProjectID, task); err != nil {
+		return nil, err
+	}
+	changes := []string{"status", "claimed_by", "lease_expires_at"}
+	event := &domain.TaskEvent{
+		Layer:    "task",
+		Type:     "released",
+		ID:       task.ID,
+		By:       input.Agent,
+		Ts:       now,
+		Changes:  changes,
+		Diff:     domain.DiffFields(before, task, changes),
+		Snapshot: task,
+	}
+	if err := s.writer.AppendTaskEvent(task.ProjectID, event); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ReapExpiredLeases returns every in-progress task whose lease has run out to
+// ready with a system lease_expired event, and returns the reaped IDs. The
+// workspace lock is only taken when something has expired.
+func (s *TaskService) ReapExpiredLeases() ([]string, error) {
+	if !s.reader.WorkspaceExists() {
+		return nil, nil
+	}
+
+	now := time.Now().UTC()
+	all, err := s.workspaceTasks()
+	if err != nil {
+		return nil, err
+	}
+	expired := false
+	for _, t := range all {
+		if leaseExpired(t, now) {
+			expired = true
+			break
+		}
+	}
+	if !expired {
+		return nil, nil
+	}
+
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Another process may have reaped or renewed them while we waited
+	all, err = s.workspaceTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &taskPlan{}
+	changes := []string{"status", "claimed_by", "lease_expires_at"}
+	for _, t := range all {
+		if !leaseExpired(t, now) {
+			continue
+		}
+		before := *t
+		if err := s.returnToReady(t, now); err != nil {
+			return nil, err
+		}
+		plan.tasks = append(plan.tasks, t)
+		plan.events = append(plan.events, &domain.TaskEvent{
+			Layer:    "task",
+			Type:     "lease_expired",
+			ID:       t.ID,
+			By:       "system",
+			Ts:       now,
+			Changes:  changes,
+			Diff:     domain.DiffFields(before, t, changes),
+			Snapshot: t,
+		})
+	}
+
+	if err := s.applyPlan(plan); err != nil {
+		return nil, err
+	}
+	return plan.ids(), nil
+}
+
+// claimedTask reads taskID and requires it to be in progress under agent's claim
+func (s *TaskService) claimedTask(taskID, agent string) (*domain.Task, error) {
+	projectID, _, err := s.ParseTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.reader.ReadTask(projectID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case task.Status != domain.TaskStatusInProgress || task.ClaimedBy == "":
+		return nil, domain.NewValidationError(fmt.Sprintf("Task %s is not claimed. Use `mandor task claim` first.", taskID))
+	case task.ClaimedBy != agent:
+		return nil, domain.NewValidationError(fmt.Sprintf("Task %s is claimed by %s.", taskID, task.ClaimedBy))
+	}
+	return task, nil
+}
+
+// returnToReady clears the claim on task and moves it back to ready, or to
+// blocked when one of its dependencies is no longer done
+func (s *TaskService) returnToReady(task *domain.Task, now time.Time) error {
+	task.Status = domain.TaskStatusReady
+	task.ClaimedBy = ""
+	task.LeaseExpiresAt = nil
+	task.UpdatedAt = now
+	_, err := s.recomputeBlockedStatus(task)
+	return err
+}
+
+// leaseExpired reports whether t is held by a claim whose lease has run out
+func leaseExpired(t *domain.Task, now time.Time) bool {
+	return t.Status == domain.TaskStatusInProgress && t.LeaseExpiresAt != nil && !t.LeaseExpiresAt.After(now)
+}
+
+// formatLease renders a lease expiry for detail output
+func formatLease(expires *time.Time) string {
+	if expires == nil {
+		return ""
+	}
+	return expires.Format(time.RFC3339)
+}
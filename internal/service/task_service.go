@@ -65,6 +65,21 @@ func (s *TaskService) ParseTaskID(taskID string) (projectID, featureID string, e
 	return projectID, featureID, nil
 }
 
+// ResolveTaskID expands a unique prefix or suffix of a task ID to the full ID
+func (s *TaskService) ResolveTaskID(ref string) (string, error) {
+	return idResolver{s.reader, s.paths}.resolve("task", "", ref)
+}
+
+// ResolveTaskIDs resolves each short task ID in refs
+func (s *TaskService) ResolveTaskIDs(refs []string) ([]string, error) {
+	return idResolver{s.reader, s.paths}.resolveAll("task", "", refs)
+}
+
+// ResolveFeatureID expands a unique prefix or suffix of a feature ID to the full ID
+func (s *TaskService) ResolveFeatureID(ref string) (string, error) {
+	return idResolver{s.reader, s.paths}.resolve("feature", "", ref)
+}
+
 func (s *TaskService) extractProjectIDFromFeatureID(featureID string) (string, error) {
 	parts := strings.Split(featureID, "-feature-")
 	if len(parts) != 2 {
@@ -131,19 +146,21 @@ func (s *TaskService) ValidateCreateInput(input *domain.TaskCreateInput) error {
 		return domain.NewValidationError("Library needs are required (--library-needs).")
 	}
 
-	// Apply default priority if not specified
+	// Apply the project's default priority if not specified
+	priorities, err := projectPriorityScheme(s.reader, projectID)
+	if err != nil {
+		return err
+	}
 	if input.Priority == "" {
-		// Use default priority from workspace config
-		ws, err := s.reader.ReadWorkspace()
-		if err == nil && ws.Config.DefaultPriority != "" {
-			input.Priority = ws.Config.DefaultPriority
-		} else {
-			input.Priority = "P3"
-		}
+		input.Priority = priorities.DefaultFor(s.reader, "P3")
 	}
 
-	if !domain.ValidatePriority(input.Priority) {
-		return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+	if err := priorities.Validate(input.Priority); err != nil {
+		return err
+	}
+
+	if err := validateProjectLabels(s.reader, projectID, input.Labels); err != nil {
+		return err
 	}
 
 	if err := s.validateDependencies(projectID, "", input.DependsOn); err != nil {
@@ -183,8 +200,8 @@ func (s *TaskService) validateDependencies(projectID, selfID string, dependsOn [
 			return err
 		}
 
-		if dep.Status == domain.TaskStatusCancelled || dep.Status == domain.TaskStatusDone {
-			return domain.NewValidationError(fmt.Sprintf("Dependency is not actionable: %s (status: %s)", depID, dep.Status))
+		if dep.Status == domain.TaskStatusCancelled {
+			return domain.NewValidationError("Dependency is cancelled: " + depID)
 		}
 	}
 
@@ -196,48 +213,37 @@ func (s *TaskService) validateDependencies(projectID, selfID string, dependsOn [
 }
 
 func (s *TaskService) validateNoCycle(projectID, selfID string, dependsOn []string) error {
-	visited := make(map[string]bool)
-	var dfs func(taskID string) bool
-
-	dfs = func(taskID string) bool {
-		if taskID == selfID {
-			return true
-		}
-		if visited[taskID] {
-			return false
-		}
-		visited[taskID] = true
+	allowed, err := cycleAllowed(s.reader, projectID, "task")
+	if err != nil || allowed {
+		return err
+	}
 
+	path := findCyclePath(selfID, dependsOn, func(taskID string) []string {
 		// Extract project ID from task ID for cross-project dependencies
 		depProjectID, _, err := s.ParseTaskID(taskID)
 		if err != nil {
-			return false
+			return nil
 		}
-
 		t, err := s.reader.ReadTask(depProjectID, taskID)
 		if err != nil {
-			return false
-		}
-
-		for _, dep := range t.DependsOn {
-			if dfs(dep) {
-				return true
-			}
-		}
-		return false
-	}
-
-	for _, depID := range dependsOn {
-		visited = make(map[string]bool)
-		if dfs(depID) {
-			return domain.NewValidationError("Circular dependency detected.")
+			return nil
 		}
+		return t.DependsOn
+	})
+	if path != nil {
+		return cycleError(path)
 	}
 
 	return nil
 }
 
 func (s *TaskService) CreateTask(input *domain.TaskCreateInput) (*domain.Task, error) {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	creator := util.GetGitUsername()
 	now := time.Now().UTC()
 
@@ -266,6 +272,8 @@ func (s *TaskService) CreateTask(input *domain.TaskCreateInput) (*domain.Task, e
 		TestCases:           input.TestCases,
 		DerivableFiles:      input.DerivableFiles,
 		LibraryNeeds:        input.LibraryNeeds,
+		Assignee:            strings.TrimSpace(input.Assignee),
+		Labels:              input.Labels,
 		CreatedAt:           now,
 		UpdatedAt:           now,
 		CreatedBy:           creator,
@@ -289,11 +297,12 @@ func (s *TaskService) CreateTask(input *domain.TaskCreateInput) (*domain.Task, e
 	}
 
 	event := &domain.TaskEvent{
-		Layer: "task",
-		Type:  "created",
-		ID:    taskID,
-		By:    creator,
-		Ts:    now,
+		Layer:    "task",
+		Type:     "created",
+		ID:       taskID,
+		By:       creator,
+		Ts:       now,
+		Snapshot: task,
 	}
 	if err := s.writer.AppendTaskEvent(projectID, event); err != nil {
 		return nil, err
@@ -301,11 +310,12 @@ func (s *TaskService) CreateTask(input *domain.TaskCreateInput) (*domain.Task, e
 
 	if task.Status == domain.TaskStatusReady && len(input.DependsOn) == 0 {
 		readyEvent := &domain.TaskEvent{
-			Layer: "task",
-			Type:  "ready",
-			ID:    taskID,
-			By:    "system",
-			Ts:    now,
+			Layer:    "task",
+			Type:     "ready",
+			ID:       taskID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: task,
 		}
 		if err := s.writer.AppendTaskEvent(projectID, readyEvent); err != nil {
 			return nil, err
@@ -314,11 +324,12 @@ func (s *TaskService) CreateTask(input *domain.TaskCreateInput) (*domain.Task, e
 
 	if task.Status == domain.TaskStatusBlocked {
 		blockedEvent := &domain.TaskEvent{
-			Layer: "task",
-			Type:  "blocked",
-			ID:    taskID,
-			By:    "system",
-			Ts:    now,
+			Layer:    "task",
+			Type:     "blocked",
+			ID:       taskID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: task,
 		}
 		if err := s.writer.AppendTaskEvent(projectID, blockedEvent); err != nil {
 			return nil, err
@@ -340,7 +351,7 @@ func (s *TaskService) checkDependenciesDone(projectID string, dependsOn []string
 		if err != nil {
 			return false, domain.NewValidationError("Dependency not found: " + depID)
 		}
-		if dep.Status != domain.TaskStatusDone && dep.Status != domain.TaskStatusCancelled {
+		if dep.Status != domain.TaskStatusDone {
 			return false, nil
 		}
 	}
@@ -348,6 +359,26 @@ func (s *TaskService) checkDependenciesDone(projectID string, dependsOn []string
 }
 
 func (s *TaskService) ListTasks(input *domain.TaskListInput) (*domain.TaskListOutput, error) {
+	if input.Priority != "" {
+		filterProject := input.ProjectID
+		if filterProject == "" && input.FeatureID != "" {
+			filterProject, _ = s.extractProjectIDFromFeatureID(input.FeatureID)
+		}
+		var priorities *PriorityScheme
+		var err error
+		if filterProject != "" && s.reader.ProjectExists(filterProject) {
+			priorities, err = projectPriorityScheme(s.reader, filterProject)
+		} else {
+			priorities, err = workspacePriorityScheme(s.reader)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := priorities.Validate(input.Priority); err != nil {
+			return nil, err
+		}
+	}
+
 	var tasks []domain.TaskListItem
 	deletedCount := 0
 
@@ -379,6 +410,14 @@ func (s *TaskService) ListTasks(input *domain.TaskListInput) (*domain.TaskListOu
 				return nil
 			}
 
+			if input.Assignee != "" && t.Assignee != input.Assignee {
+				return nil
+			}
+
+			if !matchLabels(t.Labels, input.Labels, input.LabelMatch) {
+				return nil
+			}
+
 			if !input.IncludeDeleted && t.Status == domain.TaskStatusCancelled {
 				deletedCount++
 				return nil
@@ -392,6 +431,9 @@ func (s *TaskService) ListTasks(input *domain.TaskListInput) (*domain.TaskListOu
 				FeatureID:      t.FeatureID,
 				ProjectID:      t.ProjectID,
 				DependsOnCount: len(t.DependsOn),
+				Assignee:       t.Assignee,
+				Labels:         t.Labels,
+				ClaimedBy:      t.ClaimedBy,
 				CreatedAt:      t.CreatedAt.Format(time.RFC3339),
 				UpdatedAt:      t.UpdatedAt.Format(time.RFC3339),
 			}
@@ -414,13 +456,16 @@ func (s *TaskService) ListTasks(input *domain.TaskListInput) (*domain.TaskListOu
 	}
 	orderDesc := input.Order != "asc"
 
-	sort.Slice(tasks, func(i, j int) bool {
+	ranker := newPriorityRanker(s.reader)
+	sort.SliceStable(tasks, func(i, j int) bool {
 		switch sortBy {
 		case "priority":
+			ri := ranker.Rank(tasks[i].ProjectID, tasks[i].Priority)
+			rj := ranker.Rank(tasks[j].ProjectID, tasks[j].Priority)
 			if orderDesc {
-				return comparePriority(tasks[i].Priority, tasks[j].Priority) > 0
+				return ri > rj
 			}
-			return comparePriority(tasks[i].Priority, tasks[j].Priority) < 0
+			return ri < rj
 		case "created_at":
 			if orderDesc {
 				return tasks[i].CreatedAt > tasks[j].CreatedAt
@@ -443,16 +488,6 @@ func (s *TaskService) ListTasks(input *domain.TaskListInput) (*domain.TaskListOu
 	}, nil
 }
 
-func comparePriority(p1, p2 string) int {
-	levels := []string{"P0", "P1", "P2", "P3", "P4", "P5"}
-	for i, level := range levels {
-		if p1 == level {
-			return i
-		}
-	}
-	return 3
-}
-
 func (s *TaskService) GetTaskDetail(input *domain.TaskDetailInput) (*domain.TaskDetailOutput, error) {
 	projectID, _, err := s.ParseTaskID(input.TaskID)
 	if err != nil {
@@ -470,6 +505,11 @@ func (s *TaskService) GetTaskDetail(input *domain.TaskDetailInput) (*domain.Task
 
 	events, _ := s.reader.CountEventLines(projectID)
 
+	comments, commentCount, err := entityComments(s.reader, s.paths, projectID, task.ID, input.LastComments)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.TaskDetailOutput{
 		ID:                  task.ID,
 		FeatureID:           task.FeatureID,
@@ -484,7 +524,13 @@ func (s *TaskService) GetTaskDetail(input *domain.TaskDetailInput) (*domain.Task
 		TestCases:           task.TestCases,
 		DerivableFiles:      task.DerivableFiles,
 		LibraryNeeds:        task.LibraryNeeds,
+		Assignee:            task.Assignee,
+		Labels:              task.Labels,
+		ClaimedBy:           task.ClaimedBy,
+		LeaseExpiresAt:      formatLease(task.LeaseExpiresAt),
 		Events:              events,
+		CommentCount:        commentCount,
+		Comments:            comments,
 		CreatedAt:           task.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:           task.UpdatedAt.Format(time.RFC3339),
 		CreatedBy:           task.CreatedBy,
@@ -503,8 +549,8 @@ func (s *TaskService) ValidateUpdateInput(input *domain.TaskUpdateInput) error {
 		return err
 	}
 
-	if task.Status == domain.TaskStatusDone {
-		return domain.NewValidationError("Cannot modify done task.")
+	if task.Status == domain.TaskStatusDone && !input.Reopen {
+		return domain.NewValidationError("Cannot modify done task. Use --reopen to reopen it.")
 	}
 
 	if task.Status == domain.TaskStatusCancelled && !input.Reopen && !input.Cancel {
@@ -515,40 +561,29 @@ func (s *TaskService) ValidateUpdateInput(input *domain.TaskUpdateInput) error {
 		return domain.NewValidationError("Task name cannot be empty.")
 	}
 
-	if input.Priority != nil && !domain.ValidatePriority(*input.Priority) {
-		return domain.NewValidationError("Invalid priority. Valid options: P0, P1, P2, P3, P4, P5")
+	if input.Priority != nil {
+		if err := validateProjectPriority(s.reader, projectID, *input.Priority); err != nil {
+			return err
+		}
 	}
 
 	if input.Status != nil && !domain.ValidateTaskStatus(*input.Status) {
 		return domain.NewValidationError("Invalid status. Valid options: pending, ready, in_progress, blocked, done, cancelled")
 	}
 
-	if input.DependsOn != nil {
-		if err := s.validateDependencies(projectID, input.TaskID, *input.DependsOn); err != nil {
-			return err
-		}
+	cancelling := input.Cancel || (input.Status != nil && *input.Status == domain.TaskStatusCancelled)
+	if cancelling && input.Reopen {
+		return domain.NewValidationError("Use only one of --reopen or --cancel.")
 	}
-
-	if input.DependsAdd != nil {
-		allDeps := append(task.DependsOn, *input.DependsAdd...)
-		if err := s.validateDependencies(projectID, input.TaskID, allDeps); err != nil {
-			return err
-		}
+	if err := validateCancelPolicyFlags(cancelling, input.Cascade, input.Rewire, input.KeepBlocked); err != nil {
+		return err
+	}
+	if _, err := resolveCancelPolicy(input.Cascade, input.Rewire, input.KeepBlocked, input.Force); err != nil {
+		return err
 	}
 
-	if input.DependsRemove != nil {
-		depSet := make(map[string]bool)
-		for _, dep := range task.DependsOn {
-			depSet[dep] = true
-		}
-		for _, remove := range *input.DependsRemove {
-			delete(depSet, remove)
-		}
-		var remaining []string
-		for dep := range depSet {
-			remaining = append(remaining, dep)
-		}
-		if err := s.validateDependencies(projectID, input.TaskID, remaining); err != nil {
+	if deps, changed := applyDependencyChanges(task.DependsOn, input.DependsOn, input.DependsAdd, input.DependsRemove); changed {
+		if err := s.validateDependencies(projectID, input.TaskID, addedDependencies(task.DependsOn, deps)); err != nil {
 			return err
 		}
 	}
@@ -557,27 +592,48 @@ func (s *TaskService) ValidateUpdateInput(input *domain.TaskUpdateInput) error {
 }
 
 func (s *TaskService) UpdateTask(input *domain.TaskUpdateInput) ([]string, error) {
-	projectID, _, err := s.ParseTaskID(input.TaskID)
+	return s.updateTask(input, nil)
+}
+
+// PreviewTaskUpdate runs input through the whole update in memory and returns
+// what it would change, without writing anything
+func (s *TaskService) PreviewTaskUpdate(input *domain.TaskUpdateInput) (*domain.UpdatePreview, error) {
+	dryRun := *input
+	dryRun.DryRun = true
+	preview := &domain.UpdatePreview{}
+	if _, err := s.updateTask(&dryRun, preview); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// updateTask applies input. On a dry run it stops before the first write and
+// fills preview, when given, with the computed change set.
+func (s *TaskService) updateTask(input *domain.TaskUpdateInput, preview *domain.UpdatePreview) ([]string, error) {
+	unlock, err := s.writer.Lock()
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
 
-	task, err := s.reader.ReadTask(projectID, input.TaskID)
+	projectID, _, err := s.ParseTaskID(input.TaskID)
 	if err != nil {
 		return nil, err
 	}
 
-	if input.DryRun {
-		return []string{"[DRY RUN] Would update task: " + input.TaskID}, nil
+	task, err := s.reader.ReadTask(projectID, input.TaskID)
+	if err != nil {
+		return nil, err
 	}
 
+	before := *task
 	var changes []string
 	updater := util.GetGitUsername()
 	now := time.Now().UTC()
 
 	if input.Reopen {
-		if task.Status != domain.TaskStatusCancelled {
-			return nil, domain.NewValidationError("Task is not cancelled. Nothing to reopen.")
+		if task.Status != domain.TaskStatusCancelled && task.Status != domain.TaskStatusDone {
+			return nil, domain.NewValidationError("Task is not done or cancelled. Nothing to reopen.")
 		}
 		task.Status = domain.TaskStatusPending
 		task.Reason = ""
@@ -589,14 +645,6 @@ func (s *TaskService) UpdateTask(input *domain.TaskUpdateInput) ([]string, error
 			return nil, domain.NewValidationError("Task is already cancelled.")
 		}
 
-		dependents, err := s.findDependents(projectID, input.TaskID)
-		if err != nil {
-			return nil, err
-		}
-		if len(dependents) > 0 && !input.Force {
-			return nil, domain.NewValidationError("Task has " + fmt.Sprintf("%d", len(dependents)) + " dependent(s). Use --force to cancel anyway.")
-		}
-
 		if input.Reason == nil || *input.Reason == "" {
 			return nil, domain.NewValidationError("Cancellation reason is required (--reason).")
 		}
@@ -621,6 +669,20 @@ func (s *TaskService) UpdateTask(input *domain.TaskUpdateInput) ([]string, error
 		changes = append(changes, "priority")
 	}
 
+	if input.Assignee != nil && strings.TrimSpace(*input.Assignee) != task.Assignee {
+		task.Assignee = strings.TrimSpace(*input.Assignee)
+		changes = append(changes, "assignee")
+	}
+
+	labels, labelsChanged := applyLabelChanges(task.Labels, input.Labels, input.LabelsAdd, input.LabelsRemove)
+	if labelsChanged {
+		if err := validateProjectLabels(s.reader, projectID, addedDependencies(task.Labels, labels)); err != nil {
+			return nil, err
+		}
+		task.Labels = labels
+		changes = append(changes, "labels")
+	}
+
 	if input.ImplementationSteps != nil {
 		task.ImplementationSteps = *input.ImplementationSteps
 		changes = append(changes, "implementation_steps")
@@ -641,38 +703,122 @@ func (s *TaskService) UpdateTask(input *domain.TaskUpdateInput) ([]string, error
 		changes = append(changes, "library_needs")
 	}
 
-	if input.DependsOn != nil {
-		task.DependsOn = *input.DependsOn
+	deps, depsChanged := applyDependencyChanges(task.DependsOn, input.DependsOn, input.DependsAdd, input.DependsRemove)
+	if depsChanged {
+		if err := s.validateDependencies(projectID, input.TaskID, addedDependencies(task.DependsOn, deps)); err != nil {
+			return nil, err
+		}
+		task.DependsOn = deps
 		changes = append(changes, "depends_on")
 	}
 
-	if input.DependsAdd != nil {
-		task.DependsOn = append(task.DependsOn, *input.DependsAdd...)
-		changes = append(changes, "depends_on")
+	if input.Status != nil && *input.Status != task.Status {
+		if err := s.validateStatusTransition(task.Status, *input.Status); err != nil {
+			return nil, err
+		}
+		task.Status = *input.Status
+		changes = append(changes, "status")
+	}
+
+	// A claim only lasts while the task is in progress
+	if task.Status != domain.TaskStatusInProgress && task.ClaimedBy != "" {
+		task.ClaimedBy = ""
+		task.LeaseExpiresAt = nil
+		changes = append(changes, "claimed_by", "lease_expires_at")
+	}
+
+	if input.Priority != nil {
+		if err := validateProjectPriority(s.reader, projectID, task.Priority); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkStrictTask(projectID, &before, task); err != nil {
+		return nil, err
 	}
 
-	if input.DependsRemove != nil {
-		depSet := make(map[string]bool)
-		for _, dep := range task.DependsOn {
-			depSet[dep] = true
+	// A dependency change can block a ready task or release a blocked one
+	recomputed := ""
+	if depsChanged && input.Status == nil {
+		recomputed, err = s.recomputeBlockedStatus(task)
+		if err != nil {
+			return nil, err
 		}
-		for _, remove := range *input.DependsRemove {
-			delete(depSet, remove)
+	}
+
+	// Dependents of a cancelled task follow the chosen policy
+	cancelling := task.Status == domain.TaskStatusCancelled && before.Status != domain.TaskStatusCancelled
+	cancelPlan := &taskPlan{}
+	if cancelling {
+		policy, err := resolveCancelPolicy(input.Cascade, input.Rewire, input.KeepBlocked, input.Force)
+		if err != nil {
+			return nil, err
 		}
-		var remaining []string
-		for dep := range depSet {
-			remaining = append(remaining, dep)
+		cancelPlan, err = s.planCancelDependents(task, policy, input.Rewire, updater, now)
+		if err != nil {
+			return nil, err
 		}
-		task.DependsOn = remaining
-		changes = append(changes, "depends_on")
 	}
 
-	if input.Status != nil && *input.Status != task.Status {
-		if err := s.validateStatusTransition(task.Status, *input.Status); err != nil {
+	unblockPlan := &taskPlan{}
+	if task.Status == domain.TaskStatusDone && before.Status != domain.TaskStatusDone {
+		unblockPlan, err = s.planUnblockDependents(input.TaskID, now)
+		if err != nil {
 			return nil, err
 		}
-		task.Status = *input.Status
-		changes = append(changes, "status")
+	}
+
+	reblockPlan := &taskPlan{}
+	if isTaskFinished(before.Status) && !isTaskFinished(task.Status) && !cancelling {
+		reblockPlan, err = s.planReblockDependents(input.TaskID, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if input.DryRun {
+		diffFields := changes
+		if recomputed != "" {
+			diffFields = append(diffFields, "status")
+		}
+
+		// The feature and project follow the task as they would on a real run
+		var rollups []domain.StatusChange
+		if task.Status != before.Status {
+			feature, target, err := NewFeatureServiceWithPaths(s.paths).planRollup(projectID, task.FeatureID, task)
+			if err != nil {
+				return nil, err
+			}
+			if target != "" {
+				rollups = append(rollups, domain.StatusChange{Layer: "feature", ID: feature.ID, From: feature.Status, To: target})
+			}
+		}
+		if task.Status == domain.TaskStatusInProgress && before.Status != domain.TaskStatusInProgress {
+			activation, err := NewProjectServiceWithPaths(s.paths).planActivation(projectID)
+			if err != nil {
+				return nil, err
+			}
+			if activation != nil {
+				rollups = append(rollups, *activation)
+			}
+		}
+
+		p := &domain.UpdatePreview{
+			Layer:      "task",
+			ID:         input.TaskID,
+			Changes:    changes,
+			Diff:       domain.DiffFields(before, task, diffFields),
+			StatusFrom: before.Status,
+			StatusTo:   task.Status,
+			Unblocked:  unblockPlan.ids(),
+			Reblocked:  reblockPlan.ids(),
+			Rollups:    rollups,
+			Dependents: cancelPlan.lines,
+			Warnings:   append(cancelPlan.warnings, reblockPlan.warnings...),
+		}
+		if preview != nil {
+			*preview = *p
+		}
+		return p.Lines(), nil
 	}
 
 	task.UpdatedAt = now
@@ -682,29 +828,122 @@ func (s *TaskService) UpdateTask(input *domain.TaskUpdateInput) ([]string, error
 		return nil, err
 	}
 
-	if input.Status != nil && *input.Status == domain.TaskStatusDone {
-		unblocked, err := s.unblockDependents(projectID, input.TaskID)
+	if len(cancelPlan.tasks) > 0 {
+		changes = append(changes, cancelPlan.change)
+	}
+	if len(unblockPlan.tasks) > 0 {
+		changes = append(changes, "dependent_unblocked")
+	}
+	if len(reblockPlan.tasks) > 0 {
+		changes = append(changes, "dependent_blocked")
+	}
+	warnings := append(cancelPlan.warnings, reblockPlan.warnings...)
+
+	event := &domain.TaskEvent{
+		Layer:    "task",
+		Type:     "updated",
+		ID:       input.TaskID,
+		By:       updater,
+		Ts:       now,
+		Changes:  changes,
+		Diff:     domain.DiffFields(before, task, changes),
+		Snapshot: task,
+	}
+	if err := s.writer.AppendTaskEvent(projectID, event); err != nil {
+		return nil, err
+	}
+
+	if recomputed != "" {
+		statusEvent := &domain.TaskEvent{
+			Layer:    "task",
+			Type:     recomputed,
+			ID:       input.TaskID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: task,
+		}
+		if err := s.writer.AppendTaskEvent(projectID, statusEvent); err != nil {
+			return nil, err
+		}
+		changes = append(changes, "status_"+recomputed)
+	}
+
+	// Dependents are written after the task's own events
+	for _, plan := range []*taskPlan{cancelPlan, unblockPlan, reblockPlan} {
+		if err := s.applyPlan(plan); err != nil {
+			return nil, err
+		}
+	}
+
+	// The owning feature follows its tasks
+	if task.Status != before.Status {
+		featureStatus, err := NewFeatureServiceWithPaths(s.paths).rollupFromTasks(projectID, task.FeatureID)
 		if err != nil {
 			return nil, err
 		}
-		if unblocked {
-			changes = append(changes, "dependent_unblocked")
+		if featureStatus != "" {
+			changes = append(changes, "feature_"+featureStatus)
 		}
 	}
 
-	event := &domain.TaskEvent{
-		Layer:   "task",
-		Type:    "updated",
-		ID:      input.TaskID,
-		By:      updater,
-		Ts:      now,
-		Changes: changes,
+	// The first started task starts the project
+	if task.Status == domain.TaskStatusInProgress && before.Status != domain.TaskStatusInProgress {
+		activated, err := NewProjectServiceWithPaths(s.paths).activateProject(projectID)
+		if err != nil {
+			return nil, err
+		}
+		if activated {
+			changes = append(changes, "project_active")
+		}
 	}
-	if err := s.writer.AppendTaskEvent(projectID, event); err != nil {
+
+	// What happened to each dependent is reported, not recorded as a field
+	changes = append(changes, cancelPlan.lines...)
+	return append(changes, warnings...), nil
+}
+
+// recomputeBlockedStatus moves a ready task with an unfinished dependency to
+// blocked, and a blocked task whose dependencies are all finished to ready. It
+// returns the new status, or "" when the status did not change.
+func (s *TaskService) recomputeBlockedStatus(task *domain.Task) (string, error) {
+	if task.Status != domain.TaskStatusReady && task.Status != domain.TaskStatusBlocked {
+		return "", nil
+	}
+
+	allDone, err := s.checkDependenciesDone(task.ProjectID, task.DependsOn)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case task.Status == domain.TaskStatusReady && !allDone:
+		task.Status = domain.TaskStatusBlocked
+	case task.Status == domain.TaskStatusBlocked && allDone:
+		task.Status = domain.TaskStatusReady
+	default:
+		return "", nil
+	}
+	return task.Status, nil
+}
+
+func (s *TaskService) GetTaskEvents(taskID string) ([]domain.TaskEvent, error) {
+	projectID, _, err := s.ParseTaskID(taskID)
+	if err != nil {
 		return nil, err
 	}
 
-	return changes, nil
+	var events []domain.TaskEvent
+	err = s.reader.ReadNDJSON(s.paths.ProjectEventsPath(projectID), func(raw []byte) error {
+		var event domain.TaskEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return err
+		}
+		if event.Layer == "task" && event.ID == taskID {
+			events = append(events, event)
+		}
+		return nil
+	})
+	return events, err
 }
 
 func (s *TaskService) validateStatusTransition(current, next string) error {
@@ -729,182 +968,297 @@ func (s *TaskService) validateStatusTransition(current, next string) error {
 	return domain.NewValidationError(fmt.Sprintf("Invalid status transition from %s to %s", current, next))
 }
 
-func (s *TaskService) findDependents(projectID, taskID string) ([]string, error) {
-	var dependents []string
-	err := s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
-		var t domain.Task
-		if err := json.Unmarshal(raw, &t); err != nil {
-			return err
-		}
-		for _, dep := range t.DependsOn {
-			if dep == taskID {
-				dependents = append(dependents, t.ID)
-			}
-		}
-		return nil
-	})
-	return dependents, err
+// isTaskFinished reports whether status satisfies a dependency. A cancelled
+// task does not: its dependents stay blocked until they are rewired.
+func isTaskFinished(status string) bool {
+	return status == domain.TaskStatusDone
 }
 
-func (s *TaskService) unblockDependents(projectID, doneTaskID string) (bool, error) {
-	unblockedAny := false
-	now := time.Now().UTC()
+// taskPlan is a set of changes to other tasks that an update causes. It is
+// worked out before anything is written so a dry run can show it.
+type taskPlan struct {
+	// change is recorded in the causing update's event when the plan
+	// writes anything
+	change   string
+	lines    []string
+	warnings []string
+	tasks    []*domain.Task
+	events   []*domain.TaskEvent
+}
 
-	// First handle same-project dependencies
-	var allTasks []*domain.Task
-	err := s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
-		var task domain.Task
-		if err := json.Unmarshal(raw, &task); err != nil {
-			return err
+// ids returns the IDs of the tasks the plan changes
+func (p *taskPlan) ids() []string {
+	var ids []string
+	for _, t := range p.tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// workspaceTasks reads the tasks of every project
+func (s *TaskService) workspaceTasks() ([]*domain.Task, error) {
+	projects, err := s.reader.ListProjects(false)
+	if err != nil {
+		return nil, err
+	}
+	var all []*domain.Task
+	for _, projectID := range projects {
+		err := s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
+			var t domain.Task
+			if err := json.Unmarshal(raw, &t); err != nil {
+				return err
+			}
+			all = append(all, &t)
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
-		allTasks = append(allTasks, &task)
-		return nil
-	})
+	}
+	return all, nil
+}
+
+// planCancelDependents works out, without writing anything, how policy treats
+// the open tasks in every project that depend on cancelled
+func (s *TaskService) planCancelDependents(cancelled *domain.Task, policy cancelPolicy, rewire *string, updater string, now time.Time) (*taskPlan, error) {
+	all, err := s.workspaceTasks()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	// Track which tasks need to be written back
-	tasksToWrite := make(map[string]*domain.Task)
-	eventsToAppend := []*domain.TaskEvent{}
+	dependentsOf := func(id string) []*domain.Task {
+		var found []*domain.Task
+		for _, t := range all {
+			if !isTaskFinished(t.Status) && t.Status != domain.TaskStatusCancelled && containsID(t.DependsOn, id) {
+				found = append(found, t)
+			}
+		}
+		return found
+	}
+
+	plan := &taskPlan{}
+	direct := dependentsOf(cancelled.ID)
+	if len(direct) == 0 {
+		return plan, nil
+	}
+
+	systemEvent := func(t *domain.Task) *domain.TaskEvent {
+		snapshot := *t
+		return &domain.TaskEvent{Layer: "task", Type: t.Status, ID: t.ID, By: "system", Ts: now, Snapshot: &snapshot}
+	}
+	updatedEvent := func(before, t *domain.Task, changes []string) *domain.TaskEvent {
+		snapshot := *t
+		return &domain.TaskEvent{
+			Layer:    "task",
+			Type:     "updated",
+			ID:       t.ID,
+			By:       updater,
+			Ts:       now,
+			Changes:  changes,
+			Diff:     domain.DiffFields(*before, t, changes),
+			Snapshot: &snapshot,
+		}
+	}
 
-	// Process all tasks to find those that should unblock
-	for _, task := range allTasks {
-		if task.Status != domain.TaskStatusBlocked {
-			continue
+	switch policy {
+	case cancelPolicyNone:
+		var ids []string
+		for _, t := range direct {
+			ids = append(ids, fmt.Sprintf("%s (%s)", t.ID, t.Status))
 		}
+		return nil, dependentsError("Task", cancelled.ID, ids)
+
+	case cancelPolicyCascade:
+		plan.change = "dependent_cancelled"
+		seen := map[string]bool{cancelled.ID: true}
+		queue := direct
+		for len(queue) > 0 {
+			t := queue[0]
+			queue = queue[1:]
+			if seen[t.ID] {
+				continue
+			}
+			seen[t.ID] = true
 
-		hasDone := false
-		allDone := true
-		for _, depID := range task.DependsOn {
-			if depID == doneTaskID {
-				hasDone = true
+			// Started work is never thrown away; it and its dependents are left alone
+			if t.Status == domain.TaskStatusInProgress {
+				plan.warnings = append(plan.warnings, fmt.Sprintf("warning: %s is in progress; --cascade left it and its dependents alone", t.ID))
+				continue
 			}
-			// Parse the dependency ID to get the project it belongs to
-			depProjectID, _, err := s.ParseTaskID(depID)
-			if err != nil {
-				return false, err
+
+			before := *t
+			t.Status = domain.TaskStatusCancelled
+			t.Reason = cancelled.Reason
+			t.UpdatedAt = now
+			t.UpdatedBy = updater
+			plan.tasks = append(plan.tasks, t)
+			plan.events = append(plan.events, updatedEvent(&before, t, []string{"status", "reason"}))
+			plan.lines = append(plan.lines, "cancelled dependent: "+t.ID)
+			queue = append(queue, dependentsOf(t.ID)...)
+		}
+
+	case cancelPolicyRewire:
+		plan.change = "dependent_rewired"
+		replacementID := *rewire
+		if replacementID == cancelled.ID {
+			return nil, domain.NewValidationError("Cannot rewire dependents onto the task being cancelled.")
+		}
+		for _, t := range direct {
+			if err := s.validateDependencies(t.ProjectID, t.ID, []string{replacementID}); err != nil {
+				return nil, err
 			}
-			dep, err := s.reader.ReadTask(depProjectID, depID)
+			before := *t
+			t.DependsOn, _ = applyDependencyChanges(t.DependsOn, nil, &[]string{replacementID}, &[]string{cancelled.ID})
+			t.UpdatedAt = now
+			t.UpdatedBy = updater
+			plan.tasks = append(plan.tasks, t)
+			plan.events = append(plan.events, updatedEvent(&before, t, []string{"depends_on"}))
+			plan.lines = append(plan.lines, fmt.Sprintf("rewired dependent: %s (%s -> %s)", t.ID, cancelled.ID, replacementID))
+
+			status, err := s.recomputeBlockedStatus(t)
 			if err != nil {
-				return false, err
+				return nil, err
 			}
-			if dep.Status != domain.TaskStatusDone && dep.Status != domain.TaskStatusCancelled {
-				allDone = false
+			if status != "" {
+				plan.events = append(plan.events, systemEvent(t))
 			}
 		}
 
-		if hasDone && allDone {
-			task.Status = domain.TaskStatusReady
-			task.UpdatedAt = now
-			tasksToWrite[task.ID] = task
-			unblockedAny = true
-
-			event := &domain.TaskEvent{
-				Layer: "task",
-				Type:  "ready",
-				ID:    task.ID,
-				By:    "system",
-				Ts:    now,
-			}
-			eventsToAppend = append(eventsToAppend, event)
-		}
-	}
-
-	// If we have same-project updates, write them
-	if unblockedAny {
-		if err := s.writer.ReplaceTasks(projectID, allTasks, tasksToWrite); err != nil {
-			return false, err
-		}
-		for _, event := range eventsToAppend {
-			if err := s.writer.AppendTaskEvent(projectID, event); err != nil {
-				return false, err
+	case cancelPolicyKeepBlocked:
+		plan.change = "dependent_blocked"
+		for _, t := range direct {
+			switch t.Status {
+			case domain.TaskStatusReady:
+				t.Status = domain.TaskStatusBlocked
+				t.UpdatedAt = now
+				plan.tasks = append(plan.tasks, t)
+				plan.events = append(plan.events, systemEvent(t))
+				plan.lines = append(plan.lines, "blocked dependent: "+t.ID)
+			case domain.TaskStatusInProgress:
+				plan.warnings = append(plan.warnings, fmt.Sprintf("warning: %s is in progress but its dependency %s is cancelled", t.ID, cancelled.ID))
+			default:
+				plan.lines = append(plan.lines, fmt.Sprintf("kept blocked: %s (%s)", t.ID, t.Status))
 			}
 		}
 	}
 
-	// Now handle cross-project dependencies: find all projects and check for tasks that depend on doneTaskID
-	projects, err := s.reader.ListProjects(false)
-	if err != nil {
-		return unblockedAny, err
-	}
+	return plan, nil
+}
 
-	for _, otherProjectID := range projects {
-		if otherProjectID == projectID {
-			continue // Already handled
+// applyPlan writes the tasks changed by a plan and their events
+func (s *TaskService) applyPlan(plan *taskPlan) error {
+	var projectIDs []string
+	toWrite := make(map[string]map[string]*domain.Task)
+	for _, t := range plan.tasks {
+		if toWrite[t.ProjectID] == nil {
+			toWrite[t.ProjectID] = make(map[string]*domain.Task)
+			projectIDs = append(projectIDs, t.ProjectID)
 		}
+		toWrite[t.ProjectID][t.ID] = t
+	}
 
-		var otherProjectTasks []*domain.Task
-		err := s.reader.ReadNDJSON(s.paths.ProjectTasksPath(otherProjectID), func(raw []byte) error {
-			var task domain.Task
-			if err := json.Unmarshal(raw, &task); err != nil {
+	for _, projectID := range projectIDs {
+		var all []*domain.Task
+		err := s.reader.ReadNDJSON(s.paths.ProjectTasksPath(projectID), func(raw []byte) error {
+			var t domain.Task
+			if err := json.Unmarshal(raw, &t); err != nil {
 				return err
 			}
-			otherProjectTasks = append(otherProjectTasks, &task)
+			all = append(all, &t)
 			return nil
 		})
 		if err != nil {
-			continue // Skip if project tasks can't be read
+			return err
 		}
+		if err := s.writer.ReplaceTasks(projectID, all, toWrite[projectID]); err != nil {
+			return err
+		}
+	}
 
-		otherTasksToWrite := make(map[string]*domain.Task)
-		otherEventsToAppend := []*domain.TaskEvent{}
-
-		// Process all tasks in other project
-		for _, task := range otherProjectTasks {
-			if task.Status != domain.TaskStatusBlocked {
-				continue
-			}
+	for _, event := range plan.events {
+		if err := s.writer.AppendTaskEvent(event.Snapshot.ProjectID, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			hasDone := false
-			allDone := true
-			for _, depID := range task.DependsOn {
-				if depID == doneTaskID {
-					hasDone = true
-				}
-				depProjectID, _, err := s.ParseTaskID(depID)
-				if err != nil {
-					continue
-				}
-				dep, err := s.reader.ReadTask(depProjectID, depID)
-				if err != nil {
-					allDone = false
-					continue
-				}
-				if dep.Status != domain.TaskStatusDone && dep.Status != domain.TaskStatusCancelled {
-					allDone = false
-				}
-			}
+// planUnblockDependents works out which blocked tasks, in any project, become
+// ready once doneTaskID is done. doneTaskID counts as done even before it is
+// written so the plan can be shown on a dry run.
+func (s *TaskService) planUnblockDependents(doneTaskID string, now time.Time) (*taskPlan, error) {
+	all, err := s.workspaceTasks()
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]string, len(all))
+	for _, t := range all {
+		status[t.ID] = t.Status
+	}
+	status[doneTaskID] = domain.TaskStatusDone
 
-			if hasDone && allDone {
-				task.Status = domain.TaskStatusReady
-				task.UpdatedAt = now
-				otherTasksToWrite[task.ID] = task
-				unblockedAny = true
-
-				event := &domain.TaskEvent{
-					Layer: "task",
-					Type:  "ready",
-					ID:    task.ID,
-					By:    "system",
-					Ts:    now,
-				}
-				otherEventsToAppend = append(otherEventsToAppend, event)
-			}
+	plan := &taskPlan{}
+	for _, t := range all {
+		if t.Status != domain.TaskStatusBlocked || !containsID(t.DependsOn, doneTaskID) {
+			continue
 		}
-
-		// Write updates for other project if any
-		if len(otherTasksToWrite) > 0 {
-			if err := s.writer.ReplaceTasks(otherProjectID, otherProjectTasks, otherTasksToWrite); err != nil {
-				continue // Skip error for other project
-			}
-			for _, event := range otherEventsToAppend {
-				if err := s.writer.AppendTaskEvent(otherProjectID, event); err != nil {
-					continue
-				}
+		allDone := true
+		for _, depID := range t.DependsOn {
+			if !isTaskFinished(status[depID]) {
+				allDone = false
+				break
 			}
 		}
+		if !allDone {
+			continue
+		}
+
+		t.Status = domain.TaskStatusReady
+		t.UpdatedAt = now
+		plan.tasks = append(plan.tasks, t)
+		plan.events = append(plan.events, &domain.TaskEvent{
+			Layer:    "task",
+			Type:     "ready",
+			ID:       t.ID,
+			By:       "system",
+			Ts:       now,
+			Snapshot: t,
+		})
 	}
+	return plan, nil
+}
 
-	return unblockedAny, nil
+// planReblockDependents is the reverse of planUnblockDependents: once
+// reopenedID is no longer done, ready tasks that depend on it go back to
+// blocked with a system event. Dependents already in progress are left alone
+// and reported as warnings.
+func (s *TaskService) planReblockDependents(reopenedID string, now time.Time) (*taskPlan, error) {
+	all, err := s.workspaceTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &taskPlan{}
+	for _, t := range all {
+		if !containsID(t.DependsOn, reopenedID) {
+			continue
+		}
+		switch t.Status {
+		case domain.TaskStatusReady:
+			t.Status = domain.TaskStatusBlocked
+			t.UpdatedAt = now
+			plan.tasks = append(plan.tasks, t)
+			plan.events = append(plan.events, &domain.TaskEvent{
+				Layer:    "task",
+				Type:     "blocked",
+				ID:       t.ID,
+				By:       "system",
+				Ts:       now,
+				Snapshot: t,
+			})
+		case domain.TaskStatusInProgress:
+			plan.warnings = append(plan.warnings, fmt.Sprintf("warning: %s is in_progress but its dependency %s is no longer done", t.ID, reopenedID))
+		}
+	}
+	return plan, nil
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"mandor/internal/domain"
@@ -30,6 +31,20 @@ func NewWorkspaceService() (*WorkspaceService, error) {
 	}, nil
 }
 
+// NewWorkspaceServiceForInit creates a workspace service rooted at the cwd (or
+// --workspace / MANDOR_WORKSPACE) without walking up to a parent workspace
+func NewWorkspaceServiceForInit() (*WorkspaceService, error) {
+	paths, err := fs.NewInitPaths()
+	if err != nil {
+		return nil, err
+	}
+	return &WorkspaceService{
+		reader: fs.NewReader(paths),
+		writer: fs.NewWriter(paths),
+		paths:  paths,
+	}, nil
+}
+
 // InitWorkspace initializes a new workspace
 func (s *WorkspaceService) InitWorkspace(workspaceName string) (*domain.Workspace, error) {
 	// Pre-flight checks
@@ -40,12 +55,7 @@ func (s *WorkspaceService) InitWorkspace(workspaceName string) (*domain.Workspac
 	}
 
 	// Check write permissions
-	cwd, err := os.Getwd()
-	if err != nil {
-		return nil, domain.NewSystemError("Cannot determine current directory", err)
-	}
-
-	testFile := fmt.Sprintf("%s/.mandor_test", cwd)
+	testFile := filepath.Join(s.paths.WorkspaceRoot, ".mandor_test")
 	if err := os.WriteFile(testFile, []byte("test"), 0644); err != nil {
 		os.Remove(testFile)
 		if os.IsPermission(err) {
@@ -65,10 +75,7 @@ func (s *WorkspaceService) InitWorkspace(workspaceName string) (*domain.Workspac
 
 	// Determine workspace name
 	if workspaceName == "" {
-		workspaceName, err = util.GetCurrentDirectory()
-		if err != nil {
-			return nil, domain.NewSystemError("Cannot determine directory name", err)
-		}
+		workspaceName = filepath.Base(s.paths.WorkspaceRoot)
 	}
 
 	// Validate workspace name
@@ -87,7 +94,7 @@ func (s *WorkspaceService) InitWorkspace(workspaceName string) (*domain.Workspac
 		ID:            id,
 		Name:          workspaceName,
 		Version:       "mandor.v1",
-		SchemaVersion: "mandor.v1",
+		SchemaVersion: domain.CurrentSchemaVersion,
 		CreatedAt:     now,
 		LastUpdatedAt: now,
 		CreatedBy:     createdBy,
@@ -114,6 +121,12 @@ func (s *WorkspaceService) GetWorkspace() (*domain.Workspace, error) {
 
 // UpdateWorkspaceConfig updates a configuration value
 func (s *WorkspaceService) UpdateWorkspaceConfig(key string, value interface{}) error {
+	unlock, err := s.writer.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	ws, err := s.reader.ReadWorkspace()
 	if err != nil {
 		return err
@@ -140,9 +153,22 @@ func (s *WorkspaceService) UpdateWorkspaceConfig(key string, value interface{})
 		}
 		ws.Config.StrictMode = boolValue
 
+	case "lock_timeout":
+		strValue, ok := value.(string)
+		if !ok {
+			return domain.NewValidationError("lock_timeout must be a duration string")
+		}
+		d, err := time.ParseDuration(strValue)
+		if err != nil || d <= 0 {
+			return domain.NewValidationError(
+				"Invalid value for lock_timeout.\nUse a positive duration (e.g., 5s, 30s, 1m)",
+			)
+		}
+		ws.Config.LockTimeout = strValue
+
 	default:
 		return domain.NewValidationError(
-			fmt.Sprintf("Unknown configuration key: %s\n\nAvailable keys:\n  - default_priority\n  - strict_mode", key),
+			fmt.Sprintf("Unknown configuration key: %s\n\nAvailable keys:\n  - default_priority\n  - strict_mode\n  - lock_timeout", key),
 		)
 	}
 
@@ -165,6 +191,11 @@ func (s *WorkspaceService) GetConfigValue(key string) (interface{}, error) {
 		return ws.Config.DefaultPriority, nil
 	case "strict_mode":
 		return ws.Config.StrictMode, nil
+	case "lock_timeout":
+		if ws.Config.LockTimeout == "" {
+			return fs.DefaultLockTimeout.String(), nil
+		}
+		return ws.Config.LockTimeout, nil
 	default:
 		return nil, domain.NewValidationError(
 			fmt.Sprintf("Unknown configuration key: %s", key),
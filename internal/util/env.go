@@ -3,6 +3,7 @@ package util
 import (
 	"os"
 	"strings"
+	"time"
 )
 
 // GetEnvironment returns the current environment: "development", "staging", or "production"
@@ -33,3 +34,16 @@ func isTestMode() bool {
 	// Check if testing flag is set (go test sets this)
 	return strings.Contains(os.Args[0], "test") || strings.Contains(os.Args[0], ".test")
 }
+
+// GetLockTimeout returns the lock wait timeout from MANDOR_LOCK_TIMEOUT (e.g. "30s")
+func GetLockTimeout() (time.Duration, bool) {
+	value := os.Getenv("MANDOR_LOCK_TIMEOUT")
+	if value == "" {
+		return 0, false
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, false
+	}
+	return timeout, true
+}
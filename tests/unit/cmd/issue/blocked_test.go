@@ -78,16 +78,16 @@ func TestBlockedCmd_WithBlockedIssues(t *testing.T) {
 	// Create a blocked issue
 	issueID := projectID + "-issue-abc123def456"
 	issueData := map[string]interface{}{
-		"id":              issueID,
-		"project_id":      projectID,
-		"name":            "Blocked Issue",
-		"issue_type":      "bug",
-		"status":          "blocked",
-		"priority":        "P2",
-		"created_at":      time.Now().UTC().Format(time.RFC3339),
-		"last_updated_at": time.Now().UTC().Format(time.RFC3339),
-		"created_by":      "testuser",
-		"last_updated_by": "testuser",
+		"id":         issueID,
+		"project_id": projectID,
+		"name":       "Blocked Issue",
+		"issue_type": "bug",
+		"status":     "blocked",
+		"priority":   "P2",
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+		"created_by": "testuser",
+		"updated_by": "testuser",
 	}
 
 	issuesPath := filepath.Join(tmpDir, ".mandor", "projects", projectID, "issues.jsonl")
@@ -191,16 +191,16 @@ func TestBlockedCmd_JSONOutput(t *testing.T) {
 
 	issueID := projectID + "-issue-xyz789uvw123"
 	issueData := map[string]interface{}{
-		"id":              issueID,
-		"project_id":      projectID,
-		"name":            "Blocked Issue",
-		"issue_type":      "improvement",
-		"status":          "blocked",
-		"priority":        "P1",
-		"created_at":      time.Now().UTC().Format(time.RFC3339),
-		"last_updated_at": time.Now().UTC().Format(time.RFC3339),
-		"created_by":      "testuser",
-		"last_updated_by": "testuser",
+		"id":         issueID,
+		"project_id": projectID,
+		"name":       "Blocked Issue",
+		"issue_type": "improvement",
+		"status":     "blocked",
+		"priority":   "P1",
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+		"created_by": "testuser",
+		"updated_by": "testuser",
 	}
 
 	issuesPath := filepath.Join(tmpDir, ".mandor", "projects", projectID, "issues.jsonl")
@@ -219,9 +219,9 @@ func TestCreateCmd_WithDependency(t *testing.T) {
 		AffectedTests:       []string{"tests/dep.test.ts"},
 		ImplementationSteps: []string{"Step 1"},
 		CreatedAt:           time.Now().UTC(),
-		LastUpdatedAt:       time.Now().UTC(),
+		UpdatedAt:           time.Now().UTC(),
 		CreatedBy:           "testuser",
-		LastUpdatedBy:       "testuser",
+		UpdatedBy:           "testuser",
 	}
 
 	issuesPath := filepath.Join(tmpDir, ".mandor", "projects", "auth", "issues.jsonl")
@@ -78,16 +78,16 @@ func TestReadyCmd_WithReadyIssues(t *testing.T) {
 	// Create a ready issue
 	issueID := projectID + "-issue-abc123def456"
 	issueData := map[string]interface{}{
-		"id":              issueID,
-		"project_id":      projectID,
-		"name":            "Ready Issue",
-		"issue_type":      "bug",
-		"status":          "ready",
-		"priority":        "P2",
-		"created_at":      time.Now().UTC().Format(time.RFC3339),
-		"last_updated_at": time.Now().UTC().Format(time.RFC3339),
-		"created_by":      "testuser",
-		"last_updated_by": "testuser",
+		"id":         issueID,
+		"project_id": projectID,
+		"name":       "Ready Issue",
+		"issue_type": "bug",
+		"status":     "ready",
+		"priority":   "P2",
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+		"created_by": "testuser",
+		"updated_by": "testuser",
 	}
 
 	issuesPath := filepath.Join(tmpDir, ".mandor", "projects", projectID, "issues.jsonl")
@@ -191,16 +191,16 @@ func TestReadyCmd_JSONOutput(t *testing.T) {
 
 	issueID := projectID + "-issue-xyz789uvw123"
 	issueData := map[string]interface{}{
-		"id":              issueID,
-		"project_id":      projectID,
-		"name":            "Ready Issue",
-		"issue_type":      "improvement",
-		"status":          "ready",
-		"priority":        "P1",
-		"created_at":      time.Now().UTC().Format(time.RFC3339),
-		"last_updated_at": time.Now().UTC().Format(time.RFC3339),
-		"created_by":      "testuser",
-		"last_updated_by": "testuser",
+		"id":         issueID,
+		"project_id": projectID,
+		"name":       "Ready Issue",
+		"issue_type": "improvement",
+		"status":     "ready",
+		"priority":   "P1",
+		"created_at": time.Now().UTC().Format(time.RFC3339),
+		"updated_at": time.Now().UTC().Format(time.RFC3339),
+		"created_by": "testuser",
+		"updated_by": "testuser",
 	}
 
 	issuesPath := filepath.Join(tmpDir, ".mandor", "projects", projectID, "issues.jsonl")
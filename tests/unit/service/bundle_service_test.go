@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/service"
+)
+
+// setupBundleWorkspace writes project "api" with one feature and two dependent tasks
+func setupBundleWorkspace(t *testing.T) (*service.BundleService, string) {
+	t.Helper()
+
+	_, tmpDir := setupTestTaskService(t)
+	writeTestProjectForTask(t, tmpDir, "api", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "api", "api-feature-abc", domain.FeatureStatusDraft)
+	writeTestTask(t, tmpDir, "api", "api-feature-abc-task-001", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "api", "api-feature-abc-task-002", domain.TaskStatusBlocked, []string{"api-feature-abc-task-001"})
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	return service.NewBundleServiceWithPaths(paths), tmpDir
+}
+
+func TestBundle_ExportImportWithPrefix(t *testing.T) {
+	svc, tmpDir := setupBundleWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	bundle, err := svc.Export()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if bundle.BundleVersion != domain.BundleVersion || len(bundle.Projects) != 1 {
+		t.Fatalf("Unexpected bundle: %+v", bundle)
+	}
+	if len(bundle.Projects[0].Tasks) != 2 || bundle.Projects[0].Schema == nil {
+		t.Errorf("Expected tasks and schema in bundle, got: %+v", bundle.Projects[0])
+	}
+
+	// Free text that mentions an ID is not an ID reference
+	bundle.Projects[0].Tasks[1].Goal = "Builds on api-feature-abc-task-001"
+
+	// Importing into the same workspace conflicts unless prefixed
+	if _, err := svc.Import(bundle, service.ImportOptions{}); err == nil {
+		t.Fatal("Expected error importing an existing project")
+	}
+
+	result, err := svc.Import(bundle, service.ImportOptions{ProjectPrefix: "seed-"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Projects[0].ID != "seed-api" || result.Projects[0].SourceID != "api" {
+		t.Errorf("Unexpected import result: %+v", result.Projects[0])
+	}
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	task, err := fs.NewReader(paths).ReadTask("seed-api", "seed-api-feature-abc-task-002")
+	if err != nil {
+		t.Fatalf("Expected prefixed task, got: %v", err)
+	}
+	if task.FeatureID != "seed-api-feature-abc" || task.ProjectID != "seed-api" {
+		t.Errorf("Expected prefixed references, got feature %s project %s", task.FeatureID, task.ProjectID)
+	}
+	if len(task.DependsOn) != 1 || task.DependsOn[0] != "seed-api-feature-abc-task-001" {
+		t.Errorf("Expected prefixed dependency, got: %v", task.DependsOn)
+	}
+	if task.Goal != "Builds on api-feature-abc-task-001" {
+		t.Errorf("Expected goal text left alone, got: %s", task.Goal)
+	}
+}
+
+func TestBundle_ImportRestoresWorkspaceOnWriteFailure(t *testing.T) {
+	svc, tmpDir := setupBundleWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	bundle, err := svc.Export()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	bundle.Projects[0].Tasks[0].Name = "Renamed by import"
+
+	// comments.jsonl is written after tasks.jsonl; make it fail
+	commentsPath := filepath.Join(tmpDir, ".mandor", "projects", "api", "comments.jsonl")
+	if err := os.MkdirAll(filepath.Join(commentsPath, "blocker"), 0755); err != nil {
+		t.Fatalf("Failed to create blocker: %v", err)
+	}
+
+	if _, err := svc.Import(bundle, service.ImportOptions{Mode: service.ImportModeReplace}); err == nil {
+		t.Fatal("Expected the import to fail")
+	}
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	task, err := fs.NewReader(paths).ReadTask("api", "api-feature-abc-task-001")
+	if err != nil {
+		t.Fatalf("Failed to read task: %v", err)
+	}
+	if task.Name == "Renamed by import" {
+		t.Error("Expected tasks.jsonl restored after the failed import")
+	}
+}
+
+func TestBundle_ImportRejectsUnknownDependency(t *testing.T) {
+	svc, tmpDir := setupBundleWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	bundle, err := svc.Export()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	bundle.Projects[0].Tasks[1].DependsOn = []string{"api-feature-abc-task-999"}
+
+	_, err = svc.Import(bundle, service.ImportOptions{Mode: service.ImportModeReplace})
+	if err == nil || !strings.Contains(err.Error(), "api-feature-abc-task-999") {
+		t.Fatalf("Expected unknown dependency error, got: %v", err)
+	}
+
+	// Nothing was written
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	task, _ := fs.NewReader(paths).ReadTask("api", "api-feature-abc-task-002")
+	if task.DependsOn[0] != "api-feature-abc-task-001" {
+		t.Errorf("Expected workspace unchanged, got: %v", task.DependsOn)
+	}
+}
@@ -0,0 +1,191 @@
+package service_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/service"
+)
+
+func setupTestDoctorService(t *testing.T) (*service.DoctorService, *service.TaskService, string) {
+	t.Helper()
+
+	taskSvc, tmpDir := setupTestTaskService(t)
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+
+	return service.NewDoctorServiceWithPaths(paths), taskSvc, tmpDir
+}
+
+func findingCodes(report *service.DoctorReport) map[string]int {
+	codes := make(map[string]int)
+	for _, f := range report.Findings {
+		codes[f.Code]++
+	}
+	return codes
+}
+
+func TestDoctor_Healthy(t *testing.T) {
+	svc, _, tmpDir := setupTestDoctorService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-abc123", domain.TaskStatusReady, nil)
+
+	report, err := svc.Check(false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("Expected no findings, got: %+v", report.Findings)
+	}
+}
+
+func TestDoctor_ReportsProblems(t *testing.T) {
+	svc, _, tmpDir := setupTestDoctorService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-done01", domain.TaskStatusDone, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-stale1", domain.TaskStatusBlocked, []string{"testproject-feature-abc-task-done01"})
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-dangle", domain.TaskStatusReady, []string{"testproject-feature-abc-task-gone00"})
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-dangle", domain.TaskStatusReady, []string{"testproject-feature-abc-task-gone00"})
+
+	tmpFile := filepath.Join(tmpDir, ".mandor", "projects", "testproject", "tasks.jsonl.tmp")
+	if err := os.WriteFile(tmpFile, []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write tmp file: %v", err)
+	}
+
+	report, err := svc.Check(false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	codes := findingCodes(report)
+	for _, code := range []string{service.DoctorDanglingDependency, service.DoctorDuplicateID, service.DoctorStaleBlocked, service.DoctorLeftoverTmp} {
+		if codes[code] != 1 {
+			t.Errorf("Expected 1 %s finding, got %d", code, codes[code])
+		}
+	}
+	if report.Fixed != 0 {
+		t.Errorf("Expected nothing fixed without --fix, got %d", report.Fixed)
+	}
+	if _, err := os.Stat(tmpFile); err != nil {
+		t.Error("Expected tmp file to be left in place without --fix")
+	}
+}
+
+func TestDoctor_Fix(t *testing.T) {
+	svc, taskSvc, tmpDir := setupTestDoctorService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-done01", domain.TaskStatusDone, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-stale1", domain.TaskStatusBlocked, []string{"testproject-feature-abc-task-done01", "testproject-feature-abc-task-gone00"})
+
+	report, err := svc.Check(true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if report.Unresolved() != 0 {
+		t.Errorf("Expected all findings fixed, got: %+v", report.Findings)
+	}
+
+	detail, err := taskSvc.GetTaskDetail(&domain.TaskDetailInput{TaskID: "testproject-feature-abc-task-stale1"})
+	if err != nil {
+		t.Fatalf("Failed to read task: %v", err)
+	}
+	if detail.Status != domain.TaskStatusReady {
+		t.Errorf("Expected status ready, got: %s", detail.Status)
+	}
+	if len(detail.DependsOn) != 1 {
+		t.Errorf("Expected dangling dependency removed, got: %v", detail.DependsOn)
+	}
+
+	events, err := taskSvc.GetTaskEvents("testproject-feature-abc-task-stale1")
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(events) != 2 || events[0].Type != "repaired" || events[0].By != "system" {
+		t.Errorf("Expected 2 system repaired events, got: %+v", events)
+	}
+
+	again, err := svc.Check(false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(again.Findings) != 0 {
+		t.Errorf("Expected no findings after fix, got: %+v", again.Findings)
+	}
+}
+
+func TestDoctor_FixBlocksReadyTaskOnCancelledDependency(t *testing.T) {
+	svc, taskSvc, tmpDir := setupTestDoctorService(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Left ready while a cancelled dependency still counted as finished
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-gone01", domain.TaskStatusCancelled, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-ready1", domain.TaskStatusReady, []string{"testproject-feature-abc-task-gone01"})
+
+	report, err := svc.Check(false)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if codes := findingCodes(report); codes[service.DoctorStaleReady] != 1 || len(report.Findings) != 1 {
+		t.Fatalf("Expected 1 %s finding, got: %+v", service.DoctorStaleReady, report.Findings)
+	}
+
+	if _, err := svc.Check(true); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	detail, err := taskSvc.GetTaskDetail(&domain.TaskDetailInput{TaskID: "testproject-feature-abc-task-ready1"})
+	if err != nil {
+		t.Fatalf("Failed to read task: %v", err)
+	}
+	if detail.Status != domain.TaskStatusBlocked {
+		t.Errorf("Expected status blocked, got: %s", detail.Status)
+	}
+}
+
+func TestDoctor_FixRecordsRepairDetails(t *testing.T) {
+	svc, _, tmpDir := setupTestDoctorService(t)
+	defer os.RemoveAll(tmpDir)
+
+	projectDir := filepath.Join(tmpDir, ".mandor", "projects", "testproject")
+	if err := os.WriteFile(filepath.Join(projectDir, "tasks.jsonl.tmp"), []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write tmp file: %v", err)
+	}
+
+	if _, err := svc.Check(true); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(projectDir, "events.jsonl"))
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	var repaired *domain.ProjectEvent
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var event domain.ProjectEvent
+		if err := json.Unmarshal([]byte(line), &event); err == nil && event.Type == "repaired" {
+			repaired = &event
+		}
+	}
+	if repaired == nil || repaired.Repair == nil {
+		t.Fatalf("Expected a repaired project event with repair details, got: %s", data)
+	}
+	if repaired.Repair.Code != service.DoctorLeftoverTmp || !strings.HasSuffix(repaired.Repair.Path, "tasks.jsonl.tmp") {
+		t.Errorf("Unexpected repair record: %+v", repaired.Repair)
+	}
+	// Changes only ever holds entity field names
+	if len(repaired.Changes) != 0 {
+		t.Errorf("Expected no changed fields on a file repair, got: %v", repaired.Changes)
+	}
+}
@@ -3,6 +3,7 @@ package service_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -471,3 +472,131 @@ func TestFeatureValidateUpdateInput_CancelledFeature(t *testing.T) {
 		t.Error("Expected validation error for cancelled feature")
 	}
 }
+
+func TestFeatureUpdate_StatusTransitions(t *testing.T) {
+	svc, tmpDir := setupTestFeatureService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForFeature(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeature(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusDraft, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-aaaa", domain.TaskStatusReady, nil)
+
+	update := func(input domain.FeatureUpdateInput) error {
+		input.ProjectID = "testproject"
+		input.FeatureID = "testproject-feature-abc"
+		if err := svc.ValidateUpdateInput(&input); err != nil {
+			return err
+		}
+		_, err := svc.UpdateFeature(&input)
+		return err
+	}
+
+	done := domain.FeatureStatusDone
+	if err := update(domain.FeatureUpdateInput{Status: &done}); err == nil {
+		t.Error("Expected draft -> done to be rejected")
+	}
+	if err := update(domain.FeatureUpdateInput{Complete: true}); err == nil {
+		t.Error("Expected --complete on a draft feature to be rejected")
+	}
+	if err := update(domain.FeatureUpdateInput{Start: true, Complete: true}); err == nil {
+		t.Error("Expected --start with --complete to be rejected")
+	}
+	if err := update(domain.FeatureUpdateInput{Start: true}); err != nil {
+		t.Fatalf("Failed to start feature: %v", err)
+	}
+
+	err := update(domain.FeatureUpdateInput{Complete: true})
+	if err == nil || !strings.Contains(err.Error(), "testproject-feature-abc-task-aaaa") {
+		t.Errorf("Expected open task to prevent completion, got: %v", err)
+	}
+
+	reason := "dropped"
+	cancelled := domain.FeatureStatusCancelled
+	if err := update(domain.FeatureUpdateInput{Status: &cancelled, Reason: &reason}); err != nil {
+		t.Fatalf("Failed to cancel feature: %v", err)
+	}
+	if err := update(domain.FeatureUpdateInput{Status: &done}); err == nil {
+		t.Error("Expected cancelled -> done to be rejected")
+	}
+
+	writeTestFeature(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusDone, nil)
+	blocked := domain.FeatureStatusBlocked
+	if err := update(domain.FeatureUpdateInput{Status: &blocked}); err != nil {
+		t.Errorf("Expected done -> blocked to be accepted, got: %v", err)
+	}
+	writeTestFeature(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusDone, nil)
+	if err := update(domain.FeatureUpdateInput{Status: &cancelled, Reason: &reason}); err != nil {
+		t.Errorf("Expected done -> cancelled to be accepted, got: %v", err)
+	}
+}
+
+func TestFeatureUpdate_CompleteUnblocksCrossProjectDependents(t *testing.T) {
+	svc, tmpDir := setupTestFeatureService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForFeature(t, tmpDir, "api", domain.ProjectStatusActive)
+	writeTestProjectForFeature(t, tmpDir, "web", domain.ProjectStatusActive)
+	writeTestFeature(t, tmpDir, "api", "api-feature-abc", domain.FeatureStatusActive, nil)
+	writeTestFeature(t, tmpDir, "web", "web-feature-abc", domain.FeatureStatusDraft, nil)
+
+	deps := []string{"api-feature-abc"}
+	input := &domain.FeatureUpdateInput{ProjectID: "web", FeatureID: "web-feature-abc", DependsOn: &deps}
+	if err := svc.ValidateUpdateInput(input); err != nil {
+		t.Fatalf("Expected cross-project dependency to be accepted, got: %v", err)
+	}
+	blocked := domain.FeatureStatusBlocked
+	input.Status = &blocked
+	if _, err := svc.UpdateFeature(input); err != nil {
+		t.Fatalf("Failed to block feature: %v", err)
+	}
+
+	changes, err := svc.UpdateFeature(&domain.FeatureUpdateInput{ProjectID: "api", FeatureID: "api-feature-abc", Complete: true})
+	if err != nil {
+		t.Fatalf("Failed to complete feature: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, ","), "dependent_unblocked") {
+		t.Errorf("Expected dependent_unblocked in changes, got: %v", changes)
+	}
+
+	detail, err := svc.GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "web", FeatureID: "web-feature-abc"})
+	if err != nil {
+		t.Fatalf("Failed to get feature: %v", err)
+	}
+	if detail.Status != domain.FeatureStatusDraft {
+		t.Errorf("Expected cross-project dependent to be unblocked, got '%s'", detail.Status)
+	}
+}
+
+func TestFeatureUpdate_CancelCascade(t *testing.T) {
+	svc, tmpDir := setupTestFeatureService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForFeature(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeature(t, tmpDir, "testproject", "testproject-feature-aaa", domain.FeatureStatusDraft, nil)
+	writeTestFeature(t, tmpDir, "testproject", "testproject-feature-bbb", domain.FeatureStatusBlocked, []string{"testproject-feature-aaa"})
+	writeTestFeature(t, tmpDir, "testproject", "testproject-feature-ccc", domain.FeatureStatusBlocked, []string{"testproject-feature-bbb"})
+
+	reason := "dropped"
+	input := &domain.FeatureUpdateInput{ProjectID: "testproject", FeatureID: "testproject-feature-aaa", Cancel: true, Reason: &reason}
+	if _, err := svc.UpdateFeature(input); err == nil || !strings.Contains(err.Error(), "testproject-feature-bbb") {
+		t.Errorf("Expected cancel without a policy to list dependents, got: %v", err)
+	}
+
+	input.Cascade = true
+	changes, err := svc.UpdateFeature(input)
+	if err != nil {
+		t.Fatalf("Failed to cancel with --cascade: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, "\n"), "cancelled dependent: testproject-feature-ccc") {
+		t.Errorf("Expected transitive dependent in changes, got: %v", changes)
+	}
+	for _, id := range []string{"testproject-feature-bbb", "testproject-feature-ccc"} {
+		detail, err := svc.GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "testproject", FeatureID: id, IncludeDeleted: true})
+		if err != nil {
+			t.Fatalf("Failed to get feature: %v", err)
+		}
+		if detail.Status != domain.FeatureStatusCancelled || detail.Reason != reason {
+			t.Errorf("Expected %s cancelled with the same reason, got '%s' (%s)", id, detail.Status, detail.Reason)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package service_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -779,6 +780,90 @@ func TestIssueService_ReopenIssue(t *testing.T) {
 	os.RemoveAll(filepath.Dir(paths.MandorDirPath()))
 }
 
+func TestIssueService_UpdateDependenciesRecomputesStatus(t *testing.T) {
+	paths, err := fs.NewPathsFromRoot(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+
+	writer := fs.NewWriter(paths)
+	if err := writer.CreateMandorDir(); err != nil {
+		t.Fatalf("Failed to create mandor dir: %v", err)
+	}
+	if err := writer.WriteWorkspace(&domain.Workspace{ID: "test-workspace", Name: "Test Workspace"}); err != nil {
+		t.Fatalf("Failed to write workspace: %v", err)
+	}
+	if err := writer.CreateProjectDir("auth"); err != nil {
+		t.Fatalf("Failed to create project dir: %v", err)
+	}
+	if err := writer.WriteProjectMetadata("auth", &domain.Project{ID: "auth", Name: "Auth", Status: domain.ProjectStatusActive}); err != nil {
+		t.Fatalf("Failed to write project: %v", err)
+	}
+	schema := domain.DefaultProjectSchema("same_project_only", "same_project_only", "same_project_only")
+	if err := writer.WriteProjectSchema("auth", &schema); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	svc := service.NewIssueServiceWithPaths(paths)
+	create := func(name string) *domain.Issue {
+		issue, err := svc.CreateIssue(&domain.IssueCreateInput{
+			ProjectID:           "auth",
+			Name:                name,
+			Goal:                "Test goal",
+			IssueType:           "bug",
+			AffectedFiles:       []string{"src/file.ts"},
+			AffectedTests:       []string{"tests/file.test.ts"},
+			ImplementationSteps: []string{"Step 1"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create issue: %v", err)
+		}
+		return issue
+	}
+	dep := create("Dependency")
+	issue := create("Dependent")
+
+	add := []string{dep.ID}
+	changes, err := svc.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "auth", IssueID: issue.ID, DependsAdd: &add})
+	if err != nil {
+		t.Fatalf("Failed to add dependency: %v", err)
+	}
+	updated, _ := svc.ReadDependency("auth", issue.ID)
+	if updated.Status != domain.IssueStatusBlocked {
+		t.Errorf("Expected issue blocked after adding open dependency, got '%s' (%v)", updated.Status, changes)
+	}
+
+	back := []string{issue.ID}
+	_, err = svc.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "auth", IssueID: dep.ID, DependsAdd: &back})
+	if err == nil || !strings.Contains(err.Error(), "Circular dependency detected") {
+		t.Errorf("Expected cycle rejected on update, got: %v", err)
+	}
+
+	missing := []string{"auth-issue-nope"}
+	if _, err := svc.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "auth", IssueID: issue.ID, DependsAdd: &missing}); err == nil {
+		t.Error("Expected unknown dependency rejected on update")
+	}
+
+	if _, err := svc.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "auth", IssueID: issue.ID, DependsRemove: &add}); err != nil {
+		t.Fatalf("Failed to remove dependency: %v", err)
+	}
+	updated, _ = svc.ReadDependency("auth", issue.ID)
+	if updated.Status != domain.IssueStatusReady {
+		t.Errorf("Expected issue ready after removing last dependency, got '%s'", updated.Status)
+	}
+
+	events, _ := svc.GetIssueEvents("auth", issue.ID)
+	var system []string
+	for _, event := range events {
+		if event.By == "system" {
+			system = append(system, event.Type)
+		}
+	}
+	if strings.Join(system, ",") != "ready,blocked,ready" {
+		t.Errorf("Expected system ready/blocked/ready events, got: %v", system)
+	}
+}
+
 func randomString(n int) string {
 	const letters = "abcdefghijklmnopqrstuvwxyz"
 	b := make([]byte, n)
@@ -787,3 +872,152 @@ func randomString(n int) string {
 	}
 	return string(b)
 }
+
+func TestIssueService_ReopenReblocksDependents(t *testing.T) {
+	paths, err := fs.NewPathsFromRoot(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+
+	writer := fs.NewWriter(paths)
+	if err := writer.CreateMandorDir(); err != nil {
+		t.Fatalf("Failed to create mandor dir: %v", err)
+	}
+	if err := writer.WriteWorkspace(&domain.Workspace{ID: "test-workspace", Name: "Test Workspace"}); err != nil {
+		t.Fatalf("Failed to write workspace: %v", err)
+	}
+	if err := writer.CreateProjectDir("auth"); err != nil {
+		t.Fatalf("Failed to create project dir: %v", err)
+	}
+	if err := writer.WriteProjectMetadata("auth", &domain.Project{ID: "auth", Name: "Auth", Status: domain.ProjectStatusActive}); err != nil {
+		t.Fatalf("Failed to write project: %v", err)
+	}
+
+	now := time.Now().UTC()
+	issues := []*domain.Issue{
+		{ID: "auth-issue-aaaa", ProjectID: "auth", Status: domain.IssueStatusResolved, CreatedAt: now, UpdatedAt: now},
+		{ID: "auth-issue-bbbb", ProjectID: "auth", Status: domain.IssueStatusReady, DependsOn: []string{"auth-issue-aaaa"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "auth-issue-cccc", ProjectID: "auth", Status: domain.IssueStatusInProgress, DependsOn: []string{"auth-issue-aaaa"}, CreatedAt: now, UpdatedAt: now},
+	}
+	if err := writer.ReplaceIssues("auth", issues, nil); err != nil {
+		t.Fatalf("Failed to write issues: %v", err)
+	}
+
+	svc := service.NewIssueServiceWithPaths(paths)
+	changes, err := svc.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "auth", IssueID: "auth-issue-aaaa", Reopen: true})
+	if err != nil {
+		t.Fatalf("Failed to reopen issue: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, "\n"), "warning: auth-issue-cccc") {
+		t.Errorf("Expected warning for in_progress dependent, got: %v", changes)
+	}
+
+	ready, _ := svc.ReadDependency("auth", "auth-issue-bbbb")
+	if ready.Status != domain.IssueStatusBlocked {
+		t.Errorf("Expected ready dependent to be blocked, got '%s'", ready.Status)
+	}
+	started, _ := svc.ReadDependency("auth", "auth-issue-cccc")
+	if started.Status != domain.IssueStatusInProgress {
+		t.Errorf("Expected in_progress dependent unchanged, got '%s'", started.Status)
+	}
+}
+
+func TestIssueService_ResolveUnblocksCrossProjectDependents(t *testing.T) {
+	paths, err := fs.NewPathsFromRoot(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+
+	writer := fs.NewWriter(paths)
+	if err := writer.CreateMandorDir(); err != nil {
+		t.Fatalf("Failed to create mandor dir: %v", err)
+	}
+	if err := writer.WriteWorkspace(&domain.Workspace{ID: "test-workspace", Name: "Test Workspace"}); err != nil {
+		t.Fatalf("Failed to write workspace: %v", err)
+	}
+	for _, projectID := range []string{"api", "web"} {
+		if err := writer.CreateProjectDir(projectID); err != nil {
+			t.Fatalf("Failed to create project dir: %v", err)
+		}
+		if err := writer.WriteProjectMetadata(projectID, &domain.Project{ID: projectID, Name: projectID, Status: domain.ProjectStatusActive}); err != nil {
+			t.Fatalf("Failed to write project: %v", err)
+		}
+	}
+
+	now := time.Now().UTC()
+	if err := writer.ReplaceIssues("api", []*domain.Issue{
+		{ID: "api-issue-aaaa", ProjectID: "api", Status: domain.IssueStatusInProgress, CreatedAt: now, UpdatedAt: now},
+	}, nil); err != nil {
+		t.Fatalf("Failed to write issues: %v", err)
+	}
+	if err := writer.ReplaceIssues("web", []*domain.Issue{
+		{ID: "web-issue-bbbb", ProjectID: "web", Status: domain.IssueStatusBlocked, DependsOn: []string{"api-issue-aaaa"}, CreatedAt: now, UpdatedAt: now},
+	}, nil); err != nil {
+		t.Fatalf("Failed to write issues: %v", err)
+	}
+
+	svc := service.NewIssueServiceWithPaths(paths)
+	changes, err := svc.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "api", IssueID: "api-issue-aaaa", Resolve: true})
+	if err != nil {
+		t.Fatalf("Failed to resolve issue: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, ","), "dependent_unblocked") {
+		t.Errorf("Expected dependent_unblocked in changes, got: %v", changes)
+	}
+
+	dependent, _ := svc.ReadDependency("web", "web-issue-bbbb")
+	if dependent.Status != domain.IssueStatusReady {
+		t.Errorf("Expected cross-project dependent to be ready, got '%s'", dependent.Status)
+	}
+}
+
+func TestIssueService_PreviewUpdate(t *testing.T) {
+	paths, err := fs.NewPathsFromRoot(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+
+	writer := fs.NewWriter(paths)
+	if err := writer.CreateMandorDir(); err != nil {
+		t.Fatalf("Failed to create mandor dir: %v", err)
+	}
+	if err := writer.WriteWorkspace(&domain.Workspace{ID: "test-workspace", Name: "Test Workspace"}); err != nil {
+		t.Fatalf("Failed to write workspace: %v", err)
+	}
+	if err := writer.CreateProjectDir("api"); err != nil {
+		t.Fatalf("Failed to create project dir: %v", err)
+	}
+	if err := writer.WriteProjectMetadata("api", &domain.Project{ID: "api", Name: "api", Status: domain.ProjectStatusActive}); err != nil {
+		t.Fatalf("Failed to write project: %v", err)
+	}
+
+	now := time.Now().UTC()
+	if err := writer.ReplaceIssues("api", []*domain.Issue{
+		{ID: "api-issue-aaaa", ProjectID: "api", Status: domain.IssueStatusResolved, CreatedAt: now, UpdatedAt: now},
+		{ID: "api-issue-bbbb", ProjectID: "api", Status: domain.IssueStatusReady, DependsOn: []string{"api-issue-aaaa"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "api-issue-cccc", ProjectID: "api", Status: domain.IssueStatusInProgress, DependsOn: []string{"api-issue-aaaa"}, CreatedAt: now, UpdatedAt: now},
+	}, nil); err != nil {
+		t.Fatalf("Failed to write issues: %v", err)
+	}
+
+	svc := service.NewIssueServiceWithPaths(paths)
+	preview, err := svc.PreviewIssueUpdate(&domain.IssueUpdateInput{ProjectID: "api", IssueID: "api-issue-aaaa", Reopen: true})
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	if preview.StatusFrom != domain.IssueStatusResolved || preview.StatusTo != domain.IssueStatusOpen {
+		t.Errorf("Expected resolved → open, got %s → %s", preview.StatusFrom, preview.StatusTo)
+	}
+	if len(preview.Reblocked) != 1 || preview.Reblocked[0] != "api-issue-bbbb" {
+		t.Errorf("Expected api-issue-bbbb to be blocked again, got %v", preview.Reblocked)
+	}
+	if len(preview.Warnings) != 1 || !strings.Contains(preview.Warnings[0], "api-issue-cccc") {
+		t.Errorf("Expected a warning for the in-progress dependent, got %v", preview.Warnings)
+	}
+
+	issue, _ := svc.ReadDependency("api", "api-issue-aaaa")
+	dependent, _ := svc.ReadDependency("api", "api-issue-bbbb")
+	if issue.Status != domain.IssueStatusResolved || dependent.Status != domain.IssueStatusReady {
+		t.Errorf("Expected preview to write nothing, got '%s' and '%s'", issue.Status, dependent.Status)
+	}
+}
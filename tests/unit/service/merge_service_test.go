@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"mandor/internal/service"
+)
+
+func decodeMergeLines(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+	var objs []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			t.Fatalf("Invalid merged line %q: %v", line, err)
+		}
+		objs = append(objs, obj)
+	}
+	return objs
+}
+
+func TestMergeJSONL_EntityFields(t *testing.T) {
+	base := `{"id":"p-task-a","name":"A","priority":"P3","status":"ready","updated_at":"2026-01-01T00:00:00Z"}
+{"id":"p-task-b","name":"B","priority":"P3","status":"ready","updated_at":"2026-01-01T00:00:00Z"}
+`
+	// ours changes a's name and b's status; theirs changes a's priority and b's status later
+	ours := `{"id":"p-task-a","name":"A2","priority":"P3","status":"ready","updated_at":"2026-01-02T00:00:00Z"}
+{"id":"p-task-b","name":"B","priority":"P3","status":"in_progress","updated_at":"2026-01-02T00:00:00Z"}
+`
+	theirs := `{"id":"p-task-a","name":"A","priority":"P1","status":"ready","updated_at":"2026-01-03T00:00:00Z"}
+{"id":"p-task-b","name":"B","priority":"P3","status":"done","updated_at":"2026-01-03T00:00:00Z"}
+{"id":"p-task-c","name":"C","priority":"P2","status":"ready","updated_at":"2026-01-03T00:00:00Z"}
+`
+
+	merged, err := service.MergeJSONL([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	objs := decodeMergeLines(t, merged)
+	if len(objs) != 3 {
+		t.Fatalf("Expected 3 entities, got %d: %s", len(objs), merged)
+	}
+	if objs[0]["name"] != "A2" || objs[0]["priority"] != "P1" {
+		t.Errorf("Expected non-conflicting fields from both sides, got: %v", objs[0])
+	}
+	if objs[1]["status"] != "done" {
+		t.Errorf("Expected newer updated_at to win conflicting field, got: %v", objs[1]["status"])
+	}
+	if objs[2]["id"] != "p-task-c" {
+		t.Errorf("Expected entity added on their side, got: %v", objs[2])
+	}
+	if !strings.HasPrefix(string(merged), `{"id":"p-task-a","name":"A2"`) {
+		t.Errorf("Expected field order preserved, got: %s", merged)
+	}
+}
+
+func TestMergeJSONL_EventsUnionByTs(t *testing.T) {
+	base := `{"layer":"task","type":"created","id":"p-task-a","by":"x","ts":"2026-01-01T00:00:00Z"}
+`
+	ours := base + `{"layer":"task","type":"updated","id":"p-task-a","by":"x","ts":"2026-01-03T00:00:00Z"}
+`
+	theirs := base + `{"layer":"task","type":"updated","id":"p-task-a","by":"y","ts":"2026-01-02T00:00:00Z"}
+`
+
+	merged, err := service.MergeJSONL([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	objs := decodeMergeLines(t, merged)
+	if len(objs) != 3 {
+		t.Fatalf("Expected 3 events, got %d: %s", len(objs), merged)
+	}
+	if objs[1]["by"] != "y" || objs[2]["by"] != "x" {
+		t.Errorf("Expected events ordered by ts, got: %s", merged)
+	}
+}
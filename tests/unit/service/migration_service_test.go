@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/service"
+)
+
+func setupLegacyWorkspace(t *testing.T) (*service.MigrationService, string) {
+	t.Helper()
+
+	// setupTestTaskService writes a workspace.json without schema_version (v1)
+	_, tmpDir := setupTestTaskService(t)
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+
+	issueLine := `{"id":"testproject-issue-abc123","project_id":"testproject","name":"Legacy","issue_type":"bug","priority":"P2","status":"open","created_at":"2026-01-27T00:00:00Z","last_updated_at":"2026-01-28T00:00:00Z","created_by":"alice","last_updated_by":"bob"}` + "\n"
+	issuesPath := filepath.Join(tmpDir, ".mandor", "projects", "testproject", "issues.jsonl")
+	if err := os.WriteFile(issuesPath, []byte(issueLine), 0644); err != nil {
+		t.Fatalf("Failed to write issues: %v", err)
+	}
+
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+	return service.NewMigrationServiceWithPaths(paths), tmpDir
+}
+
+func TestMigration_RefusesOldWorkspace(t *testing.T) {
+	svc, tmpDir := setupLegacyWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	err := svc.CheckSchemaVersion()
+	if err == nil {
+		t.Fatal("Expected error for workspace with older schema")
+	}
+	if !strings.Contains(err.Error(), "mandor migrate") {
+		t.Errorf("Expected error to point at mandor migrate, got: %v", err)
+	}
+}
+
+func TestMigration_Migrate(t *testing.T) {
+	svc, tmpDir := setupLegacyWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	result, err := svc.Migrate()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.To != domain.CurrentSchemaVersion || len(result.Applied) == 0 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	defer os.RemoveAll(result.Backup)
+	if strings.HasPrefix(result.Backup, tmpDir) {
+		t.Errorf("Expected backup outside the workspace, got: %s", result.Backup)
+	}
+	if _, err := os.Stat(filepath.Join(result.Backup, "projects", "testproject", "issues.jsonl")); err != nil {
+		t.Errorf("Expected backup of project files, got: %v", err)
+	}
+
+	if err := svc.CheckSchemaVersion(); err != nil {
+		t.Errorf("Expected migrated workspace to pass check, got: %v", err)
+	}
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	issue, err := fs.NewReader(paths).ReadIssue("testproject", "testproject-issue-abc123")
+	if err != nil {
+		t.Fatalf("Failed to read issue: %v", err)
+	}
+	if issue.UpdatedBy != "bob" || issue.UpdatedAt.IsZero() {
+		t.Errorf("Expected updated_at/updated_by carried over, got: %v / %q", issue.UpdatedAt, issue.UpdatedBy)
+	}
+
+	again, err := svc.Migrate()
+	if err != nil {
+		t.Fatalf("Expected no error on second run, got: %v", err)
+	}
+	if len(again.Applied) != 0 {
+		t.Errorf("Expected nothing to apply on second run, got: %v", again.Applied)
+	}
+}
+
+func TestMigration_RestoresWorkspaceOnFailure(t *testing.T) {
+	svc, tmpDir := setupLegacyWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	// issues.jsonl migrates before tasks.jsonl fails to parse
+	projectDir := filepath.Join(tmpDir, ".mandor", "projects", "testproject")
+	if err := os.WriteFile(filepath.Join(projectDir, "tasks.jsonl"), []byte("{not json\n"), 0644); err != nil {
+		t.Fatalf("Failed to write tasks: %v", err)
+	}
+	issuesBefore, _ := os.ReadFile(filepath.Join(projectDir, "issues.jsonl"))
+
+	if _, err := svc.Migrate(); err == nil {
+		t.Fatal("Expected migration to fail on a corrupt file")
+	}
+
+	issuesAfter, _ := os.ReadFile(filepath.Join(projectDir, "issues.jsonl"))
+	if string(issuesAfter) != string(issuesBefore) {
+		t.Errorf("Expected issues.jsonl restored, got: %s", issuesAfter)
+	}
+	if err := svc.CheckSchemaVersion(); err == nil {
+		t.Error("Expected workspace to keep its old schema version")
+	}
+}
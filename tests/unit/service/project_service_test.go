@@ -1,8 +1,10 @@
 package service_test
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -255,3 +257,112 @@ func TestValidateReopenInput_NotDeleted(t *testing.T) {
 		t.Error("Expected validation error for non-deleted project")
 	}
 }
+
+func TestProjectLifecycle(t *testing.T) {
+	svc, tmpDir := setupTestProjectService(t)
+	defer os.RemoveAll(tmpDir)
+
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+	taskSvc := service.NewTaskServiceWithPaths(paths)
+	featureSvc := service.NewFeatureServiceWithPaths(paths)
+
+	featureID := "testproject-feature-abc"
+	taskID := featureID + "-task-aaaa"
+	issueID := "testproject-issue-abc"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", featureID, domain.FeatureStatusDraft)
+	writeTestTask(t, tmpDir, "testproject", taskID, domain.TaskStatusReady, nil)
+
+	issue := &domain.Issue{
+		ID:        issueID,
+		ProjectID: "testproject",
+		Name:      "Test Issue",
+		IssueType: "bug",
+		Priority:  "P2",
+		Status:    domain.IssueStatusOpen,
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	writer := fs.NewWriter(paths)
+	if err := writer.WriteIssue("testproject", issue); err != nil {
+		t.Fatalf("Failed to write issue: %v", err)
+	}
+
+	inProgress := domain.TaskStatusInProgress
+	changes, err := taskSvc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, Status: &inProgress})
+	if err != nil {
+		t.Fatalf("Failed to start task: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, ","), "project_active") {
+		t.Errorf("Expected project_active in changes, got: %v", changes)
+	}
+	project, _ := svc.GetProject("testproject")
+	if project.Status != domain.ProjectStatusActive {
+		t.Errorf("Expected project to be active, got '%s'", project.Status)
+	}
+
+	input := &domain.ProjectCompleteInput{ID: "testproject"}
+	err = svc.ValidateCompleteInput(input)
+	if err == nil || !strings.Contains(err.Error(), featureID) || !strings.Contains(err.Error(), issueID) {
+		t.Errorf("Expected open feature and issue to prevent completion, got: %v", err)
+	}
+
+	done := domain.TaskStatusDone
+	if _, err := taskSvc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, Status: &done}); err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+	if _, err := featureSvc.UpdateFeature(&domain.FeatureUpdateInput{ProjectID: "testproject", FeatureID: featureID, Complete: true}); err != nil {
+		t.Fatalf("Failed to complete feature: %v", err)
+	}
+	issue.Status = domain.IssueStatusResolved
+	if err := writer.ReplaceIssue("testproject", issue); err != nil {
+		t.Fatalf("Failed to resolve issue: %v", err)
+	}
+
+	if err := svc.ValidateCompleteInput(input); err != nil {
+		t.Fatalf("Expected project to be completable, got: %v", err)
+	}
+	if _, err := svc.CompleteProject(input); err != nil {
+		t.Fatalf("Failed to complete project: %v", err)
+	}
+	project, _ = svc.GetProject("testproject")
+	if project.Status != domain.ProjectStatusDone {
+		t.Errorf("Expected project to be done, got '%s'", project.Status)
+	}
+	if err := svc.ValidateCompleteInput(input); err == nil {
+		t.Error("Expected completing a done project to be rejected")
+	}
+
+	reopen := &domain.ProjectReopenInput{ID: "testproject", Yes: true}
+	if err := svc.ValidateReopenInput(reopen); err != nil {
+		t.Fatalf("Expected done project to be reopenable, got: %v", err)
+	}
+	if _, err := svc.ReopenProject(reopen); err != nil {
+		t.Fatalf("Failed to reopen project: %v", err)
+	}
+	project, _ = svc.GetProject("testproject")
+	if project.Status != domain.ProjectStatusActive {
+		t.Errorf("Expected reopened done project to be active, got '%s'", project.Status)
+	}
+
+	var types []string
+	reader := fs.NewReader(paths)
+	if err := reader.ReadNDJSON(paths.ProjectEventsPath("testproject"), func(raw []byte) error {
+		if strings.Contains(string(raw), `"layer":"project"`) {
+			var event domain.ProjectEvent
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return err
+			}
+			types = append(types, event.Type)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if strings.Join(types, ",") != "active,done,reopened" {
+		t.Errorf("Expected active, done and reopened project events, got: %v", types)
+	}
+}
@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/service"
+)
+
+// setupRebuildWorkspace creates a project with a feature and a task recorded
+// through the services, so every entity has a snapshot in events.jsonl.
+func setupRebuildWorkspace(t *testing.T) (*service.RebuildService, *service.TaskService, string, string) {
+	t.Helper()
+
+	featureSvc, tmpDir := setupTestFeatureService(t)
+	writeTestProjectForFeature(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+
+	feature, err := featureSvc.CreateFeature(&domain.FeatureCreateInput{
+		ProjectID: "testproject",
+		Name:      "Feature",
+		Goal:      "This is a test feature goal",
+		Priority:  "P2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create feature: %v", err)
+	}
+
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+	taskSvc := service.NewTaskServiceWithPaths(paths)
+
+	task, err := taskSvc.CreateTask(&domain.TaskCreateInput{
+		FeatureID:           feature.ID,
+		Name:                "Task",
+		Goal:                "This is a test task goal",
+		ImplementationSteps: []string{"step1"},
+		TestCases:           []string{"test1"},
+		DerivableFiles:      []string{"file1"},
+		LibraryNeeds:        []string{"lib1"},
+		Priority:            "P2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	return service.NewRebuildServiceWithPaths(paths), taskSvc, tmpDir, task.ID
+}
+
+func TestRebuild_VerifyClean(t *testing.T) {
+	svc, taskSvc, tmpDir, taskID := setupRebuildWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	priority := "P0"
+	if _, err := taskSvc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, Priority: &priority}); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	result, err := svc.Rebuild("testproject", true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.MismatchCount() != 0 {
+		t.Errorf("Expected no mismatches, got: %+v", result.Projects[0].Mismatches)
+	}
+	if result.Projects[0].Tasks != 1 || result.Projects[0].Features != 1 {
+		t.Errorf("Expected 1 feature and 1 task, got: %+v", result.Projects[0])
+	}
+}
+
+func TestRebuild_RestoresEntityFiles(t *testing.T) {
+	svc, taskSvc, tmpDir, taskID := setupRebuildWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	tasksPath := filepath.Join(tmpDir, ".mandor", "projects", "testproject", "tasks.jsonl")
+	data, _ := os.ReadFile(tasksPath)
+	if err := os.WriteFile(tasksPath, []byte(strings.Replace(string(data), `"P2"`, `"P5"`, 1)), 0644); err != nil {
+		t.Fatalf("Failed to edit tasks file: %v", err)
+	}
+
+	result, err := svc.Rebuild("testproject", true)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	mismatches := result.Projects[0].Mismatches
+	if len(mismatches) != 1 || mismatches[0].ID != taskID || mismatches[0].Reason != service.MismatchDiffers {
+		t.Fatalf("Expected task priority mismatch, got: %+v", mismatches)
+	}
+
+	if _, err := svc.Rebuild("testproject", false); err != nil {
+		t.Fatalf("Expected rebuild to succeed, got: %v", err)
+	}
+
+	detail, err := taskSvc.GetTaskDetail(&domain.TaskDetailInput{TaskID: taskID})
+	if err != nil {
+		t.Fatalf("Failed to read task: %v", err)
+	}
+	if detail.Priority != "P2" {
+		t.Errorf("Expected priority restored to P2, got: %s", detail.Priority)
+	}
+}
+
+func TestRebuild_RefusesEntitiesWithoutPayload(t *testing.T) {
+	svc, _, tmpDir, _ := setupRebuildWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Written straight to tasks.jsonl with no event, like pre-snapshot data
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-legacy", domain.TaskStatusReady, nil)
+
+	if _, err := svc.Rebuild("testproject", false); err == nil {
+		t.Error("Expected error when an entity has no events")
+	}
+}
+
+func TestRebuild_LegacyUnblockedTaskReturnsToReady(t *testing.T) {
+	svc, taskSvc, tmpDir, taskID := setupRebuildWorkspace(t)
+	defer os.RemoveAll(tmpDir)
+
+	// System events recorded before snapshots carry only their type
+	eventsPath := filepath.Join(tmpDir, ".mandor", "projects", "testproject", "events.jsonl")
+	f, err := os.OpenFile(eventsPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open events file: %v", err)
+	}
+	f.WriteString(`{"layer":"task","type":"blocked","id":"` + taskID + `","by":"system","ts":"2099-01-01T00:00:00Z"}` + "\n")
+	f.WriteString(`{"layer":"task","type":"unblocked","id":"` + taskID + `","by":"system","ts":"2099-01-01T00:00:01Z"}` + "\n")
+	f.Close()
+
+	if _, err := svc.Rebuild("testproject", false); err != nil {
+		t.Fatalf("Expected rebuild to succeed, got: %v", err)
+	}
+
+	detail, err := taskSvc.GetTaskDetail(&domain.TaskDetailInput{TaskID: taskID})
+	if err != nil {
+		t.Fatalf("Failed to read task: %v", err)
+	}
+	if detail.Status != domain.TaskStatusReady {
+		t.Errorf("Expected unblocked task to replay as ready, got: %s", detail.Status)
+	}
+}
@@ -3,8 +3,11 @@ package service_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"mandor/internal/domain"
+	"mandor/internal/fs"
 	"mandor/internal/service"
 )
 
@@ -155,3 +158,125 @@ func TestStatusWithDependencies(t *testing.T) {
 		t.Error("New workspace should have no cross-project dependencies")
 	}
 }
+
+// TestFindCyclesAcrossProjects tests that cycles spanning projects are reported
+func TestFindCyclesAcrossProjects(t *testing.T) {
+	_, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "alpha", "active")
+	writeTestProjectForTask(t, tmpDir, "beta", "active")
+	writeTestTask(t, tmpDir, "alpha", "alpha-feature-abc-task-aaaa", "blocked", []string{"beta-feature-abc-task-bbbb"})
+	writeTestTask(t, tmpDir, "beta", "beta-feature-abc-task-bbbb", "blocked", []string{"alpha-feature-abc-task-aaaa"})
+	writeTestTask(t, tmpDir, "beta", "beta-feature-abc-task-cccc", "blocked", []string{"beta-feature-abc-task-bbbb"})
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	statusSvc := service.NewStatusServiceWithPaths(paths)
+
+	cycles, err := statusSvc.FindCycles("")
+	if err != nil {
+		t.Fatalf("Failed to find cycles: %v", err)
+	}
+	if len(cycles) != 1 || len(cycles[0]) != 2 ||
+		cycles[0][0] != "alpha-feature-abc-task-aaaa" || cycles[0][1] != "beta-feature-abc-task-bbbb" {
+		t.Errorf("Expected one cross-project cycle, got: %v", cycles)
+	}
+
+	cycles, _ = statusSvc.FindCycles("alpha")
+	if len(cycles) != 1 {
+		t.Errorf("Expected cycle reported for member project, got: %v", cycles)
+	}
+}
+
+// TestFindCyclesReportsUnparseableLine tests that a corrupt entity line is an error, not skipped
+func TestFindCyclesReportsUnparseableLine(t *testing.T) {
+	_, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "alpha", "active")
+	tasksPath := filepath.Join(tmpDir, ".mandor", "projects", "alpha", "tasks.jsonl")
+	line := `{"id":"alpha-feature-abc-task-aaaa","status":"blocked","depends_on":"alpha-feature-abc-task-aaaa"}` + "\n"
+	if err := os.WriteFile(tasksPath, []byte(line), 0644); err != nil {
+		t.Fatalf("Failed to write tasks: %v", err)
+	}
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	statusSvc := service.NewStatusServiceWithPaths(paths)
+
+	if _, err := statusSvc.FindCycles(""); err == nil {
+		t.Error("Expected parse error for malformed depends_on, got nil")
+	}
+}
+
+// TestValidateNoCycleRespectsSchema tests the cycle rule in schema.json
+func TestValidateNoCycleRespectsSchema(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "alpha", "active")
+	writeTestTask(t, tmpDir, "alpha", "alpha-feature-abc-task-aaaa", "ready", nil)
+	writeTestTask(t, tmpDir, "alpha", "alpha-feature-abc-task-bbbb", "blocked", []string{"alpha-feature-abc-task-aaaa"})
+
+	deps := []string{"alpha-feature-abc-task-bbbb"}
+	input := &domain.TaskUpdateInput{TaskID: "alpha-feature-abc-task-aaaa", DependsOn: &deps}
+	err := svc.ValidateUpdateInput(input)
+	if err == nil || !strings.Contains(err.Error(), "Circular dependency detected") {
+		t.Fatalf("Expected circular dependency error, got: %v", err)
+	}
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	schema, _ := fs.NewReader(paths).ReadProjectSchema("alpha")
+	schema.Rules.Task.Cycle = domain.CycleAllowed
+	if err := fs.NewWriter(paths).WriteProjectSchema("alpha", schema); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	if err := svc.ValidateUpdateInput(input); err != nil {
+		t.Errorf("Expected cycle allowed by schema, got: %v", err)
+	}
+}
+
+// TestGetWorkspaceStatusAssignees tests the per-assignee and per-label counts of open work
+func TestGetWorkspaceStatusAssignees(t *testing.T) {
+	taskSvc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"bbbb", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"cccc", domain.TaskStatusDone, nil)
+
+	bob := "bob"
+	labels := []string{"auth"}
+	for _, id := range []string{prefix + "aaaa", prefix + "cccc"} {
+		if _, err := taskSvc.UpdateTask(&domain.TaskUpdateInput{TaskID: id, Assignee: &bob, Labels: &labels}); err != nil {
+			t.Fatalf("Failed to assign %s: %v", id, err)
+		}
+	}
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	status, err := service.NewStatusServiceWithPaths(paths).GetWorkspaceStatus("")
+	if err != nil {
+		t.Fatalf("Failed to get workspace status: %v", err)
+	}
+
+	// The done task does not count; the unassigned feature and task do
+	expected := []service.AssigneeSummary{
+		{Assignee: "bob", Tasks: 1},
+		{Assignee: "unassigned", Features: 1, Tasks: 1},
+	}
+	if len(status.Assignees) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, status.Assignees)
+	}
+	for i := range expected {
+		if status.Assignees[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected[i], status.Assignees[i])
+		}
+	}
+
+	if len(status.Labels) != 1 || status.Labels[0] != (service.LabelSummary{Label: "auth", Tasks: 1}) {
+		t.Errorf("Expected one open task labelled auth, got %v", status.Labels)
+	}
+}
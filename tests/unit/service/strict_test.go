@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"mandor/internal/domain"
+	"mandor/internal/fs"
+	"mandor/internal/service"
+)
+
+// setupStrictProject writes a strict project with one draft feature and one ready task
+func setupStrictProject(t *testing.T) (*fs.Paths, string) {
+	t.Helper()
+
+	_, tmpDir := setupTestTaskService(t)
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusDraft)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-001", domain.TaskStatusReady, nil)
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	project, err := fs.NewReader(paths).ReadProjectMetadata("testproject")
+	if err != nil {
+		t.Fatalf("Failed to read project: %v", err)
+	}
+	project.Strict = true
+	if err := fs.NewWriter(paths).WriteProjectMetadata("testproject", project); err != nil {
+		t.Fatalf("Failed to write project: %v", err)
+	}
+	return paths, tmpDir
+}
+
+func TestStrict_TaskStartRequiresActiveFeature(t *testing.T) {
+	paths, tmpDir := setupStrictProject(t)
+	defer os.RemoveAll(tmpDir)
+
+	tasks := service.NewTaskServiceWithPaths(paths)
+	inProgress := domain.TaskStatusInProgress
+	input := &domain.TaskUpdateInput{TaskID: "testproject-feature-abc-task-001", Status: &inProgress}
+
+	_, err := tasks.UpdateTask(input)
+	if err == nil || !strings.Contains(err.Error(), "Strict mode") {
+		t.Fatalf("Expected strict mode error, got: %v", err)
+	}
+
+	active := domain.FeatureStatusActive
+	features := service.NewFeatureServiceWithPaths(paths)
+	if _, err := features.UpdateFeature(&domain.FeatureUpdateInput{
+		ProjectID: "testproject", FeatureID: "testproject-feature-abc", Status: &active,
+	}); err != nil {
+		t.Fatalf("Failed to activate feature: %v", err)
+	}
+
+	if _, err := tasks.UpdateTask(input); err != nil {
+		t.Errorf("Expected task to start once feature is active, got: %v", err)
+	}
+}
+
+func TestStrict_FeatureDoneRequiresClosedTasks(t *testing.T) {
+	paths, tmpDir := setupStrictProject(t)
+	defer os.RemoveAll(tmpDir)
+
+	features := service.NewFeatureServiceWithPaths(paths)
+	if _, err := features.UpdateFeature(&domain.FeatureUpdateInput{
+		ProjectID: "testproject", FeatureID: "testproject-feature-abc", Start: true,
+	}); err != nil {
+		t.Fatalf("Failed to start feature: %v", err)
+	}
+
+	done := domain.FeatureStatusDone
+	_, err := features.UpdateFeature(&domain.FeatureUpdateInput{
+		ProjectID: "testproject", FeatureID: "testproject-feature-abc", Status: &done,
+	})
+	if err == nil || !strings.Contains(err.Error(), "testproject-feature-abc-task-001") {
+		t.Fatalf("Expected open task listed in error, got: %v", err)
+	}
+}
+
+func TestStrict_PriorityFromSchema(t *testing.T) {
+	paths, tmpDir := setupStrictProject(t)
+	defer os.RemoveAll(tmpDir)
+
+	writer := fs.NewWriter(paths)
+	schema, _ := fs.NewReader(paths).ReadProjectSchema("testproject")
+	schema.Rules.Priority.Levels = []string{"P0", "P1", "P2"}
+	if err := writer.WriteProjectSchema("testproject", schema); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	p3 := "P3"
+	tasks := service.NewTaskServiceWithPaths(paths)
+	_, err := tasks.UpdateTask(&domain.TaskUpdateInput{TaskID: "testproject-feature-abc-task-001", Priority: &p3})
+	if err == nil || !strings.Contains(err.Error(), "P0, P1, P2") {
+		t.Fatalf("Expected priority rejected with allowed levels, got: %v", err)
+	}
+}
+
+func TestStrict_IssueResolveRequiresInProgress(t *testing.T) {
+	paths, tmpDir := setupStrictProject(t)
+	defer os.RemoveAll(tmpDir)
+
+	issues := service.NewIssueServiceWithPaths(paths)
+	issue, err := issues.CreateIssue(&domain.IssueCreateInput{
+		ProjectID: "testproject",
+		Name:      "Crash",
+		Goal:      "Fix the crash on startup",
+		IssueType: "bug",
+		Priority:  "P2",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create issue: %v", err)
+	}
+
+	_, err = issues.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "testproject", IssueID: issue.ID, Resolve: true})
+	if err == nil || !strings.Contains(err.Error(), "in_progress") {
+		t.Fatalf("Expected strict mode error, got: %v", err)
+	}
+
+	if _, err := issues.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "testproject", IssueID: issue.ID, Start: true}); err != nil {
+		t.Fatalf("Failed to start issue: %v", err)
+	}
+	if _, err := issues.UpdateIssue(&domain.IssueUpdateInput{ProjectID: "testproject", IssueID: issue.ID, Resolve: true}); err != nil {
+		t.Errorf("Expected resolve from in_progress to succeed, got: %v", err)
+	}
+}
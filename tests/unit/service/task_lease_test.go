@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mandor/internal/cmd"
+	"mandor/internal/domain"
+)
+
+func TestClaimTask_PicksMostUrgentAndIsExclusive(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"bbbb", domain.TaskStatusReady, nil)
+
+	urgent := "P0"
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "bbbb", Priority: &urgent}); err != nil {
+		t.Fatalf("Failed to raise priority: %v", err)
+	}
+
+	first, err := svc.ClaimTask(&domain.TaskClaimInput{Agent: "agent-1", Lease: time.Hour})
+	if err != nil {
+		t.Fatalf("First claim failed: %v", err)
+	}
+	if first.ID != prefix+"bbbb" || first.Status != domain.TaskStatusInProgress || first.ClaimedBy != "agent-1" || first.LeaseExpiresAt == nil {
+		t.Errorf("Expected agent-1 to hold the P0 task in progress, got %s %s by '%s'", first.ID, first.Status, first.ClaimedBy)
+	}
+
+	second, err := svc.ClaimTask(&domain.TaskClaimInput{Agent: "agent-2", Lease: time.Hour})
+	if err != nil {
+		t.Fatalf("Second claim failed: %v", err)
+	}
+	if second.ID != prefix+"aaaa" {
+		t.Errorf("Expected agent-2 to get the other task, got %s", second.ID)
+	}
+
+	if _, err := svc.ClaimTask(&domain.TaskClaimInput{Agent: "agent-3", Lease: time.Hour}); err == nil {
+		t.Error("Expected claim to fail with no ready tasks left")
+	}
+
+	if _, err := svc.HeartbeatTask(&domain.TaskHeartbeatInput{TaskID: first.ID, Agent: "agent-2", Lease: time.Hour}); err == nil {
+		t.Error("Expected heartbeat from another agent to be rejected")
+	}
+	if _, err := svc.HeartbeatTask(&domain.TaskHeartbeatInput{TaskID: first.ID, Agent: "agent-1", Lease: 2 * time.Hour}); err != nil {
+		t.Errorf("Heartbeat failed: %v", err)
+	}
+
+	released, err := svc.ReleaseTask(&domain.TaskReleaseInput{TaskID: second.ID, Agent: "agent-2"})
+	if err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if released.Status != domain.TaskStatusReady || released.ClaimedBy != "" || released.LeaseExpiresAt != nil {
+		t.Errorf("Expected released task to be ready and unclaimed, got %s by '%s'", released.Status, released.ClaimedBy)
+	}
+
+	events, _ := svc.GetTaskEvents(first.ID)
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	if len(types) != 3 || types[1] != "claimed" || types[2] != "heartbeat" {
+		t.Errorf("Expected updated, claimed and heartbeat events, got %v", types)
+	}
+}
+
+func TestReapExpiredLeases(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusReady, nil)
+
+	task, err := svc.ClaimTask(&domain.TaskClaimInput{Agent: "agent-1", Lease: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	reaped, err := svc.ReapExpiredLeases()
+	if err != nil {
+		t.Fatalf("Reap failed: %v", err)
+	}
+	if len(reaped) != 1 || reaped[0] != task.ID {
+		t.Errorf("Expected %s to be reaped, got %v", task.ID, reaped)
+	}
+
+	detail, err := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: task.ID})
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if detail.Status != domain.TaskStatusReady || detail.ClaimedBy != "" {
+		t.Errorf("Expected reaped task to be ready and unclaimed, got %s by '%s'", detail.Status, detail.ClaimedBy)
+	}
+
+	events, _ := svc.GetTaskEvents(task.ID)
+	last := events[len(events)-1]
+	if last.Type != "lease_expired" || last.By != "system" {
+		t.Errorf("Expected a system lease_expired event, got %s by %s", last.Type, last.By)
+	}
+
+	if reaped, _ := svc.ReapExpiredLeases(); len(reaped) != 0 {
+		t.Errorf("Expected nothing left to reap, got %v", reaped)
+	}
+}
+
+func TestReadCommandReapsExpiredClaim(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-aaaa", domain.TaskStatusReady, nil)
+
+	task, err := svc.ClaimTask(&domain.TaskClaimInput{Agent: "agent-1", Lease: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// Any command reaps, including one that only reads
+	workspace := `{"version":"v1.0.0","schema_version":"` + domain.CurrentSchemaVersion + `"}`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".mandor", "workspace.json"), []byte(workspace), 0644); err != nil {
+		t.Fatalf("Failed to write workspace.json: %v", err)
+	}
+	t.Setenv("MANDOR_WORKSPACE", tmpDir)
+	root := cmd.NewRootCmd()
+	root.SetOut(io.Discard)
+	root.SetArgs([]string{"task", "list", "--project", "testproject"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("task list failed: %v", err)
+	}
+
+	detail, err := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: task.ID})
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	if detail.Status != domain.TaskStatusReady || detail.ClaimedBy != "" {
+		t.Errorf("Expected task list to reap the expired claim, got %s by '%s'", detail.Status, detail.ClaimedBy)
+	}
+}
@@ -3,6 +3,7 @@ package service_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -585,3 +586,890 @@ func TestTaskParseTaskID_Invalid(t *testing.T) {
 		t.Error("Expected error for invalid task ID")
 	}
 }
+
+func TestTaskUpdate_WorkspaceLocked(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-abc123", domain.TaskStatusReady, nil)
+
+	// Simulate another process holding the workspace lock
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	held, err := fs.AcquireFileLock(paths.LockPath(), time.Second)
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer fs.ReleaseFileLock(held)
+
+	t.Setenv("MANDOR_LOCK_TIMEOUT", "100ms")
+
+	name := "Updated Name"
+	_, err = svc.UpdateTask(&domain.TaskUpdateInput{
+		TaskID: "testproject-feature-abc-task-abc123",
+		Name:   &name,
+	})
+	if err == nil {
+		t.Fatal("Expected lock error while workspace is locked")
+	}
+
+	mandorErr, ok := err.(*domain.MandorError)
+	if !ok || mandorErr.Code != domain.ExitLockError {
+		t.Errorf("Expected lock error, got: %v", err)
+	}
+}
+
+func TestTaskUpdate_AtomicRewrite(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-abc123", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-def456", domain.TaskStatusReady, nil)
+
+	name := "Updated Name"
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{
+		TaskID: "testproject-feature-abc-task-abc123",
+		Name:   &name,
+	}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	tasksPath := filepath.Join(tmpDir, ".mandor", "projects", "testproject", "tasks.jsonl")
+	if _, err := os.Stat(tasksPath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Expected temporary file to be renamed away")
+	}
+
+	tasks, err := svc.ListTasks(&domain.TaskListInput{ProjectID: "testproject", IncludeDeleted: true})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	if len(tasks.Tasks) != 2 {
+		t.Errorf("Expected 2 tasks after rewrite, got: %d", len(tasks.Tasks))
+	}
+}
+
+func TestTaskUpdate_EventDiff(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-abc123", domain.TaskStatusReady, nil)
+
+	before, err := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: "testproject-feature-abc-task-abc123"})
+	if err != nil {
+		t.Fatalf("Failed to read task: %v", err)
+	}
+
+	priority := "P0"
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{
+		TaskID:   "testproject-feature-abc-task-abc123",
+		Priority: &priority,
+	}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	events, err := svc.GetTaskEvents("testproject-feature-abc-task-abc123")
+	if err != nil {
+		t.Fatalf("Failed to read events: %v", err)
+	}
+	if len(events) == 0 {
+		t.Fatal("Expected an updated event")
+	}
+
+	diff := events[len(events)-1].Diff
+	if len(diff) != 1 {
+		t.Fatalf("Expected 1 field change, got: %d", len(diff))
+	}
+	if diff[0].Field != "priority" || diff[0].From != before.Priority || diff[0].To != "P0" {
+		t.Errorf("Unexpected diff: %+v", diff[0])
+	}
+}
+
+func TestResolveTaskID_ShortID(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusDraft)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-Xy9z", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-Xa12", domain.TaskStatusReady, nil)
+
+	id, err := svc.ResolveTaskID("Xy9z")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if id != "testproject-feature-abc-task-Xy9z" {
+		t.Errorf("Expected full ID, got: %s", id)
+	}
+
+	full := "testproject-feature-abc-task-Xa12"
+	if id, _ := svc.ResolveTaskID(full); id != full {
+		t.Errorf("Expected full ID unchanged, got: %s", id)
+	}
+
+	_, err = svc.ResolveTaskID("testproject-")
+	if err == nil {
+		t.Fatal("Expected ambiguous ID error")
+	}
+	if !strings.Contains(err.Error(), "task-Xy9z") || !strings.Contains(err.Error(), "task-Xa12") {
+		t.Errorf("Expected candidates in error, got: %v", err)
+	}
+
+	// A feature ID never resolves to one of its tasks
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-one", domain.FeatureStatusDraft)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-one-task-Solo", domain.TaskStatusReady, nil)
+	if _, err := svc.ResolveTaskID("testproject-feature-one"); err == nil || !strings.Contains(err.Error(), "not a task ID") {
+		t.Errorf("Expected a feature ID to be rejected, got: %v", err)
+	}
+	// Prefixes only match whole segments
+	if id, _ := svc.ResolveTaskID("testproj"); id != "testproj" {
+		t.Errorf("Expected a partial segment prefix to match nothing, got: %s", id)
+	}
+
+	deps, err := svc.ResolveTaskIDs([]string{"Xy9z", "Xa12"})
+	if err != nil || len(deps) != 2 || deps[1] != full {
+		t.Errorf("Expected resolved dependencies, got: %v (%v)", deps, err)
+	}
+}
+
+func TestTaskPriority_ProjectLevels(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	levels, def := "critical,high,low", "high"
+	projects := service.NewProjectServiceWithPaths(paths)
+	if _, err := projects.UpdateProject(&domain.ProjectUpdateInput{ID: "testproject", PriorityLevels: &levels, PriorityDefault: &def}); err != nil {
+		t.Fatalf("Failed to update priority levels: %v", err)
+	}
+
+	input := &domain.TaskCreateInput{
+		FeatureID:           "testproject-feature-abc",
+		Name:                "Test Task",
+		Goal:                "This is a test task goal for validation",
+		ImplementationSteps: []string{"step1"},
+		TestCases:           []string{"test1"},
+		DerivableFiles:      []string{"file1"},
+		LibraryNeeds:        []string{"lib1"},
+	}
+	if err := svc.ValidateCreateInput(input); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if input.Priority != "high" {
+		t.Errorf("Expected project default priority 'high', got: %s", input.Priority)
+	}
+
+	input.Priority = "P1"
+	err := svc.ValidateCreateInput(input)
+	if err == nil || !strings.Contains(err.Error(), "critical, high, low") {
+		t.Errorf("Expected P1 rejected with project levels, got: %v", err)
+	}
+
+	writer := fs.NewWriter(paths)
+	var tasks []*domain.Task
+	for id, priority := range map[string]string{"task-aaaa": "low", "task-bbbb": "critical", "task-cccc": "high"} {
+		taskID := "testproject-feature-abc-" + id
+		writeTestTask(t, tmpDir, "testproject", taskID, domain.TaskStatusReady, nil)
+		task, _ := fs.NewReader(paths).ReadTask("testproject", taskID)
+		task.Priority = priority
+		tasks = append(tasks, task)
+	}
+	if err := writer.ReplaceTasks("testproject", tasks, nil); err != nil {
+		t.Fatalf("Failed to write tasks: %v", err)
+	}
+
+	output, err := svc.ListTasks(&domain.TaskListInput{ProjectID: "testproject", Sort: "priority", Order: "asc"})
+	if err != nil {
+		t.Fatalf("Failed to list tasks: %v", err)
+	}
+	var order []string
+	for _, task := range output.Tasks {
+		order = append(order, task.Priority)
+	}
+	if strings.Join(order, ",") != "critical,high,low" {
+		t.Errorf("Expected tasks sorted by project levels, got: %v", order)
+	}
+
+	if _, err := svc.ListTasks(&domain.TaskListInput{ProjectID: "testproject", Priority: "P3"}); err == nil {
+		t.Error("Expected priority filter outside project levels to be rejected")
+	}
+
+	bad := "urgent"
+	err = projects.ValidateUpdateInput(&domain.ProjectUpdateInput{ID: "testproject", PriorityDefault: &bad})
+	if err == nil {
+		t.Error("Expected default outside the levels to be rejected")
+	}
+}
+
+func TestUpdateTask_DependencyChangeRecomputesStatus(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-aaaa", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-bbbb", domain.TaskStatusReady, nil)
+
+	taskID := "testproject-feature-abc-task-bbbb"
+	add := []string{"testproject-feature-abc-task-aaaa"}
+	changes, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, DependsAdd: &add})
+	if err != nil {
+		t.Fatalf("Failed to add dependency: %v", err)
+	}
+	detail, _ := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: taskID})
+	if detail.Status != domain.TaskStatusBlocked {
+		t.Errorf("Expected task blocked after adding unfinished dependency, got '%s' (%v)", detail.Status, changes)
+	}
+
+	back := []string{taskID}
+	_, err = svc.UpdateTask(&domain.TaskUpdateInput{TaskID: "testproject-feature-abc-task-aaaa", DependsAdd: &back})
+	if err == nil || !strings.Contains(err.Error(), "Circular dependency detected") {
+		t.Errorf("Expected cycle rejected on update, got: %v", err)
+	}
+
+	missing := []string{"testproject-feature-abc-task-nope"}
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, DependsAdd: &missing}); err == nil {
+		t.Error("Expected unknown dependency rejected on update")
+	}
+
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, DependsRemove: &add}); err != nil {
+		t.Fatalf("Failed to remove dependency: %v", err)
+	}
+	detail, _ = svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: taskID})
+	if detail.Status != domain.TaskStatusReady {
+		t.Errorf("Expected task ready after removing last dependency, got '%s'", detail.Status)
+	}
+
+	events, _ := svc.GetTaskEvents(taskID)
+	var system []string
+	for _, event := range events {
+		if event.By == "system" {
+			system = append(system, event.Type)
+		}
+	}
+	if strings.Join(system, ",") != "blocked,ready" {
+		t.Errorf("Expected system blocked/ready events, got: %v", system)
+	}
+}
+
+func TestUpdateTask_DoneDependencyIsSatisfied(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-done", domain.TaskStatusDone, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-gone", domain.TaskStatusCancelled, nil)
+	writeTestTask(t, tmpDir, "testproject", "testproject-feature-abc-task-bbbb", domain.TaskStatusReady, nil)
+
+	taskID := "testproject-feature-abc-task-bbbb"
+	done := []string{"testproject-feature-abc-task-done"}
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, DependsAdd: &done}); err != nil {
+		t.Fatalf("Expected done dependency accepted, got: %v", err)
+	}
+	detail, _ := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: taskID})
+	if detail.Status != domain.TaskStatusReady {
+		t.Errorf("Expected task to stay ready on a done dependency, got '%s'", detail.Status)
+	}
+
+	gone := []string{"testproject-feature-abc-task-gone"}
+	_, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, DependsAdd: &gone})
+	if err == nil || !strings.Contains(err.Error(), "Dependency is cancelled") {
+		t.Errorf("Expected cancelled dependency rejected, got: %v", err)
+	}
+}
+
+func TestUpdateTask_ReopenReblocksDependents(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	doneID := "testproject-feature-abc-task-aaaa"
+	readyID := "testproject-feature-abc-task-bbbb"
+	startedID := "testproject-feature-abc-task-cccc"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", doneID, domain.TaskStatusDone, nil)
+	writeTestTask(t, tmpDir, "testproject", readyID, domain.TaskStatusReady, []string{doneID})
+	writeTestTask(t, tmpDir, "testproject", startedID, domain.TaskStatusInProgress, []string{doneID})
+
+	input := &domain.TaskUpdateInput{TaskID: doneID, Reopen: true}
+	if err := svc.ValidateUpdateInput(input); err != nil {
+		t.Fatalf("Expected done task to be reopenable, got: %v", err)
+	}
+	changes, err := svc.UpdateTask(input)
+	if err != nil {
+		t.Fatalf("Failed to reopen task: %v", err)
+	}
+
+	joined := strings.Join(changes, "\n")
+	if !strings.Contains(joined, "dependent_blocked") || !strings.Contains(joined, "warning: "+startedID) {
+		t.Errorf("Expected re-block and in_progress warning in changes, got: %v", changes)
+	}
+
+	ready, _ := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: readyID})
+	if ready.Status != domain.TaskStatusBlocked {
+		t.Errorf("Expected ready dependent to be blocked, got '%s'", ready.Status)
+	}
+	started, _ := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: startedID})
+	if started.Status != domain.TaskStatusInProgress {
+		t.Errorf("Expected in_progress dependent to stay in_progress, got '%s'", started.Status)
+	}
+
+	events, _ := svc.GetTaskEvents(readyID)
+	if len(events) == 0 || events[len(events)-1].Type != "blocked" || events[len(events)-1].By != "system" {
+		t.Errorf("Expected system blocked event for dependent, got: %+v", events)
+	}
+
+	// The reopened task's own event is written before its dependents'
+	data, _ := os.ReadFile(filepath.Join(tmpDir, ".mandor", "projects", "testproject", "events.jsonl"))
+	updated := strings.Index(string(data), `"type":"updated","id":"`+doneID+`"`)
+	blocked := strings.Index(string(data), `"type":"blocked","id":"`+readyID+`"`)
+	if updated < 0 || blocked < updated {
+		t.Errorf("Expected updated event before dependent's blocked event, got offsets %d and %d", updated, blocked)
+	}
+}
+
+func TestUpdateTask_RollsUpFeatureStatus(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+	featureSvc := service.NewFeatureServiceWithPaths(paths)
+
+	featureID := "testproject-feature-abc"
+	firstID := featureID + "-task-aaaa"
+	secondID := featureID + "-task-bbbb"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", featureID, domain.FeatureStatusDraft)
+	writeTestTask(t, tmpDir, "testproject", firstID, domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", secondID, domain.TaskStatusReady, nil)
+
+	dependent := &domain.Feature{
+		ID:        "testproject-feature-def",
+		ProjectID: "testproject",
+		Name:      "Dependent Feature",
+		Goal:      "Depends on the rolled up feature",
+		Scope:     "fullstack",
+		Priority:  "P3",
+		Status:    domain.FeatureStatusBlocked,
+		DependsOn: []string{featureID},
+		CreatedAt: time.Now().UTC(),
+		UpdatedAt: time.Now().UTC(),
+	}
+	writer := fs.NewWriter(paths)
+	if err := writer.WriteFeature("testproject", dependent); err != nil {
+		t.Fatalf("Failed to write feature: %v", err)
+	}
+	schema := domain.DefaultProjectSchema("same_project_only", "cross_project_allowed", "same_project_only")
+	schema.Rules.Rollup.AutoCompleteFeatures = true
+	if err := writer.WriteProjectSchema("testproject", &schema); err != nil {
+		t.Fatalf("Failed to write project schema: %v", err)
+	}
+
+	featureStatus := func() (string, domain.FeatureProgress) {
+		detail, err := featureSvc.GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "testproject", FeatureID: featureID})
+		if err != nil {
+			t.Fatalf("Failed to get feature: %v", err)
+		}
+		return detail.Status, detail.Progress
+	}
+
+	inProgress := domain.TaskStatusInProgress
+	changes, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: firstID, Status: &inProgress})
+	if err != nil {
+		t.Fatalf("Failed to start task: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, ","), "feature_active") {
+		t.Errorf("Expected feature_active in changes, got: %v", changes)
+	}
+	if status, progress := featureStatus(); status != domain.FeatureStatusActive || progress.Total != 2 || progress.Done != 0 {
+		t.Errorf("Expected active feature at 0/2, got '%s' %+v", status, progress)
+	}
+
+	done := domain.TaskStatusDone
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: firstID, Status: &done}); err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+	if status, progress := featureStatus(); status != domain.FeatureStatusActive || progress.Done != 1 || progress.Percent != 50 {
+		t.Errorf("Expected active feature at 1/2, got '%s' %+v", status, progress)
+	}
+
+	reason := "not needed"
+	changes, err = svc.UpdateTask(&domain.TaskUpdateInput{TaskID: secondID, Cancel: true, Reason: &reason})
+	if err != nil {
+		t.Fatalf("Failed to cancel task: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, ","), "feature_done") {
+		t.Errorf("Expected feature_done in changes, got: %v", changes)
+	}
+	if status, progress := featureStatus(); status != domain.FeatureStatusDone || progress.Total != 1 || progress.Percent != 100 {
+		t.Errorf("Expected done feature at 1/1, got '%s' %+v", status, progress)
+	}
+
+	unblocked, err := featureSvc.GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "testproject", FeatureID: dependent.ID})
+	if err != nil {
+		t.Fatalf("Failed to get dependent feature: %v", err)
+	}
+	if unblocked.Status != domain.FeatureStatusDraft {
+		t.Errorf("Expected dependent feature to be unblocked, got '%s'", unblocked.Status)
+	}
+}
+
+func TestUpdateTask_CascadeSkipsStartedDependents(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"bbbb", domain.TaskStatusInProgress, []string{prefix + "aaaa"})
+	writeTestTask(t, tmpDir, "testproject", prefix+"cccc", domain.TaskStatusBlocked, []string{prefix + "bbbb"})
+	writeTestTask(t, tmpDir, "testproject", prefix+"dddd", domain.TaskStatusBlocked, []string{prefix + "aaaa"})
+
+	reason := "out of scope"
+	changes, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Cancel: true, Reason: &reason, Cascade: true})
+	if err != nil {
+		t.Fatalf("Failed to cancel with --cascade: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, "\n"), "warning: "+prefix+"bbbb") {
+		t.Errorf("Expected a warning for the in_progress dependent, got: %v", changes)
+	}
+
+	for id, want := range map[string]string{
+		prefix + "bbbb": domain.TaskStatusInProgress,
+		prefix + "cccc": domain.TaskStatusBlocked,
+		prefix + "dddd": domain.TaskStatusCancelled,
+	} {
+		detail, _ := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: id, IncludeDeleted: true})
+		if detail.Status != want {
+			t.Errorf("Expected %s to be %s, got '%s'", id, want, detail.Status)
+		}
+	}
+
+	// The event records field names; the per-dependent lines are output only
+	events, _ := svc.GetTaskEvents(prefix + "aaaa")
+	last := events[len(events)-1]
+	if strings.Join(last.Changes, ",") != "status,reason,dependent_cancelled" {
+		t.Errorf("Expected field names only in event changes, got: %v", last.Changes)
+	}
+}
+
+func TestUpdateTask_ReopenRollsBackDoneFeature(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+	featureSvc := service.NewFeatureServiceWithPaths(paths)
+
+	featureID := "testproject-feature-abc"
+	taskID := featureID + "-task-aaaa"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", featureID, domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", taskID, domain.TaskStatusInProgress, nil)
+
+	schemaPath := filepath.Join(tmpDir, ".mandor", "projects", "testproject", "schema.json")
+	if err := os.WriteFile(schemaPath, []byte("{corrupt"), 0644); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+	done := domain.TaskStatusDone
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, Status: &done}); err == nil {
+		t.Error("Expected a corrupt schema.json to be reported by the rollup")
+	}
+
+	schema := domain.DefaultProjectSchema("same_project_only", "cross_project_allowed", "same_project_only")
+	schema.Rules.Rollup.AutoCompleteFeatures = true
+	if err := fs.NewWriter(paths).WriteProjectSchema("testproject", &schema); err != nil {
+		t.Fatalf("Failed to write project schema: %v", err)
+	}
+	writeTestTask(t, tmpDir, "testproject", taskID, domain.TaskStatusInProgress, nil)
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, Status: &done}); err != nil {
+		t.Fatalf("Failed to complete task: %v", err)
+	}
+	detail, _ := featureSvc.GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "testproject", FeatureID: featureID})
+	if detail.Status != domain.FeatureStatusDone {
+		t.Fatalf("Expected feature auto-completed, got '%s'", detail.Status)
+	}
+
+	changes, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, Reopen: true})
+	if err != nil {
+		t.Fatalf("Failed to reopen task: %v", err)
+	}
+	if !strings.Contains(strings.Join(changes, ","), "feature_active") {
+		t.Errorf("Expected feature_active in changes, got: %v", changes)
+	}
+	detail, _ = featureSvc.GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "testproject", FeatureID: featureID})
+	if detail.Status != domain.FeatureStatusActive {
+		t.Errorf("Expected feature back to active with an open task, got '%s'", detail.Status)
+	}
+}
+
+func TestUpdateTask_AllCancelledFeatureStaysOpen(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+
+	featureID := "testproject-feature-abc"
+	taskID := featureID + "-task-aaaa"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", featureID, domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", taskID, domain.TaskStatusReady, nil)
+
+	schema := domain.DefaultProjectSchema("same_project_only", "cross_project_allowed", "same_project_only")
+	schema.Rules.Rollup.AutoCompleteFeatures = true
+	if err := fs.NewWriter(paths).WriteProjectSchema("testproject", &schema); err != nil {
+		t.Fatalf("Failed to write project schema: %v", err)
+	}
+
+	reason := "not needed"
+	changes, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: taskID, Cancel: true, Reason: &reason})
+	if err != nil {
+		t.Fatalf("Failed to cancel task: %v", err)
+	}
+	if strings.Contains(strings.Join(changes, ","), "feature_done") {
+		t.Errorf("Expected no feature_done when every task is cancelled, got: %v", changes)
+	}
+	detail, _ := service.NewFeatureServiceWithPaths(paths).GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "testproject", FeatureID: featureID})
+	if detail.Status != domain.FeatureStatusActive {
+		t.Errorf("Expected feature to stay active, got '%s'", detail.Status)
+	}
+}
+
+func TestUpdateTask_CancelPolicies(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"bbbb", domain.TaskStatusBlocked, []string{prefix + "aaaa"})
+	writeTestTask(t, tmpDir, "testproject", prefix+"cccc", domain.TaskStatusBlocked, []string{prefix + "bbbb"})
+	writeTestTask(t, tmpDir, "testproject", prefix+"dddd", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"eeee", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"ffff", domain.TaskStatusReady, []string{prefix + "eeee"})
+
+	status := func(id string) (string, []string) {
+		detail, err := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: id, IncludeDeleted: true})
+		if err != nil {
+			t.Fatalf("Failed to get task %s: %v", id, err)
+		}
+		return detail.Status, detail.DependsOn
+	}
+	reason := "out of scope"
+
+	_, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Cancel: true, Reason: &reason})
+	if err == nil || !strings.Contains(err.Error(), prefix+"bbbb") {
+		t.Errorf("Expected cancel without a policy to list dependents, got: %v", err)
+	}
+
+	invalid := &domain.TaskUpdateInput{TaskID: prefix + "aaaa", Cascade: true, KeepBlocked: true, Cancel: true, Reason: &reason}
+	if err := svc.ValidateUpdateInput(invalid); err == nil {
+		t.Error("Expected combining --cascade and --keep-blocked to be rejected")
+	}
+	reopenCancel := &domain.TaskUpdateInput{TaskID: prefix + "aaaa", Reopen: true, Cancel: true, Reason: &reason, Cascade: true}
+	if err := svc.ValidateUpdateInput(reopenCancel); err == nil {
+		t.Error("Expected combining --reopen and --cancel to be rejected")
+	}
+
+	changes, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Cancel: true, Reason: &reason, Cascade: true, DryRun: true})
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	joined := strings.Join(changes, "\n")
+	if !strings.Contains(joined, prefix+"bbbb") || !strings.Contains(joined, prefix+"cccc") {
+		t.Errorf("Expected dry run to list every cascaded task, got: %v", changes)
+	}
+	if s, _ := status(prefix + "bbbb"); s != domain.TaskStatusBlocked {
+		t.Errorf("Expected dry run to leave dependents untouched, got '%s'", s)
+	}
+
+	rewire := prefix + "dddd"
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Cancel: true, Reason: &reason, Rewire: &rewire}); err != nil {
+		t.Fatalf("Failed to cancel with --rewire: %v", err)
+	}
+	if s, deps := status(prefix + "bbbb"); s != domain.TaskStatusBlocked || len(deps) != 1 || deps[0] != rewire {
+		t.Errorf("Expected dependent rewired onto %s and still blocked, got '%s' %v", rewire, s, deps)
+	}
+
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: rewire, Cancel: true, Reason: &reason, Cascade: true}); err != nil {
+		t.Fatalf("Failed to cancel with --cascade: %v", err)
+	}
+	for _, id := range []string{prefix + "bbbb", prefix + "cccc"} {
+		detail, _ := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: id, IncludeDeleted: true})
+		if detail.Status != domain.TaskStatusCancelled || detail.Reason != reason {
+			t.Errorf("Expected %s cancelled with the same reason, got '%s' (%s)", id, detail.Status, detail.Reason)
+		}
+	}
+
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "eeee", Cancel: true, Reason: &reason, KeepBlocked: true}); err != nil {
+		t.Fatalf("Failed to cancel with --keep-blocked: %v", err)
+	}
+	if s, _ := status(prefix + "ffff"); s != domain.TaskStatusBlocked {
+		t.Errorf("Expected dependent kept blocked, got '%s'", s)
+	}
+}
+
+func TestPreviewTaskUpdate(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusInProgress, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"bbbb", domain.TaskStatusBlocked, []string{prefix + "aaaa"})
+
+	done := domain.TaskStatusDone
+	name := "Renamed"
+	preview, err := svc.PreviewTaskUpdate(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Status: &done, Name: &name})
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	if preview.StatusFrom != domain.TaskStatusInProgress || preview.StatusTo != domain.TaskStatusDone {
+		t.Errorf("Expected in_progress → done, got %s → %s", preview.StatusFrom, preview.StatusTo)
+	}
+	if len(preview.Diff) != 2 {
+		t.Errorf("Expected name and status in the diff, got %v", preview.Diff)
+	}
+	if len(preview.Unblocked) != 1 || preview.Unblocked[0] != prefix+"bbbb" {
+		t.Errorf("Expected %s to be unblocked, got %v", prefix+"bbbb", preview.Unblocked)
+	}
+
+	for id, want := range map[string]string{prefix + "aaaa": domain.TaskStatusInProgress, prefix + "bbbb": domain.TaskStatusBlocked} {
+		detail, err := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: id})
+		if err != nil {
+			t.Fatalf("Failed to get task %s: %v", id, err)
+		}
+		if detail.Status != want {
+			t.Errorf("Expected preview to leave %s %s, got '%s'", id, want, detail.Status)
+		}
+	}
+	events, _ := svc.GetTaskEvents(prefix + "aaaa")
+	if len(events) != 0 {
+		t.Errorf("Expected preview to write no events, got %d", len(events))
+	}
+
+	lines, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Status: &done, DryRun: true})
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	joined := strings.Join(lines, "\n")
+	if !strings.Contains(joined, "status: in_progress → done") || !strings.Contains(joined, "would unblock: "+prefix+"bbbb") {
+		t.Errorf("Expected dry run to show the transition and the unblocked dependent, got: %v", lines)
+	}
+}
+
+func TestPreviewTaskUpdate_Rollups(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	taskID := "testproject-feature-abc-task-aaaa"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusInitial)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusDraft)
+	writeTestTask(t, tmpDir, "testproject", taskID, domain.TaskStatusReady, nil)
+
+	inProgress := domain.TaskStatusInProgress
+	preview, err := svc.PreviewTaskUpdate(&domain.TaskUpdateInput{TaskID: taskID, Status: &inProgress})
+	if err != nil {
+		t.Fatalf("Preview failed: %v", err)
+	}
+	want := []domain.StatusChange{
+		{Layer: "feature", ID: "testproject-feature-abc", From: domain.FeatureStatusDraft, To: domain.FeatureStatusActive},
+		{Layer: "project", ID: "testproject", From: domain.ProjectStatusInitial, To: domain.ProjectStatusActive},
+	}
+	if len(preview.Rollups) != len(want) {
+		t.Fatalf("Expected rollups %v, got %v", want, preview.Rollups)
+	}
+	for i := range want {
+		if preview.Rollups[i] != want[i] {
+			t.Errorf("Expected rollup %v, got %v", want[i], preview.Rollups[i])
+		}
+	}
+
+	paths, err := fs.NewPathsFromRoot(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create paths: %v", err)
+	}
+	feature, err := service.NewFeatureServiceWithPaths(paths).GetFeatureDetail(&domain.FeatureDetailInput{ProjectID: "testproject", FeatureID: "testproject-feature-abc"})
+	if err != nil {
+		t.Fatalf("Failed to get feature: %v", err)
+	}
+	if feature.Status != domain.FeatureStatusDraft {
+		t.Errorf("Expected preview to leave the feature draft, got '%s'", feature.Status)
+	}
+}
+
+func TestListTasks_AssigneeFilter(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"bbbb", domain.TaskStatusReady, nil)
+
+	alice := " alice "
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Assignee: &alice}); err != nil {
+		t.Fatalf("Failed to assign task: %v", err)
+	}
+
+	output, err := svc.ListTasks(&domain.TaskListInput{ProjectID: "testproject", Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if output.Total != 1 || output.Tasks[0].ID != prefix+"aaaa" || output.Tasks[0].Assignee != "alice" {
+		t.Errorf("Expected only the task assigned to alice, got %+v", output.Tasks)
+	}
+
+	unassign := ""
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Assignee: &unassign}); err != nil {
+		t.Fatalf("Failed to unassign task: %v", err)
+	}
+	output, _ = svc.ListTasks(&domain.TaskListInput{ProjectID: "testproject", Assignee: "alice"})
+	if output.Total != 0 {
+		t.Errorf("Expected no tasks for alice after unassigning, got %d", output.Total)
+	}
+
+	if _, err := service.ResolveAssigneeFilter("alice", true); err == nil {
+		t.Error("Expected --assignee together with --mine to be rejected")
+	}
+}
+
+func TestTaskLabels_FilterAndAllowedSet(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	prefix := "testproject-feature-abc-task-"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", prefix+"aaaa", domain.TaskStatusReady, nil)
+	writeTestTask(t, tmpDir, "testproject", prefix+"bbbb", domain.TaskStatusReady, nil)
+
+	both := []string{"auth", "api"}
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", Labels: &both}); err != nil {
+		t.Fatalf("Failed to set labels: %v", err)
+	}
+	add := []string{"api", "tech-debt"}
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "bbbb", LabelsAdd: &add}); err != nil {
+		t.Fatalf("Failed to add labels: %v", err)
+	}
+
+	count := func(labels []string, match string) int {
+		output, err := svc.ListTasks(&domain.TaskListInput{ProjectID: "testproject", Labels: labels, LabelMatch: match})
+		if err != nil {
+			t.Fatalf("ListTasks failed: %v", err)
+		}
+		return output.Total
+	}
+	if n := count([]string{"auth", "api"}, service.LabelMatchAll); n != 1 {
+		t.Errorf("Expected 1 task with auth and api, got %d", n)
+	}
+	if n := count([]string{"auth", "tech-debt"}, service.LabelMatchAny); n != 2 {
+		t.Errorf("Expected 2 tasks with auth or tech-debt, got %d", n)
+	}
+
+	remove := []string{"api"}
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", LabelsRemove: &remove}); err != nil {
+		t.Fatalf("Failed to remove label: %v", err)
+	}
+	task, err := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: prefix + "aaaa"})
+	if err != nil {
+		t.Fatalf("GetTaskDetail failed: %v", err)
+	}
+	if len(task.Labels) != 1 || task.Labels[0] != "auth" {
+		t.Errorf("Expected labels [auth], got %v", task.Labels)
+	}
+
+	paths, _ := fs.NewPathsFromRoot(tmpDir)
+	allowed := "auth,api"
+	if _, err := service.NewProjectServiceWithPaths(paths).UpdateProject(&domain.ProjectUpdateInput{ID: "testproject", AllowedLabels: &allowed}); err != nil {
+		t.Fatalf("Failed to set allowed labels: %v", err)
+	}
+	bogus := []string{"bogus"}
+	_, err = svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "aaaa", LabelsAdd: &bogus})
+	if err == nil || !strings.Contains(err.Error(), "auth, api") {
+		t.Errorf("Expected a label outside the allowed set to be rejected, got: %v", err)
+	}
+	// Labels added before the restriction are kept
+	if _, err := svc.UpdateTask(&domain.TaskUpdateInput{TaskID: prefix + "bbbb", LabelsAdd: &remove}); err != nil {
+		t.Errorf("Expected an allowed label to be accepted next to an existing one, got: %v", err)
+	}
+}
+
+func TestTaskComments_ReplyAndLast(t *testing.T) {
+	svc, tmpDir := setupTestTaskService(t)
+	defer os.RemoveAll(tmpDir)
+
+	taskID := "testproject-feature-abc-task-aaaa"
+	writeTestProjectForTask(t, tmpDir, "testproject", domain.ProjectStatusActive)
+	writeTestFeatureForTask(t, tmpDir, "testproject", "testproject-feature-abc", domain.FeatureStatusActive)
+	writeTestTask(t, tmpDir, "testproject", taskID, domain.TaskStatusReady, nil)
+
+	// No comments file yet
+	detail, err := svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: taskID})
+	if err != nil {
+		t.Fatalf("GetTaskDetail failed: %v", err)
+	}
+	if detail.CommentCount != 0 || len(detail.Comments) != 0 {
+		t.Errorf("Expected no comments, got %d", detail.CommentCount)
+	}
+
+	comment := func(body, replyTo string) (*domain.Comment, error) {
+		input := &domain.CommentInput{EntityID: taskID, Author: "agent-1", Body: body, ReplyTo: replyTo}
+		if err := svc.ValidateCommentInput(input); err != nil {
+			return nil, err
+		}
+		return svc.CommentTask(input)
+	}
+
+	if _, err := comment("   ", ""); err == nil {
+		t.Error("Expected an empty comment to be rejected")
+	}
+	first, err := comment("Started on the handler", "")
+	if err != nil {
+		t.Fatalf("Failed to add comment: %v", err)
+	}
+	if _, err := comment("Done", "comment-none"); err == nil {
+		t.Error("Expected a reply to an unknown comment to be rejected")
+	}
+	reply, err := comment("Looks good", first.ID)
+	if err != nil {
+		t.Fatalf("Failed to add reply: %v", err)
+	}
+	if reply.ReplyTo != first.ID || reply.ID == first.ID {
+		t.Errorf("Expected a new comment replying to %s, got %+v", first.ID, reply)
+	}
+
+	detail, err = svc.GetTaskDetail(&domain.TaskDetailInput{TaskID: taskID, LastComments: 1})
+	if err != nil {
+		t.Fatalf("GetTaskDetail failed: %v", err)
+	}
+	if detail.CommentCount != 2 {
+		t.Errorf("Expected 2 comments, got %d", detail.CommentCount)
+	}
+	if len(detail.Comments) != 1 || detail.Comments[0].ID != reply.ID {
+		t.Errorf("Expected only the latest comment, got %+v", detail.Comments)
+	}
+	if detail.Comments[0].Author != "agent-1" || detail.Comments[0].Layer != "task" {
+		t.Errorf("Unexpected comment: %+v", detail.Comments[0])
+	}
+}
@@ -212,3 +212,61 @@ func TestWorkspaceTimestampFormat(t *testing.T) {
 		t.Error("Timestamp is not in UTC ISO8601 format")
 	}
 }
+
+// TestWorkspaceDiscoveryFromSubdirectory tests that services find .mandor in a parent directory
+func TestWorkspaceDiscoveryFromSubdirectory(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	svc, _ := service.NewWorkspaceService()
+	if _, err := svc.InitWorkspace(""); err != nil {
+		t.Fatalf("Failed to init workspace: %v", err)
+	}
+
+	subDir := filepath.Join(tmpDir, "internal", "pkg")
+	os.MkdirAll(subDir, 0755)
+	os.Chdir(subDir)
+
+	svc, err := service.NewWorkspaceService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if _, err := svc.GetWorkspace(); err != nil {
+		t.Errorf("Expected workspace to be found from subdirectory, got: %v", err)
+	}
+
+	// init still targets the cwd rather than the parent workspace
+	initSvc, err := service.NewWorkspaceServiceForInit()
+	if err != nil {
+		t.Fatalf("Failed to create init service: %v", err)
+	}
+	if _, err := initSvc.GetWorkspace(); err == nil {
+		t.Error("Expected init service to be rooted at the cwd")
+	}
+}
+
+// TestWorkspaceFromEnv tests that MANDOR_WORKSPACE selects the workspace root
+func TestWorkspaceFromEnv(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldCwd, _ := os.Getwd()
+	defer os.Chdir(oldCwd)
+	os.Chdir(tmpDir)
+
+	svc, _ := service.NewWorkspaceService()
+	if _, err := svc.InitWorkspace(""); err != nil {
+		t.Fatalf("Failed to init workspace: %v", err)
+	}
+
+	os.Chdir(t.TempDir())
+	t.Setenv("MANDOR_WORKSPACE", tmpDir)
+
+	svc, err := service.NewWorkspaceService()
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	if _, err := svc.GetWorkspace(); err != nil {
+		t.Errorf("Expected workspace from MANDOR_WORKSPACE, got: %v", err)
+	}
+}